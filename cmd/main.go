@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"github.com/b2wdigital/restQL-golang/v4/internal/platform/conf"
 	"github.com/b2wdigital/restQL-golang/v4/internal/platform/logger"
+	"github.com/b2wdigital/restQL-golang/v4/internal/platform/plugins"
+	"github.com/b2wdigital/restQL-golang/v4/internal/platform/tracing"
 	"github.com/b2wdigital/restQL-golang/v4/internal/platform/web"
 	"github.com/b2wdigital/restQL-golang/v4/pkg/restql"
 	"github.com/pkg/errors"
@@ -48,6 +50,14 @@ func startServer() error {
 		Level:                cfg.Logging.Level,
 		Format:               cfg.Logging.Format,
 	})
+	//// =========================================================================
+	//// Tracing
+	tracer, err := newTracer(log, cfg)
+	if err != nil {
+		return err
+	}
+	tracing.SetDefault(tracer)
+
 	//// =========================================================================
 	//// Start API
 	log.Info("initializing api")
@@ -56,7 +66,14 @@ func startServer() error {
 	signal.Notify(shutdownSignal, os.Interrupt, syscall.SIGTERM)
 
 	serverCfg := cfg.HTTP.Server
-	apiHandler, err := web.API(log, cfg)
+	middlewares := plugins.NewMiddlewares(log)
+
+	apiHandler, breakers, err := web.API(log, cfg, middlewares)
+	if err != nil {
+		return err
+	}
+
+	healthHandler, err := web.Health(log, cfg, breakers, middlewares)
 	if err != nil {
 		return err
 	}
@@ -70,7 +87,7 @@ func startServer() error {
 	}
 	health := &fasthttp.Server{
 		Name:                          "health",
-		Handler:                       web.Health(log, cfg),
+		Handler:                       healthHandler,
 		TCPKeepalive:                  false,
 		ReadTimeout:                   serverCfg.ReadTimeout,
 		DisableHeaderNamesNormalizing: true,
@@ -111,6 +128,10 @@ func startServer() error {
 		defer cancel()
 		err := shutdown(timeout, log, api, health)
 
+		if tracerErr := tracer.Shutdown(timeout); tracerErr != nil {
+			log.Error("failed to export pending spans", tracerErr)
+		}
+
 		switch {
 		case sig == syscall.SIGSTOP:
 			return errors.New("integrity issue caused shutdown")
@@ -122,6 +143,40 @@ func startServer() error {
 	return nil
 }
 
+func newTracer(log restql.Logger, cfg *conf.Config) (*tracing.Tracer, error) {
+	tracingCfg := cfg.Tracing
+	if !tracingCfg.Enable {
+		return nil, nil
+	}
+
+	var exporter tracing.Exporter
+	switch tracingCfg.Exporter {
+	case "otlp", "":
+		exporter = tracing.NewOTLPExporter(tracingCfg.Endpoint, tracingCfg.ExportTimeout)
+	case "stdout":
+		exporter = tracing.NewWriterExporter(os.Stdout)
+	case "file":
+		fileExporter, err := tracing.NewFileExporter(tracingCfg.File)
+		if err != nil {
+			return nil, err
+		}
+		exporter = fileExporter
+	default:
+		return nil, errors.Errorf("unknown tracing exporter : %s", tracingCfg.Exporter)
+	}
+
+	log.Info("tracing enabled", "exporter", tracingCfg.Exporter)
+
+	return tracing.NewTracer(exporter, tracing.Options{
+		ServiceName:   tracingCfg.ServiceName,
+		BatchSize:     tracingCfg.BatchSize,
+		FlushInterval: tracingCfg.FlushInterval,
+		OnError: func(err error) {
+			log.Error("failed to export spans", err)
+		},
+	}), nil
+}
+
 func shutdown(ctx context.Context, log restql.Logger, servers ...*fasthttp.Server) error {
 	var groupErr error
 	var g errgroup.Group
@@ -79,6 +79,71 @@ func (m Match) Map(fn func(target interface{}) interface{}) Function {
 	return Match{Value: fn(m.Value), Arg: m.Arg}
 }
 
+// Operators of the Predicate function.
+const (
+	NotMatchesOperator         = "not-matches"
+	EqualsOperator             = "equals"
+	GreaterThanOperator        = "gt"
+	GreaterThanOrEqualOperator = "gte"
+	LessThanOperator           = "lt"
+	LessThanOrEqualOperator    = "lte"
+	InOperator                 = "in"
+	ContainsOperator           = "contains"
+)
+
+// Predicate is a Function that select values from the statement
+// result comparing them with the given Arg using the Operator.
+type Predicate struct {
+	Value    interface{}
+	Operator string
+	Arg      interface{}
+}
+
+// Target return the value upon which Predicate will be applied.
+func (p Predicate) Target() interface{} {
+	return p.Value
+}
+
+// Map apply the given function to the Target value
+// preserving the Predicate as wrapper.
+func (p Predicate) Map(fn func(target interface{}) interface{}) Function {
+	return Predicate{Value: fn(p.Value), Operator: p.Operator, Arg: p.Arg}
+}
+
+// Alias is a Function that returns the target value, a
+// selected field or a computed Expression, under the given
+// Name in each record of the statement result.
+type Alias struct {
+	Value interface{}
+	Name  string
+}
+
+// Target return the value upon which Alias will be applied.
+func (a Alias) Target() interface{} {
+	return a.Value
+}
+
+// Map apply the given function to the Target value
+// preserving the Alias as wrapper.
+func (a Alias) Map(fn func(target interface{}) interface{}) Function {
+	return Alias{Value: fn(a.Value), Name: a.Name}
+}
+
+// Built-in functions of the Expression.
+const (
+	CountExpression  = "count"
+	SumExpression    = "sum"
+	ConcatExpression = "concat"
+)
+
+// Expression represents a value computed from the statement
+// result by applying the built-in Function to the Args. Arguments
+// of type []string are field paths, the others are literal values.
+type Expression struct {
+	Function string
+	Args     []interface{}
+}
+
 // AsBody is a Function that define a `with`
 // parameter as the request body for statements
 // using to, into or patch methods.
@@ -113,3 +178,43 @@ func (f Flatten) Target() interface{} {
 func (f Flatten) Map(fn func(target interface{}) interface{}) Function {
 	return Flatten{Value: fn(f.Value)}
 }
+
+// CustomFunction is a Function provided by a
+// plugin, encoding the target value in the way
+// defined by the plugin registered with the Name.
+type CustomFunction struct {
+	Name  string
+	Value interface{}
+}
+
+// Target return the value upon which CustomFunction will be applied.
+func (cf CustomFunction) Target() interface{} {
+	return cf.Value
+}
+
+// Map apply the given function to the Target value
+// preserving the CustomFunction as wrapper.
+func (cf CustomFunction) Map(fn func(target interface{}) interface{}) Function {
+	return CustomFunction{Name: cf.Name, Value: fn(cf.Value)}
+}
+
+// Batch is a Function that groups the values of a list
+// parameter in chunks of the given Size, making one request
+// per chunk instead of one per value. The Key is the field
+// of the response items used to match them with the values.
+type Batch struct {
+	Value interface{}
+	Size  int
+	Key   string
+}
+
+// Target return the value upon which Batch will be applied.
+func (b Batch) Target() interface{} {
+	return b.Value
+}
+
+// Map apply the given function to the Target value
+// preserving the Batch as wrapper.
+func (b Batch) Map(fn func(target interface{}) interface{}) Function {
+	return Batch{Value: fn(b.Value), Size: b.Size, Key: b.Key}
+}
@@ -11,6 +11,11 @@ import (
 // the timeout defined in HTTPRequest.
 var ErrRequestTimeout = errors.New("request timed out")
 
+// ErrCircuitOpen is the error returned by HTTPClient
+// when a HTTP call is not made because the circuit
+// breaker of the target resource is open.
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
 // ErrMappingsNotFound is the error returned when
 // the resource mappings is not found anywhere
 var ErrMappingsNotFound = errors.New("mappings not found")
@@ -40,22 +45,33 @@ type Headers map[string]string
 type Body interface{}
 
 // HTTPRequest describe a HTTP call to be made by HTTPClient.
+// Resource is the name of the mapping used to build it.
 type HTTPRequest struct {
-	Method  string
-	Schema  string
-	Host    string
-	Path    string
-	Query   map[string]interface{}
-	Body    Body
-	Headers Headers
-	Timeout time.Duration
+	Resource string
+	Method   string
+	Schema   string
+	Host     string
+	Path     string
+	Query    map[string]interface{}
+	Body     Body
+	Headers  Headers
+	Timeout  time.Duration
 }
 
+// Statuses of the upstream response cache lookup.
+const (
+	CacheHit  = "HIT"
+	CacheMiss = "MISS"
+)
+
 // HTTPResponse describe a HTTP response returned by HTTPClient.
+// CacheStatus is set when the response cache is enabled for the
+// resource, telling if the response was served from it.
 type HTTPResponse struct {
-	URL        string
-	StatusCode int
-	Body       Body
-	Headers    Headers
-	Duration   time.Duration
+	URL         string
+	StatusCode  int
+	Body        Body
+	Headers     Headers
+	Duration    time.Duration
+	CacheStatus string
 }
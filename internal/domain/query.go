@@ -2,6 +2,7 @@ package domain
 
 import (
 	"fmt"
+	"time"
 )
 
 // Methods available to be used in query statements.
@@ -16,25 +17,74 @@ const (
 // Query is the internal representation of the restQL language.
 type Query struct {
 	Use        Modifiers
+	Params     []ParamDeclaration
 	Statements []Statement
 }
 
 // Modifiers is the internal representation of the `use` clause.
 type Modifiers map[string]interface{}
 
+// Behaviours available to the `on-timeout` modifier.
+const (
+	OnTimeoutFail    string = "fail"
+	OnTimeoutPartial        = "partial"
+)
+
+// Param types available in the `params` clause.
+const (
+	StringParamType  string = "string"
+	IntParamType            = "int"
+	FloatParamType          = "float"
+	BooleanParamType        = "boolean"
+	ObjectParamType         = "object"
+)
+
+// ParamDeclaration is the internal representation of
+// a parameter declared in the `params` clause.
+type ParamDeclaration struct {
+	Name     string
+	Type     string
+	List     bool
+	Required bool
+	Default  interface{}
+}
+
 // Statement is the internal representation of a query statement.
 type Statement struct {
 	Method       string
 	Resource     string
 	Alias        string
 	In           []string
+	Join         *Join
 	Headers      map[string]interface{}
 	Timeout      interface{}
 	With         Params
 	Only         []interface{}
 	Hidden       bool
 	CacheControl CacheControl
+	Retry        *RetryPolicy
+	Paginate     *Pagination
+	When         *Condition
 	IgnoreErrors bool
+	Batched      *BatchChunk
+}
+
+// BatchChunk holds the values of a `batch` function sent in
+// a single request, used to split its response per value.
+type BatchChunk struct {
+	Values []interface{}
+	Key    string
+}
+
+// Join is the internal representation of the `on` clause of an
+// `in` aggregation. The origin items are set on the target items
+// whose Target field value equals their Origin field value. With
+// Many all matching origin items are set as a list, otherwise only
+// the first one is.
+type Join struct {
+	Target []string
+	Origin []string
+	Many   bool
 }
 
 // Params is the internal representation of the `with` clause.
@@ -49,6 +99,50 @@ type CacheControl struct {
 	SMaxAge interface{}
 }
 
+// RetryPolicy is the internal representation of the `retry` clause.
+// It is also used to define the default policy of a mapping.
+type RetryPolicy struct {
+	Attempts    int
+	Backoff     time.Duration
+	Jitter      time.Duration
+	StatusCodes []int
+}
+
+// DefaultMaxPages is the number of pages requested
+// when the `paginate` clause does not define a maximum.
+const DefaultMaxPages = 10
+
+// Pagination is the internal representation of the `paginate` clause.
+// Only one of Next, the body path of the next page URL, Link, the
+// relation of the next page in the Link header, or Page and Offset,
+// the names of the counter parameters, defines how pages are followed.
+// Items is the body path of the page items and MaxPages limits the
+// number of pages requested.
+type Pagination struct {
+	Next     string
+	Link     string
+	Page     string
+	Offset   string
+	Items    string
+	MaxPages int
+}
+
+// Condition operators available in the `when` clause.
+const (
+	EqualOperator    string = "=="
+	NotEqualOperator        = "!="
+)
+
+// Condition is the internal representation of the `when` clause.
+// Left and Right can be a primitive value, a Variable or a Chain.
+// When no Operator is defined only the Left value is evaluated.
+type Condition struct {
+	Negated  bool
+	Left     interface{}
+	Operator string
+	Right    interface{}
+}
+
 // Variable is the internal representation of a variable parameter value.
 type Variable struct {
 	Target string
@@ -1,5 +1,10 @@
 package domain
 
+import (
+	"fmt"
+	"strconv"
+)
+
 // ResourceID is an unique identifier used by a statement.
 // If an alias is present, it is used. Otherwise, the resource
 // name.
@@ -70,11 +75,34 @@ type Details struct {
 	Debug        *Debugging
 }
 
+// ErrorKind represents the reason of a failed statement execution.
+type ErrorKind string
+
+// Error kinds of a failed statement execution.
+const (
+	RequestErrorKind     ErrorKind = "request"
+	TimeoutErrorKind     ErrorKind = "timeout"
+	CircuitOpenErrorKind ErrorKind = "circuit-open"
+	CancelledErrorKind   ErrorKind = "cancelled"
+	FunctionErrorKind    ErrorKind = "function"
+)
+
 // DoneResource represents a statement result.
+// Skipped is true when the statement was not
+// executed due to its `when` clause or was
+// cancelled by the failure of another statement.
+// Attempts is the number of HTTP calls made
+// to the upstream dependency, including retries.
+// ErrorKind classifies the failure of the statement.
+// CacheStatus tells if the upstream response was served
+// from the response cache.
+// Pages holds the calls made for a paginated statement.
 type DoneResource struct {
 	Status          int
 	Success         bool
 	IgnoreErrors    bool
+	Skipped         bool
+	ErrorKind       ErrorKind
 	CacheControl    ResourceCacheControl
 	Method          string
 	URL             string
@@ -84,7 +112,33 @@ type DoneResource struct {
 	ResponseHeaders map[string]string
 	ResponseBody    interface{}
 	ResponseTime    int64
+	Attempts        int
+	CacheStatus     string
+	Pages           []PageDetails
+}
+
+// PageDetails describes the call made
+// for a page of a paginated statement.
+type PageDetails struct {
+	URL          string
+	Status       int
+	ResponseTime int64
+	Attempts     int
+	Items        int
 }
 
 // DoneResources represents a multiplexed statement result.
 type DoneResources []interface{}
+
+// ValueKey normalizes a response body value so it can be
+// compared with others, as numbers decoded from JSON are floats.
+func ValueKey(value interface{}) string {
+	switch value := value.(type) {
+	case string:
+		return value
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	default:
+		return fmt.Sprintf("%v", value)
+	}
+}
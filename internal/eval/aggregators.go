@@ -4,7 +4,9 @@ import "github.com/b2wdigital/restQL-golang/v4/internal/domain"
 
 // ApplyAggregators resolves the `in` keyword in the query,
 // taking values from one statement result than setting it
-// on target statement result.
+// on target statement result. By default list results are
+// matched by position, while statements with the `on` clause
+// match them by field values.
 func ApplyAggregators(query domain.Query, resources domain.Resources) domain.Resources {
 	for _, stmt := range query.Statements {
 		if len(stmt.In) == 0 {
@@ -16,17 +18,40 @@ func ApplyAggregators(query domain.Query, resources domain.Resources) domain.Res
 
 		originResourceID := domain.NewResourceID(stmt)
 		originResource := resources[originResourceID]
+		if isSkipped(originResource) {
+			continue
+		}
 
 		targetResourceID := domain.ResourceID(target)
 		targetResource := resources[targetResourceID]
 
-		aggregateOriginOnTarget(path, originResource, targetResource)
+		if stmt.Join != nil && len(path) > 0 {
+			joinOriginOnTarget(path, *stmt.Join, originResource, targetResource)
+		} else {
+			aggregateOriginOnTarget(path, originResource, targetResource)
+		}
 		resources[originResourceID] = cleanOriginResult(originResource)
 	}
 
 	return resources
 }
 
+func isSkipped(resource interface{}) bool {
+	switch resource := resource.(type) {
+	case domain.DoneResource:
+		return resource.Skipped
+	case domain.DoneResources:
+		for _, r := range resource {
+			if !isSkipped(r) {
+				return false
+			}
+		}
+		return len(resource) > 0
+	default:
+		return false
+	}
+}
+
 func aggregateOriginOnTarget(path []string, origin interface{}, target interface{}) {
 	switch target := target.(type) {
 	case domain.DoneResource:
@@ -72,6 +97,136 @@ func aggregateOriginOnListTarget(path []string, origin interface{}, target []int
 	}
 }
 
+// joinOriginOnTarget sets on each target item the origin items
+// whose join field matches the target item join field. Target items
+// without matches receive null or, when joining many, an empty list.
+func joinOriginOnTarget(path []string, join domain.Join, origin interface{}, target interface{}) {
+	index := make(map[string][]interface{})
+	indexOriginItems(join.Origin, origin, index)
+
+	field := path[len(path)-1]
+	for _, item := range collectJoinTargets(path[:len(path)-1], target) {
+		key, found := getJoinValue(join.Target, item)
+		if !found {
+			item[field] = matchJoinKey(join, index, nil)
+			continue
+		}
+
+		if keys, ok := key.([]interface{}); ok {
+			if join.Many {
+				matches := []interface{}{}
+				for _, k := range keys {
+					matches = append(matches, index[domain.ValueKey(k)]...)
+				}
+				item[field] = matches
+				continue
+			}
+
+			matches := make([]interface{}, len(keys))
+			for i, k := range keys {
+				matches[i] = matchJoinKey(join, index, k)
+			}
+			item[field] = matches
+			continue
+		}
+
+		item[field] = matchJoinKey(join, index, key)
+	}
+}
+
+func matchJoinKey(join domain.Join, index map[string][]interface{}, key interface{}) interface{} {
+	var matches []interface{}
+	if key != nil {
+		matches = index[domain.ValueKey(key)]
+	}
+
+	if join.Many {
+		if matches == nil {
+			return []interface{}{}
+		}
+		return matches
+	}
+
+	if len(matches) == 0 {
+		return nil
+	}
+	return matches[0]
+}
+
+func indexOriginItems(path []string, origin interface{}, index map[string][]interface{}) {
+	switch origin := origin.(type) {
+	case domain.DoneResource:
+		if origin.Success {
+			indexOriginItems(path, origin.ResponseBody, index)
+		}
+	case domain.DoneResources:
+		for _, o := range origin {
+			indexOriginItems(path, o, index)
+		}
+	case []interface{}:
+		for _, o := range origin {
+			indexOriginItems(path, o, index)
+		}
+	case map[string]interface{}:
+		key, found := getJoinValue(path, origin)
+		if !found || key == nil {
+			return
+		}
+
+		k := domain.ValueKey(key)
+		index[k] = append(index[k], origin)
+	}
+}
+
+func collectJoinTargets(path []string, target interface{}) []map[string]interface{} {
+	switch target := target.(type) {
+	case domain.DoneResource:
+		return collectJoinTargets(path, target.ResponseBody)
+	case domain.DoneResources:
+		var result []map[string]interface{}
+		for _, t := range target {
+			result = append(result, collectJoinTargets(path, t)...)
+		}
+		return result
+	case []interface{}:
+		var result []map[string]interface{}
+		for _, t := range target {
+			result = append(result, collectJoinTargets(path, t)...)
+		}
+		return result
+	case map[string]interface{}:
+		if len(path) == 0 {
+			return []map[string]interface{}{target}
+		}
+
+		next, found := target[path[0]]
+		if !found {
+			return nil
+		}
+		return collectJoinTargets(path[1:], next)
+	default:
+		return nil
+	}
+}
+
+func getJoinValue(path []string, item interface{}) (interface{}, bool) {
+	if len(path) == 0 {
+		return item, true
+	}
+
+	m, ok := item.(map[string]interface{})
+	if !ok {
+		return nil, false
+	}
+
+	value, found := m[path[0]]
+	if !found {
+		return nil, false
+	}
+
+	return getJoinValue(path[1:], value)
+}
+
 func setOriginOnTarget(field string, origin interface{}, target interface{}) {
 	switch target := target.(type) {
 	case domain.DoneResource:
@@ -180,6 +180,59 @@ func TestApplyAggregators(t *testing.T) {
 				"sidekick": domain.DoneResource{ResponseBody: nil},
 			},
 		},
+		{
+			"should join list resources by field value",
+			domain.Query{Statements: []domain.Statement{
+				{Resource: "hero"},
+				{Resource: "weapon", In: []string{"hero", "weapon"}, Join: &domain.Join{Target: []string{"weaponId"}, Origin: []string{"id"}}},
+			}},
+			domain.Resources{
+				"hero":   domain.DoneResource{ResponseBody: test.Unmarshal(`[{ "name": "batman", "weaponId": 2 }, { "name": "robin", "weaponId": 1 }, { "name": "alfred", "weaponId": 3 }]`)},
+				"weapon": domain.DoneResource{Success: true, ResponseBody: test.Unmarshal(`[{ "id": 1, "name": "staff" }, { "id": 2, "name": "batarang" }]`)},
+			},
+			domain.Resources{
+				"hero":   domain.DoneResource{ResponseBody: test.Unmarshal(`[{ "name": "batman", "weaponId": 2, "weapon": { "id": 2, "name": "batarang" } }, { "name": "robin", "weaponId": 1, "weapon": { "id": 1, "name": "staff" } }, { "name": "alfred", "weaponId": 3, "weapon": null }]`)},
+				"weapon": domain.DoneResource{Success: true, ResponseBody: nil},
+			},
+		},
+		{
+			"should join many multiplexed resources by field value",
+			domain.Query{Statements: []domain.Statement{
+				{Resource: "hero"},
+				{Resource: "weapon", In: []string{"hero", "weapons"}, Join: &domain.Join{Target: []string{"id"}, Origin: []string{"owner", "id"}, Many: true}},
+			}},
+			domain.Resources{
+				"hero": domain.DoneResource{ResponseBody: test.Unmarshal(`[{ "id": 1, "name": "batman" }, { "id": 2, "name": "alfred" }]`)},
+				"weapon": domain.DoneResources{
+					domain.DoneResource{Success: true, ResponseBody: test.Unmarshal(`{ "name": "batarang", "owner": { "id": 1 } }`)},
+					domain.DoneResource{Success: false, ResponseBody: test.Unmarshal(`{ "owner": { "id": 2 } }`)},
+					domain.DoneResource{Success: true, ResponseBody: test.Unmarshal(`{ "name": "grapnel", "owner": { "id": 1 } }`)},
+				},
+			},
+			domain.Resources{
+				"hero": domain.DoneResource{ResponseBody: test.Unmarshal(`[{ "id": 1, "name": "batman", "weapons": [{ "name": "batarang", "owner": { "id": 1 } }, { "name": "grapnel", "owner": { "id": 1 } }] }, { "id": 2, "name": "alfred", "weapons": [] }]`)},
+				"weapon": domain.DoneResources{
+					domain.DoneResource{Success: true, ResponseBody: nil},
+					domain.DoneResource{Success: false, ResponseBody: nil},
+					domain.DoneResource{Success: true, ResponseBody: nil},
+				},
+			},
+		},
+		{
+			"should join each value of a list field",
+			domain.Query{Statements: []domain.Statement{
+				{Resource: "hero"},
+				{Resource: "weapon", In: []string{"hero", "weapons"}, Join: &domain.Join{Target: []string{"weaponIds"}, Origin: []string{"id"}}},
+			}},
+			domain.Resources{
+				"hero":   domain.DoneResource{ResponseBody: test.Unmarshal(`{ "name": "batman", "weaponIds": ["b", "a", "c"] }`)},
+				"weapon": domain.DoneResource{Success: true, ResponseBody: test.Unmarshal(`[{ "id": "a" }, { "id": "b" }]`)},
+			},
+			domain.Resources{
+				"hero":   domain.DoneResource{ResponseBody: test.Unmarshal(`{ "name": "batman", "weaponIds": ["b", "a", "c"], "weapons": [{ "id": "b" }, { "id": "a" }, null] }`)},
+				"weapon": domain.DoneResource{Success: true, ResponseBody: nil},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,114 @@
+package eval
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/b2wdigital/restQL-golang/v4/internal/domain"
+	"github.com/b2wdigital/restQL-golang/v4/internal/runner"
+	"github.com/pkg/errors"
+)
+
+// ValidateDependencies builds the dependency graph of the query
+// statements using the chained values present in the `with`
+// values and body, `headers` and `when` clauses.
+// If a chain targets an unknown statement or the statements
+// depend on each other in a cycle, a ValidationError naming
+// every statement involved is returned.
+func ValidateDependencies(query domain.Query) error {
+	ids, graph := dependencyGraph(query)
+
+	var problems []string
+	for _, id := range ids {
+		for _, dependency := range graph[id] {
+			if _, found := graph[dependency]; !found {
+				problems = append(problems, fmt.Sprintf("statement %s references unknown statement %s", id, dependency))
+			}
+		}
+	}
+
+	for _, cycle := range findCycles(ids, graph) {
+		problems = append(problems, fmt.Sprintf("statements %s form a dependency cycle", strings.Join(cycle, " -> ")))
+	}
+
+	if len(problems) > 0 {
+		err := errors.Errorf("invalid statement dependencies: %s", strings.Join(problems, ", "))
+		return ValidationError{Err: err}
+	}
+
+	return nil
+}
+
+func dependencyGraph(query domain.Query) ([]string, map[string][]string) {
+	ids := make([]string, 0, len(query.Statements))
+	graph := make(map[string][]string, len(query.Statements))
+
+	for _, stmt := range query.Statements {
+		id := string(domain.NewResourceID(stmt))
+		dependencies := runner.StatementDependencies(stmt)
+
+		targets := make([]string, len(dependencies))
+		for i, d := range dependencies {
+			targets[i] = string(d)
+		}
+
+		ids = append(ids, id)
+		graph[id] = targets
+	}
+
+	return ids, graph
+}
+
+const (
+	unvisited = iota
+	visiting
+	visited
+)
+
+func findCycles(ids []string, graph map[string][]string) [][]string {
+	var cycles [][]string
+	state := make(map[string]int, len(ids))
+	var path []string
+
+	var visit func(id string)
+	visit = func(id string) {
+		state[id] = visiting
+		path = append(path, id)
+
+		for _, dependency := range graph[id] {
+			if _, found := graph[dependency]; !found {
+				continue
+			}
+
+			switch state[dependency] {
+			case unvisited:
+				visit(dependency)
+			case visiting:
+				cycles = append(cycles, makeCycle(path, dependency))
+			}
+		}
+
+		path = path[:len(path)-1]
+		state[id] = visited
+	}
+
+	for _, id := range ids {
+		if state[id] == unvisited {
+			visit(id)
+		}
+	}
+
+	return cycles
+}
+
+func makeCycle(path []string, start string) []string {
+	var cycle []string
+	for i, id := range path {
+		if id == start {
+			cycle = append(cycle, path[i:]...)
+			break
+		}
+	}
+
+	return append(cycle, start)
+}
@@ -0,0 +1,99 @@
+package eval_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/b2wdigital/restQL-golang/v4/internal/domain"
+	"github.com/b2wdigital/restQL-golang/v4/internal/eval"
+	"github.com/b2wdigital/restQL-golang/v4/test"
+)
+
+func TestValidateDependencies(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    domain.Query
+		expected string
+	}{
+		{
+			"accept statements without chained values",
+			domain.Query{Statements: []domain.Statement{{Method: "from", Resource: "hero"}, {Method: "from", Resource: "sidekick"}}},
+			"",
+		},
+		{
+			"accept chains targeting known statements",
+			domain.Query{Statements: []domain.Statement{
+				{Method: "from", Resource: "hero", Alias: "h"},
+				{Method: "from", Resource: "sidekick", With: domain.Params{Values: map[string]interface{}{"id": domain.Chain{"h", "sidekick", "id"}}}},
+				{Method: "from", Resource: "villain", Headers: map[string]interface{}{"X-Hero": domain.Chain{"h", "id"}}, With: domain.Params{Values: map[string]interface{}{"id": []interface{}{domain.Chain{"sidekick", "villain"}}}}},
+			}},
+			"",
+		},
+		{
+			"reject chain targeting unknown statement",
+			domain.Query{Statements: []domain.Statement{
+				{Method: "from", Resource: "hero"},
+				{Method: "from", Resource: "sidekick", With: domain.Params{Values: map[string]interface{}{"id": domain.Chain{"heroes", "id"}}}},
+			}},
+			"invalid statement dependencies: statement sidekick references unknown statement heroes",
+		},
+		{
+			"reject body chain targeting unknown statement",
+			domain.Query{Statements: []domain.Statement{
+				{Method: "from", Resource: "hero"},
+				{Method: "to", Resource: "sidekick", With: domain.Params{Body: map[string]interface{}{"hero": domain.Chain{"heroes", "id"}}}},
+			}},
+			"invalid statement dependencies: statement sidekick references unknown statement heroes",
+		},
+		{
+			"reject cycle through body chain",
+			domain.Query{Statements: []domain.Statement{
+				{Method: "to", Resource: "a", With: domain.Params{Body: domain.Chain{"b", "id"}}},
+				{Method: "from", Resource: "b", With: domain.Params{Values: map[string]interface{}{"y": domain.Chain{"a", "id"}}}},
+			}},
+			"invalid statement dependencies: statements a -> b -> a form a dependency cycle",
+		},
+		{
+			"reject statements with cyclic dependency",
+			domain.Query{Statements: []domain.Statement{
+				{Method: "from", Resource: "a", With: domain.Params{Values: map[string]interface{}{"x": domain.Chain{"b", "id"}}}},
+				{Method: "from", Resource: "b", With: domain.Params{Values: map[string]interface{}{"y": domain.Chain{"a", "id"}}}},
+			}},
+			"invalid statement dependencies: statements a -> b -> a form a dependency cycle",
+		},
+		{
+			"reject statement depending on itself",
+			domain.Query{Statements: []domain.Statement{
+				{Method: "from", Resource: "hero", When: &domain.Condition{Left: domain.Chain{"hero", "active"}}},
+			}},
+			"invalid statement dependencies: statements hero -> hero form a dependency cycle",
+		},
+		{
+			"report every invalid dependency",
+			domain.Query{Statements: []domain.Statement{
+				{Method: "from", Resource: "a", With: domain.Params{Values: map[string]interface{}{"x": domain.Chain{"c", "id"}}}},
+				{Method: "from", Resource: "b", With: domain.Params{Values: map[string]interface{}{"y": domain.Chain{"d", "id"}}}},
+				{Method: "from", Resource: "c", Headers: map[string]interface{}{"z": domain.Chain{"a", "id"}}},
+			}},
+			"invalid statement dependencies: statement b references unknown statement d, statements a -> c -> a form a dependency cycle",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := eval.ValidateDependencies(tt.query)
+
+			if tt.expected == "" {
+				test.VerifyError(t, err)
+				return
+			}
+
+			var ve eval.ValidationError
+			if !errors.As(err, &ve) {
+				t.Fatalf("expected a ValidationError, got: %v", err)
+			}
+
+			test.Equal(t, err.Error(), tt.expected)
+		})
+	}
+}
@@ -23,6 +23,8 @@ type QueryReader interface {
 //• Query name: is an empty string or is not present
 //• Revision: is not a positive integer
 //• Tenant: is an empty string or is not present
+//• Params: a declared parameter is missing or has a value of the wrong type
+//• Dependencies: a chained value targets an unknown statement or statements depend on each other in a cycle
 type ValidationError struct {
 	Err error
 }
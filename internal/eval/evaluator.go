@@ -3,10 +3,12 @@ package eval
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/b2wdigital/restQL-golang/v4/internal/domain"
 	"github.com/b2wdigital/restQL-golang/v4/internal/parser"
 	"github.com/b2wdigital/restQL-golang/v4/internal/platform/plugins"
+	"github.com/b2wdigital/restQL-golang/v4/internal/platform/tracing"
 	"github.com/b2wdigital/restQL-golang/v4/internal/runner"
 	"github.com/b2wdigital/restQL-golang/v4/pkg/restql"
 	"github.com/pkg/errors"
@@ -29,10 +31,12 @@ type Evaluator struct {
 	queryReader    QueryReader
 	runner         runner.Runner
 	lifecycle      plugins.Lifecycle
+	resultCache    *ResultCache
 }
 
 // NewEvaluator constructs an instance of the restQL interpreter.
-func NewEvaluator(log restql.Logger, mr MappingsReader, qr QueryReader, r runner.Runner, p parser.Parser, l plugins.Lifecycle) Evaluator {
+// The result cache is optional and only used by saved queries.
+func NewEvaluator(log restql.Logger, mr MappingsReader, qr QueryReader, r runner.Runner, p parser.Parser, l plugins.Lifecycle, rc *ResultCache) Evaluator {
 	return Evaluator{
 		log:            log,
 		mappingsReader: mr,
@@ -40,6 +44,7 @@ func NewEvaluator(log restql.Logger, mr MappingsReader, qr QueryReader, r runner
 		runner:         r,
 		parser:         p,
 		lifecycle:      l,
+		resultCache:    rc,
 	}
 }
 
@@ -50,87 +55,240 @@ func (e Evaluator) AdHocQuery(ctx context.Context, queryTxt string, queryOpts re
 		return nil, ValidationError{ErrInvalidTenant}
 	}
 
-	return e.evaluateQuery(ctx, queryTxt, queryOpts, queryInput)
+	resources, _, err := e.evaluateQuery(ctx, queryTxt, queryOpts, queryInput, nil)
+	return resources, err
+}
+
+// StreamAdHocQuery executes an ad-hoc query like AdHocQuery,
+// notifying the listener of each statement result as soon
+// as it is available.
+func (e Evaluator) StreamAdHocQuery(ctx context.Context, queryTxt string, queryOpts restql.QueryOptions, queryInput restql.QueryInput, listener StatementListener) (domain.Resources, error) {
+	if queryOpts.Tenant == "" {
+		return nil, ValidationError{ErrInvalidTenant}
+	}
+
+	resources, _, err := e.evaluateQuery(ctx, queryTxt, queryOpts, queryInput, listener)
+	return resources, err
+}
+
+// SavedQueryInfo tells if a saved query was found and if
+// its result was served from the result cache, in which
+// case CacheAge is for how long it has been stored.
+type SavedQueryInfo struct {
+	Resolved bool
+	CacheHit bool
+	CacheAge time.Duration
 }
 
 // SavedQuery executes a saved query identified by namespace,
 // id and revision with the options and HTTP information
-// send by the client.
-func (e Evaluator) SavedQuery(ctx context.Context, queryOpts restql.QueryOptions, queryInput restql.QueryInput) (domain.Resources, error) {
+// send by the client. When the result cache is enabled the
+// result may be served from it, as told by the SavedQueryInfo.
+func (e Evaluator) SavedQuery(ctx context.Context, queryOpts restql.QueryOptions, queryInput restql.QueryInput) (domain.Resources, SavedQueryInfo, error) {
+	queryTxt, err := e.fetchSavedQuery(ctx, queryOpts)
+	if err != nil {
+		return nil, SavedQueryInfo{}, err
+	}
+
+	resources, info, err := e.evaluateQuery(ctx, queryTxt, queryOpts, queryInput, nil)
+	info.Resolved = true
+
+	return resources, info, err
+}
+
+// StreamSavedQuery executes a saved query like SavedQuery,
+// notifying the listener of each statement result as soon
+// as it is available.
+func (e Evaluator) StreamSavedQuery(ctx context.Context, queryOpts restql.QueryOptions, queryInput restql.QueryInput, listener StatementListener) (domain.Resources, SavedQueryInfo, error) {
+	queryTxt, err := e.fetchSavedQuery(ctx, queryOpts)
+	if err != nil {
+		return nil, SavedQueryInfo{}, err
+	}
+
+	resources, _, err := e.evaluateQuery(ctx, queryTxt, queryOpts, queryInput, listener)
+	return resources, SavedQueryInfo{Resolved: true}, err
+}
+
+// ExplainAdHocQuery builds the execution plan of an ad-hoc
+// query send by the client without executing it.
+func (e Evaluator) ExplainAdHocQuery(ctx context.Context, queryTxt string, queryOpts restql.QueryOptions, queryInput restql.QueryInput) (runner.Plan, error) {
+	if queryOpts.Tenant == "" {
+		return runner.Plan{}, ValidationError{ErrInvalidTenant}
+	}
+
+	return e.explainQuery(ctx, queryTxt, queryOpts, queryInput)
+}
+
+// ExplainSavedQuery builds the execution plan of a saved query
+// identified by namespace, id and revision without executing it.
+func (e Evaluator) ExplainSavedQuery(ctx context.Context, queryOpts restql.QueryOptions, queryInput restql.QueryInput) (runner.Plan, error) {
+	queryTxt, err := e.fetchSavedQuery(ctx, queryOpts)
+	if err != nil {
+		return runner.Plan{}, err
+	}
+
+	return e.explainQuery(ctx, queryTxt, queryOpts, queryInput)
+}
+
+func (e Evaluator) fetchSavedQuery(ctx context.Context, queryOpts restql.QueryOptions) (string, error) {
 	err := validateQueryOptions(queryOpts)
 	if err != nil {
-		return nil, err
+		return "", err
 	}
 
 	savedQuery, err := e.queryReader.Get(ctx, queryOpts.Namespace, queryOpts.Id, queryOpts.Revision)
 	if err != nil {
-		return nil, err
+		return "", err
 	}
 
 	log := restql.GetLogger(ctx)
 	log.Debug("Saved query retrieved", "query", savedQuery)
 
 	if savedQuery.Deprecated {
-		return nil, domain.ErrQueryRevisionDeprecated{Revision: queryOpts.Revision}
+		return "", domain.ErrQueryRevisionDeprecated{Revision: queryOpts.Revision}
 	}
 
-	return e.evaluateQuery(ctx, savedQuery.Text, queryOpts, queryInput)
+	return savedQuery.Text, nil
 }
 
-func (e Evaluator) evaluateQuery(ctx context.Context, queryTxt string, queryOpts restql.QueryOptions, queryInput restql.QueryInput) (domain.Resources, error) {
-	log := restql.GetLogger(ctx)
-
-	query, err := e.parser.Parse(queryTxt)
+func (e Evaluator) explainQuery(ctx context.Context, queryTxt string, queryOpts restql.QueryOptions, queryInput restql.QueryInput) (runner.Plan, error) {
+	query, queryContext, err := e.prepareQuery(ctx, queryTxt, queryOpts, queryInput)
 	if err != nil {
-		log.Debug("failed to parse query", "error", err)
-		return nil, ParserError{errors.Wrap(err, "invalid query syntax")}
+		return runner.Plan{}, err
 	}
 
-	mappings, err := e.mappingsReader.FromTenant(ctx, queryOpts.Tenant)
-	if err != nil {
-		log.Error("failed to fetch mappings", err)
-		return nil, err
+	query = ResolveVariables(query, queryContext.Input)
+
+	plan, err := e.runner.Explain(ctx, query, queryContext)
+	if errors.Is(err, runner.ErrInvalidChainedParameter) {
+		return runner.Plan{}, ParserError{Err: err}
 	}
 
-	err = validateQueryResources(query, mappings)
+	return plan, err
+}
+
+func (e Evaluator) evaluateQuery(ctx context.Context, queryTxt string, queryOpts restql.QueryOptions, queryInput restql.QueryInput, listener StatementListener) (domain.Resources, SavedQueryInfo, error) {
+	ctx, span := tracing.Start(ctx, "restql.query", tracing.SpanKindInternal)
+	defer span.Finish()
+
+	span.SetAttribute("restql.tenant", queryOpts.Tenant)
+	if queryOpts.Id != "" {
+		span.SetAttribute("restql.query.namespace", queryOpts.Namespace)
+		span.SetAttribute("restql.query.id", queryOpts.Id)
+		span.SetAttribute("restql.query.revision", queryOpts.Revision)
+	}
+
+	resources, info, err := e.runQuery(ctx, queryTxt, queryOpts, queryInput, listener)
+	span.SetError(err)
+
 	if err != nil {
-		log.Error("query reference invalid resource", err, "mappings", fmt.Sprintf("%#v", mappings))
-		return nil, err
+		e.lifecycle.OnQueryError(ctx, queryTxt, err)
 	}
 
-	queryContext := restql.QueryContext{
-		Mappings: mappings,
-		Options:  queryOpts,
-		Input:    queryInput,
+	return resources, info, err
+}
+
+func (e Evaluator) runQuery(ctx context.Context, queryTxt string, queryOpts restql.QueryOptions, queryInput restql.QueryInput, listener StatementListener) (domain.Resources, SavedQueryInfo, error) {
+	log := restql.GetLogger(ctx)
+
+	query, queryContext, err := e.prepareQuery(ctx, queryTxt, queryOpts, queryInput)
+	if err != nil {
+		return nil, SavedQueryInfo{}, err
 	}
 
 	queryCtx := e.lifecycle.BeforeQuery(ctx, queryTxt, queryContext)
 
+	useResultCache := e.resultCache != nil && listener == nil && queryOpts.Id != ""
+	if useResultCache && !bypassResultCache(queryContext.Input) {
+		if resources, age, found := e.resultCache.Get(queryOpts, query, queryContext.Input); found {
+			log.Debug("query result served from cache")
+			e.lifecycle.AfterQuery(queryCtx, queryTxt, resources)
+			return ApplyHidden(query, resources), SavedQueryInfo{CacheHit: true, CacheAge: age}, nil
+		}
+	}
+
+	rawQuery := query
 	query = ResolveVariables(query, queryContext.Input)
 
-	resources, err := e.runner.ExecuteQuery(queryCtx, query, queryContext)
+	var resources domain.Resources
+	if listener != nil {
+		resources, err = e.runner.StreamQuery(queryCtx, query, queryContext, streamStatements(log, query, listener))
+	} else {
+		resources, err = e.runner.ExecuteQuery(queryCtx, query, queryContext)
+	}
+
 	switch {
 	case err == runner.ErrQueryTimedOut:
-		return nil, TimeoutError{Err: err}
+		return nil, SavedQueryInfo{}, TimeoutError{Err: err}
 	case errors.Is(err, runner.ErrInvalidChainedParameter):
-		return nil, ParserError{Err: err}
+		return nil, SavedQueryInfo{}, ParserError{Err: err}
 	case err != nil:
-		return nil, err
+		return nil, SavedQueryInfo{}, err
 	}
 
 	resources, err = ApplyFilters(log, query, resources)
 	if err != nil {
 		log.Error("failed to apply filters", err, "input", fmt.Sprintf("%+#v", queryContext.Input))
-		return nil, err
+		return nil, SavedQueryInfo{}, err
 	}
 
 	resources = ApplyAggregators(query, resources)
 
+	if useResultCache {
+		e.resultCache.Set(queryOpts, rawQuery, queryContext.Input, resources)
+	}
+
 	e.lifecycle.AfterQuery(queryCtx, queryTxt, resources)
 
 	resources = ApplyHidden(query, resources)
 
-	return resources, nil
+	if listener != nil {
+		streamAggregated(query, resources, listener)
+	}
+
+	return resources, SavedQueryInfo{}, nil
+}
+
+func (e Evaluator) prepareQuery(ctx context.Context, queryTxt string, queryOpts restql.QueryOptions, queryInput restql.QueryInput) (domain.Query, restql.QueryContext, error) {
+	log := restql.GetLogger(ctx)
+
+	query, err := e.parser.Parse(queryTxt)
+	if err != nil {
+		log.Debug("failed to parse query", "error", err)
+		return domain.Query{}, restql.QueryContext{}, ParserError{errors.Wrap(err, "invalid query syntax")}
+	}
+
+	err = ValidateDependencies(query)
+	if err != nil {
+		log.Debug("query has invalid statement dependencies", "error", err)
+		return domain.Query{}, restql.QueryContext{}, err
+	}
+
+	queryInput, err = ValidateParams(query, queryInput)
+	if err != nil {
+		log.Debug("query input does not match declared params", "error", err)
+		return domain.Query{}, restql.QueryContext{}, err
+	}
+
+	mappings, err := e.mappingsReader.FromTenant(ctx, queryOpts.Tenant)
+	if err != nil {
+		log.Error("failed to fetch mappings", err)
+		return domain.Query{}, restql.QueryContext{}, err
+	}
+
+	err = validateQueryResources(query, mappings)
+	if err != nil {
+		log.Error("query reference invalid resource", err, "mappings", fmt.Sprintf("%#v", mappings))
+		return domain.Query{}, restql.QueryContext{}, err
+	}
+
+	queryContext := restql.QueryContext{
+		Mappings: mappings,
+		Options:  queryOpts,
+		Input:    queryInput,
+	}
+
+	return query, queryContext, nil
 }
 
 func validateQueryResources(query domain.Query, mappings map[string]restql.Mapping) error {
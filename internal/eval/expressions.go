@@ -0,0 +1,90 @@
+package eval
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/b2wdigital/restQL-golang/v4/internal/domain"
+	"github.com/pkg/errors"
+)
+
+// evaluateExpression computes the value of a computed
+// field in the `only` clause from a record of the result.
+func evaluateExpression(expression domain.Expression, record map[string]interface{}) (interface{}, error) {
+	switch expression.Function {
+	case domain.CountExpression:
+		return countValues(expressionArg(expression, record)), nil
+	case domain.SumExpression:
+		return sumValues(expressionArg(expression, record)), nil
+	case domain.ConcatExpression:
+		var sb strings.Builder
+		for _, arg := range expression.Args {
+			writeValue(&sb, resolveExpressionArg(arg, record))
+		}
+		return sb.String(), nil
+	default:
+		return nil, errors.Errorf("failed to evaluate expression : unknown function %s", expression.Function)
+	}
+}
+
+func expressionArg(expression domain.Expression, record map[string]interface{}) interface{} {
+	if len(expression.Args) == 0 {
+		return nil
+	}
+
+	return resolveExpressionArg(expression.Args[0], record)
+}
+
+func resolveExpressionArg(arg interface{}, record map[string]interface{}) interface{} {
+	path, ok := arg.([]string)
+	if !ok {
+		return arg
+	}
+
+	value, _ := getFieldValue(path, record)
+	return value
+}
+
+// countValues returns the number of elements of a list,
+// considering a single value as one and a missing value as none.
+func countValues(value interface{}) int {
+	switch value := value.(type) {
+	case nil:
+		return 0
+	case []interface{}:
+		return len(value)
+	default:
+		return 1
+	}
+}
+
+// sumValues adds the numbers in the value, including
+// the ones in nested lists, ignoring anything else.
+func sumValues(value interface{}) float64 {
+	switch value := value.(type) {
+	case []interface{}:
+		var sum float64
+		for _, v := range value {
+			sum += sumValues(v)
+		}
+		return sum
+	default:
+		n, ok := toFloatParam(value)
+		if !ok {
+			return 0
+		}
+		return n.(float64)
+	}
+}
+
+func writeValue(sb *strings.Builder, value interface{}) {
+	switch value := value.(type) {
+	case nil:
+	case []interface{}:
+		for _, v := range value {
+			writeValue(sb, v)
+		}
+	default:
+		sb.WriteString(fmt.Sprintf("%v", value))
+	}
+}
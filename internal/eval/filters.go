@@ -3,6 +3,7 @@ package eval
 import (
 	"fmt"
 	"regexp"
+	"strings"
 
 	"github.com/b2wdigital/restQL-golang/v4/internal/domain"
 	"github.com/b2wdigital/restQL-golang/v4/pkg/restql"
@@ -37,7 +38,7 @@ func applyOnlyFilters(filters []interface{}, resourceResult interface{}) (interf
 
 	switch resourceResult := resourceResult.(type) {
 	case domain.DoneResource:
-		result, err := extractWithFilters(buildFilterTree(filters), resourceResult.ResponseBody)
+		result, err := applyFieldFilters(filters, resourceResult.ResponseBody)
 		if err != nil {
 			return nil, err
 		}
@@ -55,6 +56,146 @@ func applyOnlyFilters(filters []interface{}, resourceResult interface{}) (interf
 	}
 }
 
+// applyFieldFilters selects the fields of the body and then adds
+// the renamed and computed ones to each of its records. These are
+// evaluated first, as selecting all fields changes the body itself.
+func applyFieldFilters(filters []interface{}, body interface{}) (interface{}, error) {
+	var selected []interface{}
+	var aliases []domain.Alias
+	for _, f := range filters {
+		if alias, ok := f.(domain.Alias); ok {
+			aliases = append(aliases, alias)
+		} else {
+			selected = append(selected, f)
+		}
+	}
+
+	if len(aliases) == 0 {
+		return extractWithFilters(buildFilterTree(selected), body)
+	}
+
+	named, err := evaluateAliases(aliases, body)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := extractWithFilters(buildFilterTree(selected), body)
+	if err != nil {
+		return nil, err
+	}
+
+	return mergeAliases(result, named), nil
+}
+
+func evaluateAliases(aliases []domain.Alias, body interface{}) (interface{}, error) {
+	switch body := body.(type) {
+	case map[string]interface{}:
+		named := make(map[string]interface{}, len(aliases))
+		for _, alias := range aliases {
+			value, found, err := evaluateAlias(alias, body)
+			if err != nil {
+				return nil, err
+			}
+
+			if found {
+				named[alias.Name] = value
+			}
+		}
+
+		return named, nil
+	case []interface{}:
+		list := make([]interface{}, len(body))
+		for i, item := range body {
+			named, err := evaluateAliases(aliases, item)
+			if err != nil {
+				return nil, err
+			}
+			list[i] = named
+		}
+
+		return list, nil
+	default:
+		return nil, nil
+	}
+}
+
+func evaluateAlias(alias domain.Alias, record map[string]interface{}) (interface{}, bool, error) {
+	if expression, ok := alias.Value.(domain.Expression); ok {
+		value, err := evaluateExpression(expression, record)
+		return value, err == nil, err
+	}
+
+	var path []string
+	switch f := alias.Value.(type) {
+	case []string:
+		path = f
+	case domain.Function:
+		path, _ = f.Target().([]string)
+	}
+
+	selected, err := extractWithFilters(buildFilterTree([]interface{}{alias.Value}), record)
+	if err != nil {
+		return nil, false, err
+	}
+
+	value, found := getFieldValue(path, selected)
+	return value, found, nil
+}
+
+func mergeAliases(result interface{}, named interface{}) interface{} {
+	switch named := named.(type) {
+	case map[string]interface{}:
+		node, ok := result.(map[string]interface{})
+		if !ok {
+			return result
+		}
+
+		for key, value := range named {
+			node[key] = value
+		}
+	case []interface{}:
+		list, ok := result.([]interface{})
+		if !ok || len(list) != len(named) {
+			return result
+		}
+
+		for i := range list {
+			list[i] = mergeAliases(list[i], named[i])
+		}
+	}
+
+	return result
+}
+
+// getFieldValue returns the value in the path, collecting
+// the values of each element when it goes through a list.
+func getFieldValue(path []string, value interface{}) (interface{}, bool) {
+	if len(path) == 0 {
+		return value, true
+	}
+
+	switch value := value.(type) {
+	case map[string]interface{}:
+		field, found := value[path[0]]
+		if !found {
+			return nil, false
+		}
+
+		return getFieldValue(path[1:], field)
+	case []interface{}:
+		list := make([]interface{}, 0, len(value))
+		for _, item := range value {
+			if v, found := getFieldValue(path, item); found {
+				list = append(list, v)
+			}
+		}
+
+		return list, true
+	default:
+		return nil, false
+	}
+}
+
 func extractWithFilters(filters map[string]interface{}, resourceResult interface{}) (interface{}, error) {
 	filters, hasSelectAll := extractSelectAllFilter(filters)
 
@@ -78,6 +219,11 @@ func extractWithFilters(filters map[string]interface{}, resourceResult interface
 				if err != nil {
 					return nil, err
 				}
+			} else if predicate, ok := subFilter.(domain.Predicate); ok {
+				err := applyPredicateFilter(predicate, key, value, node)
+				if err != nil {
+					return nil, err
+				}
 			} else if subFilter == nil {
 				node[key] = value
 			} else {
@@ -135,14 +281,39 @@ func applyMatchFilter(filter domain.Match, key string, value interface{}, node m
 		return err
 	}
 
+	selectValues(func(v interface{}) bool {
+		return matchRegex.MatchString(fmt.Sprintf("%v", v))
+	}, key, value, node)
+
+	return nil
+}
+
+// applyPredicateFilter keeps the value when it satisfies the predicate.
+// As with `matches`, the elements of a list value are tested one by one,
+// except for `contains`, which tests if the list has the argument.
+func applyPredicateFilter(filter domain.Predicate, key string, value interface{}, node map[string]interface{}) error {
+	test, err := parsePredicate(filter)
+	if err != nil {
+		return err
+	}
+
+	if filter.Operator == domain.ContainsOperator {
+		keepValue(test(value), key, value, node)
+		return nil
+	}
+
+	selectValues(test, key, value, node)
+
+	return nil
+}
+
+func selectValues(test func(v interface{}) bool, key string, value interface{}, node map[string]interface{}) {
 	switch value := value.(type) {
 	case []interface{}:
 		var list []interface{}
 
 		for _, v := range value {
-			strVal := fmt.Sprintf("%v", v)
-			match := matchRegex.MatchString(strVal)
-			if match {
+			if test(v) {
 				list = append(list, v)
 			}
 		}
@@ -150,20 +321,107 @@ func applyMatchFilter(filter domain.Match, key string, value interface{}, node m
 		if len(list) > 0 {
 			node[key] = list
 		}
+	default:
+		keepValue(test(value), key, value, node)
+	}
+}
 
-		return nil
+func keepValue(keep bool, key string, value interface{}, node map[string]interface{}) {
+	if keep {
+		node[key] = value
+	} else {
+		delete(node, key)
+	}
+}
+
+func parsePredicate(filter domain.Predicate) (func(v interface{}) bool, error) {
+	switch filter.Operator {
+	case domain.NotMatchesOperator:
+		regex, err := parseMatchArg(filter.Arg)
+		if err != nil {
+			return nil, err
+		}
+		return func(v interface{}) bool {
+			return !regex.MatchString(fmt.Sprintf("%v", v))
+		}, nil
+	case domain.EqualsOperator:
+		return func(v interface{}) bool {
+			return equalValues(v, filter.Arg)
+		}, nil
+	case domain.GreaterThanOperator, domain.GreaterThanOrEqualOperator, domain.LessThanOperator, domain.LessThanOrEqualOperator:
+		return parseComparison(filter.Operator, filter.Arg)
+	case domain.InOperator:
+		list, ok := filter.Arg.([]interface{})
+		if !ok {
+			list = []interface{}{filter.Arg}
+		}
+		return func(v interface{}) bool {
+			return containsValue(list, v)
+		}, nil
+	case domain.ContainsOperator:
+		return func(v interface{}) bool {
+			switch v := v.(type) {
+			case string:
+				return strings.Contains(v, fmt.Sprintf("%v", filter.Arg))
+			case []interface{}:
+				return containsValue(v, filter.Arg)
+			default:
+				return false
+			}
+		}, nil
 	default:
-		strVal := fmt.Sprintf("%v", value)
-		match := matchRegex.MatchString(strVal)
+		return nil, errors.Errorf("failed to parse predicate : unknown operator %s", filter.Operator)
+	}
+}
 
-		if match {
-			node[key] = value
-		} else {
-			delete(node, key)
+func parseComparison(operator string, arg interface{}) (func(v interface{}) bool, error) {
+	n, ok := toFloatParam(arg)
+	if !ok {
+		return nil, errors.Errorf("failed to parse %s argument : %v is not a number", operator, arg)
+	}
+	limit := n.(float64)
+
+	return func(v interface{}) bool {
+		n, ok := toFloatParam(v)
+		if !ok {
+			return false
+		}
+		value := n.(float64)
+
+		switch operator {
+		case domain.GreaterThanOperator:
+			return value > limit
+		case domain.GreaterThanOrEqualOperator:
+			return value >= limit
+		case domain.LessThanOperator:
+			return value < limit
+		default:
+			return value <= limit
 		}
+	}, nil
+}
 
-		return nil
+// equalValues compares numbers by their value, as the ones
+// decoded from JSON are floats, and the others by their
+// representation, as arguments from the query input are strings.
+func equalValues(a, b interface{}) bool {
+	af, aok := toFloatParam(a)
+	bf, bok := toFloatParam(b)
+	if aok && bok {
+		return af == bf
 	}
+
+	return fmt.Sprintf("%v", a) == fmt.Sprintf("%v", b)
+}
+
+func containsValue(list []interface{}, value interface{}) bool {
+	for _, item := range list {
+		if equalValues(item, value) {
+			return true
+		}
+	}
+
+	return false
 }
 
 func parseMatchArg(arg interface{}) (*regexp.Regexp, error) {
@@ -200,8 +458,8 @@ func buildPathInTree(path []interface{}, tree map[string]interface{}) {
 	case string:
 		field = f
 		leaf = nil
-	case domain.Match:
-		fields, ok := f.Target().([]string)
+	case domain.Match, domain.Predicate:
+		fields, ok := f.(domain.Function).Target().([]string)
 		if !ok {
 			return
 		}
@@ -256,6 +514,21 @@ func parsePath(s interface{}) []interface{} {
 			}
 		}
 		return result
+	case domain.Predicate:
+		items, ok := s.Target().([]string)
+		if !ok {
+			return nil
+		}
+
+		result := make([]interface{}, len(items))
+		for i, item := range items {
+			if i == len(items)-1 {
+				result[i] = domain.Predicate{Value: []string{item}, Operator: s.Operator, Arg: s.Arg}
+			} else {
+				result[i] = item
+			}
+		}
+		return result
 	default:
 		return nil
 	}
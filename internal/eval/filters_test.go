@@ -328,6 +328,120 @@ func TestOnlyFilters(t *testing.T) {
 				},
 			},
 		},
+		{
+			"should bring only the given fields that satisfy the predicates",
+			domain.Query{Statements: []domain.Statement{{
+				Resource: "hero",
+				Only: []interface{}{
+					domain.Predicate{Value: []string{"id"}, Operator: domain.EqualsOperator, Arg: "12345"},
+					domain.Predicate{Value: []string{"name"}, Operator: domain.NotMatchesOperator, Arg: regexp.MustCompile("^b")},
+					domain.Predicate{Value: []string{"age"}, Operator: domain.GreaterThanOrEqualOperator, Arg: "42"},
+					domain.Predicate{Value: []string{"height"}, Operator: domain.LessThanOperator, Arg: 1.8},
+					domain.Predicate{Value: []string{"city"}, Operator: domain.InOperator, Arg: []interface{}{"Gotham", "Metropolis"}},
+					domain.Predicate{Value: []string{"nickname"}, Operator: domain.ContainsOperator, Arg: "Knight"},
+				},
+			}}},
+			domain.Resources{
+				"hero": domain.DoneResource{
+					ResponseBody: test.Unmarshal(`{ "id": 12345, "name": "batman", "age": 42, "height": 1.88, "city": "Gotham", "nickname": "The Dark Knight" }`),
+				},
+			},
+			domain.Resources{
+				"hero": domain.DoneResource{
+					ResponseBody: test.Unmarshal(`{ "id": 12345, "age": 42, "city": "Gotham", "nickname": "The Dark Knight" }`),
+				},
+			},
+		},
+		{
+			"should bring only the list elements that satisfy the predicate",
+			domain.Query{Statements: []domain.Statement{{
+				Resource: "hero",
+				Only: []interface{}{
+					domain.Predicate{Value: []string{"sidekicks", "age"}, Operator: domain.GreaterThanOperator, Arg: 20},
+					domain.Predicate{Value: []string{"sidekicks", "name"}, Operator: domain.NotMatchesOperator, Arg: "^R"},
+					domain.Predicate{Value: []string{"scores"}, Operator: domain.LessThanOrEqualOperator, Arg: 7},
+				},
+			}}},
+			domain.Resources{
+				"hero": domain.DoneResource{
+					ResponseBody: test.Unmarshal(`{ "sidekicks": [{ "name": "Robin", "age": 16 }, { "name": "Batgirl", "age": 24 }], "scores": [9, 7, 3] }`),
+				},
+			},
+			domain.Resources{
+				"hero": domain.DoneResource{
+					ResponseBody: test.Unmarshal(`{ "sidekicks": [{}, { "name": "Batgirl", "age": 24 }], "scores": [7, 3] }`),
+				},
+			},
+		},
+		{
+			"should bring renamed fields",
+			domain.Query{Statements: []domain.Statement{{
+				Resource: "hero",
+				Only: []interface{}{
+					[]string{"id"},
+					domain.Alias{Value: []string{"name"}, Name: "heroName"},
+					domain.Alias{Value: []string{"stats", "power"}, Name: "power"},
+					domain.Alias{Value: []string{"sidekicks", "name"}, Name: "sidekickNames"},
+					domain.Alias{Value: domain.Match{Value: []string{"weapons"}, Arg: regexp.MustCompile("^b")}, Name: "bWeapons"},
+					domain.Alias{Value: []string{"city"}, Name: "city"},
+				},
+			}}},
+			domain.Resources{
+				"hero": domain.DoneResource{
+					ResponseBody: test.Unmarshal(`{ "id": 1, "name": "batman", "stats": { "power": 90 }, "sidekicks": [{ "name": "robin" }, { "name": "batgirl" }], "weapons": ["belt", "batarang", "katana"] }`),
+				},
+			},
+			domain.Resources{
+				"hero": domain.DoneResource{
+					ResponseBody: test.Unmarshal(`{ "id": 1, "heroName": "batman", "power": 90, "sidekickNames": ["robin", "batgirl"], "bWeapons": ["belt", "batarang"] }`),
+				},
+			},
+		},
+		{
+			"should bring computed fields in each list element",
+			domain.Query{Statements: []domain.Statement{{
+				Resource: "hero",
+				Only: []interface{}{
+					[]string{"first"},
+					domain.Alias{Value: domain.Expression{Function: domain.CountExpression, Args: []interface{}{[]string{"weapons"}}}, Name: "count"},
+					domain.Alias{Value: domain.Expression{Function: domain.SumExpression, Args: []interface{}{[]string{"scores"}}}, Name: "total"},
+					domain.Alias{Value: domain.Expression{Function: domain.ConcatExpression, Args: []interface{}{[]string{"first"}, " ", []string{"last"}, []string{"missing"}}}, Name: "fullName"},
+				},
+			}}},
+			domain.Resources{
+				"hero": domain.DoneResource{
+					ResponseBody: test.Unmarshal(`[{ "first": "Bruce", "last": "Wayne", "weapons": ["belt", "batarang"], "scores": [1, 2.5] }, { "first": "Diana", "last": "Prince" }]`),
+				},
+			},
+			domain.Resources{
+				"hero": domain.DoneResource{
+					ResponseBody: []interface{}{
+						map[string]interface{}{"first": "Bruce", "count": 2, "total": 3.5, "fullName": "Bruce Wayne"},
+						map[string]interface{}{"first": "Diana", "count": 0, "total": 0.0, "fullName": "Diana Prince"},
+					},
+				},
+			},
+		},
+		{
+			"should bring the list that contains the argument",
+			domain.Query{Statements: []domain.Statement{{
+				Resource: "hero",
+				Only: []interface{}{
+					domain.Predicate{Value: []string{"weapons"}, Operator: domain.ContainsOperator, Arg: "belt"},
+					domain.Predicate{Value: []string{"powers"}, Operator: domain.ContainsOperator, Arg: "flight"},
+				},
+			}}},
+			domain.Resources{
+				"hero": domain.DoneResource{
+					ResponseBody: test.Unmarshal(`{ "weapons": ["belt", "batarang"], "powers": ["money"] }`),
+				},
+			},
+			domain.Resources{
+				"hero": domain.DoneResource{
+					ResponseBody: test.Unmarshal(`{ "weapons": ["belt", "batarang"] }`),
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
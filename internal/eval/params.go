@@ -0,0 +1,232 @@
+package eval
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/b2wdigital/restQL-golang/v4/internal/domain"
+	"github.com/b2wdigital/restQL-golang/v4/pkg/restql"
+	"github.com/pkg/errors"
+)
+
+// ValidateParams checks the client input against the parameters
+// declared in the query `params` clause, returning a new input
+// with default values applied and every value coerced to its
+// declared type.
+// If any parameter is missing or has an invalid value
+// a ValidationError listing all of them is returned.
+func ValidateParams(query domain.Query, input restql.QueryInput) (restql.QueryInput, error) {
+	if len(query.Params) == 0 {
+		return input, nil
+	}
+
+	result := copyQueryInput(input)
+
+	var invalid []string
+	for _, param := range query.Params {
+		value, found := getUniqueParamValue(param.Name, input)
+		if !found || value == nil {
+			if param.Default != nil {
+				d, err := coerceParam(param, param.Default)
+				if err != nil {
+					invalid = append(invalid, fmt.Sprintf("$%s has an invalid default value: %s", param.Name, err))
+					continue
+				}
+
+				result.Params[param.Name] = d
+				continue
+			}
+
+			if param.Required {
+				invalid = append(invalid, fmt.Sprintf("$%s is required", param.Name))
+			}
+			continue
+		}
+
+		v, err := coerceParam(param, value)
+		if err != nil {
+			invalid = append(invalid, fmt.Sprintf("$%s %s", param.Name, err))
+			continue
+		}
+
+		setParamValue(result, param.Name, v)
+	}
+
+	if len(invalid) > 0 {
+		err := errors.Errorf("invalid query parameters: %s", strings.Join(invalid, ", "))
+		return restql.QueryInput{}, ValidationError{Err: err}
+	}
+
+	return result, nil
+}
+
+func copyQueryInput(input restql.QueryInput) restql.QueryInput {
+	params := make(map[string]interface{}, len(input.Params))
+	for k, v := range input.Params {
+		params[k] = v
+	}
+
+	body := input.Body
+	if b, ok := body.(map[string]interface{}); ok {
+		bodyCopy := make(map[string]interface{}, len(b))
+		for k, v := range b {
+			bodyCopy[k] = v
+		}
+		body = bodyCopy
+	}
+
+	return restql.QueryInput{Params: params, Body: body, Headers: input.Headers}
+}
+
+// setParamValue overwrites the parameter in the same place
+// it will be looked for by getUniqueParamValue.
+func setParamValue(input restql.QueryInput, name string, value interface{}) {
+	if b, ok := input.Body.(map[string]interface{}); ok {
+		if _, found := b[name]; found {
+			b[name] = value
+			return
+		}
+	}
+
+	input.Params[name] = value
+}
+
+func coerceParam(param domain.ParamDeclaration, value interface{}) (interface{}, error) {
+	if !param.List {
+		return coerceParamValue(param.Type, value)
+	}
+
+	var list []interface{}
+	switch value := value.(type) {
+	case []interface{}:
+		list = value
+	case string:
+		var l []interface{}
+		if err := json.Unmarshal([]byte(value), &l); err == nil {
+			list = l
+		} else {
+			list = []interface{}{value}
+		}
+	default:
+		list = []interface{}{value}
+	}
+
+	result := make([]interface{}, len(list))
+	for i, item := range list {
+		v, err := coerceParamValue(param.Type, item)
+		if err != nil {
+			return nil, errors.Errorf("must be a list of %s", param.Type)
+		}
+
+		result[i] = v
+	}
+
+	return result, nil
+}
+
+func coerceParamValue(paramType string, value interface{}) (interface{}, error) {
+	var result interface{}
+	ok := false
+
+	switch paramType {
+	case domain.StringParamType:
+		result, ok = toStringParam(value)
+	case domain.IntParamType:
+		result, ok = toIntParam(value)
+	case domain.FloatParamType:
+		result, ok = toFloatParam(value)
+	case domain.BooleanParamType:
+		result, ok = toBooleanParam(value)
+	case domain.ObjectParamType:
+		result, ok = toObjectParam(value)
+	default:
+		return nil, errors.Errorf("has an unknown type %s", paramType)
+	}
+
+	if !ok {
+		return nil, errors.Errorf("must be of type %s", paramType)
+	}
+
+	return result, nil
+}
+
+func toStringParam(value interface{}) (interface{}, bool) {
+	switch value := value.(type) {
+	case string:
+		return value, true
+	case int, float64, bool:
+		return fmt.Sprintf("%v", value), true
+	default:
+		return nil, false
+	}
+}
+
+func toIntParam(value interface{}) (interface{}, bool) {
+	switch value := value.(type) {
+	case int:
+		return value, true
+	case float64:
+		if value != math.Trunc(value) {
+			return nil, false
+		}
+		return int(value), true
+	case string:
+		i, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, false
+		}
+		return i, true
+	default:
+		return nil, false
+	}
+}
+
+func toFloatParam(value interface{}) (interface{}, bool) {
+	switch value := value.(type) {
+	case float64:
+		return value, true
+	case int:
+		return float64(value), true
+	case string:
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, false
+		}
+		return f, true
+	default:
+		return nil, false
+	}
+}
+
+func toBooleanParam(value interface{}) (interface{}, bool) {
+	switch value := value.(type) {
+	case bool:
+		return value, true
+	case string:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, false
+		}
+		return b, true
+	default:
+		return nil, false
+	}
+}
+
+func toObjectParam(value interface{}) (interface{}, bool) {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		return value, true
+	case string:
+		var m map[string]interface{}
+		if err := json.Unmarshal([]byte(value), &m); err != nil {
+			return nil, false
+		}
+		return m, true
+	default:
+		return nil, false
+	}
+}
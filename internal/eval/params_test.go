@@ -0,0 +1,119 @@
+package eval_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/b2wdigital/restQL-golang/v4/internal/domain"
+	"github.com/b2wdigital/restQL-golang/v4/internal/eval"
+	"github.com/b2wdigital/restQL-golang/v4/pkg/restql"
+	"github.com/b2wdigital/restQL-golang/v4/test"
+)
+
+func TestValidateParams(t *testing.T) {
+	tests := []struct {
+		name     string
+		params   []domain.ParamDeclaration
+		input    restql.QueryInput
+		expected restql.QueryInput
+	}{
+		{
+			"keep input when there is no declaration",
+			nil,
+			restql.QueryInput{Params: map[string]interface{}{"id": "1"}},
+			restql.QueryInput{Params: map[string]interface{}{"id": "1"}},
+		},
+		{
+			"coerce query parameters to declared types",
+			[]domain.ParamDeclaration{
+				{Name: "id", Type: domain.IntParamType, Required: true},
+				{Name: "price", Type: domain.FloatParamType},
+				{Name: "active", Type: domain.BooleanParamType},
+				{Name: "filter", Type: domain.ObjectParamType},
+			},
+			restql.QueryInput{Params: map[string]interface{}{"id": "1", "price": "10.5", "active": "true", "filter": `{"name":"batman"}`}},
+			restql.QueryInput{Params: map[string]interface{}{"id": 1, "price": 10.5, "active": true, "filter": map[string]interface{}{"name": "batman"}}},
+		},
+		{
+			"coerce list parameters",
+			[]domain.ParamDeclaration{
+				{Name: "ids", Type: domain.IntParamType, List: true},
+				{Name: "tags", Type: domain.StringParamType, List: true},
+			},
+			restql.QueryInput{Params: map[string]interface{}{"ids": []interface{}{"1", "2"}, "tags": "dc"}},
+			restql.QueryInput{Params: map[string]interface{}{"ids": []interface{}{1, 2}, "tags": []interface{}{"dc"}}},
+		},
+		{
+			"coerce parameters from body and headers",
+			[]domain.ParamDeclaration{
+				{Name: "id", Type: domain.StringParamType},
+				{Name: "page", Type: domain.IntParamType},
+			},
+			restql.QueryInput{Body: map[string]interface{}{"id": 1.0}, Headers: map[string]string{"page": "2"}},
+			restql.QueryInput{Params: map[string]interface{}{"page": 2}, Body: map[string]interface{}{"id": "1"}, Headers: map[string]string{"page": "2"}},
+		},
+		{
+			"apply default value for missing parameters",
+			[]domain.ParamDeclaration{
+				{Name: "page", Type: domain.IntParamType, Default: 1},
+				{Name: "size", Type: domain.IntParamType, Default: 10},
+				{Name: "tags", Type: domain.StringParamType, List: true},
+			},
+			restql.QueryInput{Params: map[string]interface{}{"size": "20"}},
+			restql.QueryInput{Params: map[string]interface{}{"page": 1, "size": 20}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := eval.ValidateParams(domain.Query{Params: tt.params}, tt.input)
+			test.VerifyError(t, err)
+			test.Equal(t, got, tt.expected)
+		})
+	}
+}
+
+func TestValidateParamsErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		params   []domain.ParamDeclaration
+		input    restql.QueryInput
+		expected string
+	}{
+		{
+			"return error for missing required parameter",
+			[]domain.ParamDeclaration{{Name: "id", Type: domain.IntParamType, Required: true}},
+			restql.QueryInput{},
+			"invalid query parameters: $id is required",
+		},
+		{
+			"return error for parameter with wrong type",
+			[]domain.ParamDeclaration{{Name: "id", Type: domain.IntParamType}},
+			restql.QueryInput{Params: map[string]interface{}{"id": "abc"}},
+			"invalid query parameters: $id must be of type int",
+		},
+		{
+			"return error listing every invalid parameter",
+			[]domain.ParamDeclaration{
+				{Name: "id", Type: domain.IntParamType, Required: true},
+				{Name: "active", Type: domain.BooleanParamType},
+				{Name: "tags", Type: domain.IntParamType, List: true},
+			},
+			restql.QueryInput{Params: map[string]interface{}{"active": "yes", "tags": []interface{}{"1", "a"}}},
+			"invalid query parameters: $id is required, $active must be of type boolean, $tags must be a list of int",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := eval.ValidateParams(domain.Query{Params: tt.params}, tt.input)
+
+			var ve eval.ValidationError
+			if !errors.As(err, &ve) {
+				t.Fatalf("expected a ValidationError, got: %v", err)
+			}
+
+			test.Equal(t, err.Error(), tt.expected)
+		})
+	}
+}
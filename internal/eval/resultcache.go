@@ -0,0 +1,249 @@
+package eval
+
+import (
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"time"
+
+	"github.com/b2wdigital/restQL-golang/v4/internal/domain"
+	"github.com/b2wdigital/restQL-golang/v4/internal/platform/cache"
+	"github.com/b2wdigital/restQL-golang/v4/internal/platform/tracing"
+	"github.com/b2wdigital/restQL-golang/v4/internal/runner"
+	"github.com/b2wdigital/restQL-golang/v4/pkg/restql"
+)
+
+var resultNoCacheRegex = regexp.MustCompile("no-cache|no-store")
+
+// perRequestHeaders change on every request without
+// affecting the result, so they are never part of the key.
+var perRequestHeaders = []string{"Cache-Control", tracing.TraceparentHeader, tracing.TracestateHeader}
+
+// ResultTTL tells if the result of a query can be stored
+// and for how long, usually from its Cache-Control header.
+type ResultTTL func(resources domain.Resources) (time.Duration, bool)
+
+type cachedResult struct {
+	resources domain.Resources
+	storedAt  time.Time
+	ttl       time.Duration
+}
+
+type resultCacheKey struct {
+	Tenant    string                 `json:"tenant"`
+	Namespace string                 `json:"namespace"`
+	ID        string                 `json:"id"`
+	Revision  int                    `json:"revision"`
+	Params    map[string]interface{} `json:"params"`
+	Body      interface{}            `json:"body"`
+	Headers   map[string]string      `json:"headers"`
+}
+
+// ResultCache stores the results of saved queries for the time
+// given by the ResultTTL. Entries are keyed by the tenant, the query
+// identification and the client input. The headers in the key are
+// the ones read by the query variables plus, by default, the ones
+// forwarded to the upstream dependencies, or the vary headers when
+// given. Per request headers, like the request id and the trace
+// context, are only considered when read by the query.
+type ResultCache struct {
+	log            restql.Logger
+	cache          *cache.Cache
+	varyHeaders    map[string]bool
+	ignoredHeaders map[string]bool
+	ttl            ResultTTL
+}
+
+// NewResultCache constructs a ResultCache instance, returning
+// nil if the cache maximum size is not set. The ignored headers
+// are the per request ones set by restQL, like the request id.
+func NewResultCache(log restql.Logger, maxSize int, varyHeaders []string, ignoredHeaders []string, ttl ResultTTL) *ResultCache {
+	if maxSize <= 0 {
+		return nil
+	}
+
+	ignored := canonicalHeaderSet(perRequestHeaders)
+	for h := range canonicalHeaderSet(ignoredHeaders) {
+		ignored[h] = true
+	}
+
+	return &ResultCache{
+		log:            log,
+		cache:          cache.New(log, maxSize, nil),
+		varyHeaders:    canonicalHeaderSet(varyHeaders),
+		ignoredHeaders: ignored,
+		ttl:            ttl,
+	}
+}
+
+// Get returns the cached result for the query if present
+// and not expired, with for how long it has been stored.
+func (rc *ResultCache) Get(options restql.QueryOptions, query domain.Query, input restql.QueryInput) (domain.Resources, time.Duration, bool) {
+	key, ok := rc.makeKey(options, query, input)
+	if !ok {
+		return nil, 0, false
+	}
+
+	v, found := rc.cache.GetIfPresent(key)
+	if !found {
+		return nil, 0, false
+	}
+
+	entry, ok := v.(cachedResult)
+	if !ok {
+		return nil, 0, false
+	}
+
+	age := time.Since(entry.storedAt)
+	if age >= entry.ttl {
+		return nil, 0, false
+	}
+
+	resources := make(domain.Resources, len(entry.resources))
+	for k, v := range entry.resources {
+		resources[k] = v
+	}
+
+	return resources, age, true
+}
+
+// Set stores a query result if the ResultTTL allows it.
+func (rc *ResultCache) Set(options restql.QueryOptions, query domain.Query, input restql.QueryInput, resources domain.Resources) {
+	ttl, ok := rc.ttl(resources)
+	if !ok || ttl <= 0 {
+		return
+	}
+
+	key, ok := rc.makeKey(options, query, input)
+	if !ok {
+		return
+	}
+
+	stored := make(domain.Resources, len(resources))
+	for k, v := range resources {
+		stored[k] = v
+	}
+
+	entry := cachedResult{resources: stored, storedAt: time.Now(), ttl: ttl}
+	if err := rc.cache.Set(key, entry); err != nil {
+		rc.log.Error("failed to store query result on cache", err)
+	}
+}
+
+func (rc *ResultCache) makeKey(options restql.QueryOptions, query domain.Query, input restql.QueryInput) (string, bool) {
+	key := resultCacheKey{
+		Tenant:    options.Tenant,
+		Namespace: options.Namespace,
+		ID:        options.Id,
+		Revision:  options.Revision,
+		Params:    input.Params,
+		Body:      input.Body,
+		Headers:   rc.keyHeaders(query, input),
+	}
+
+	// Map keys are sorted when encoded, which
+	// normalizes the order of the input values.
+	data, err := json.Marshal(key)
+	if err != nil {
+		rc.log.Debug("failed to build query result cache key", "error", err)
+		return "", false
+	}
+
+	return string(data), true
+}
+
+func (rc *ResultCache) keyHeaders(query domain.Query, input restql.QueryInput) map[string]string {
+	headers := make(map[string]string)
+	for name := range queryVariables(query) {
+		if v, found := input.Headers[name]; found {
+			headers[http.CanonicalHeaderKey(name)] = v
+		}
+	}
+
+	for k, v := range input.Headers {
+		canonical := http.CanonicalHeaderKey(k)
+
+		switch {
+		case len(rc.varyHeaders) > 0:
+			if rc.varyHeaders[canonical] {
+				headers[canonical] = v
+			}
+		case runner.IsForwardedHeader(k) && !rc.ignoredHeaders[canonical]:
+			headers[canonical] = v
+		}
+	}
+
+	return headers
+}
+
+func canonicalHeaderSet(headers []string) map[string]bool {
+	set := make(map[string]bool, len(headers))
+	for _, h := range headers {
+		set[http.CanonicalHeaderKey(h)] = true
+	}
+
+	return set
+}
+
+// bypassResultCache tells if the client asked for
+// the query to be run ignoring the cached result.
+func bypassResultCache(input restql.QueryInput) bool {
+	for k, v := range input.Headers {
+		if http.CanonicalHeaderKey(k) == "Cache-Control" && resultNoCacheRegex.MatchString(v) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// queryVariables returns the names of the variables
+// used by the query, which ResolveVariables looks up
+// in the input body, parameters and headers.
+func queryVariables(query domain.Query) map[string]struct{} {
+	names := make(map[string]struct{})
+
+	for _, stmt := range query.Statements {
+		collectVariables(stmt.With.Values, names)
+		collectVariables(stmt.With.Body, names)
+		collectVariables(stmt.Headers, names)
+		collectVariables(stmt.Timeout, names)
+		collectVariables(stmt.CacheControl.MaxAge, names)
+		collectVariables(stmt.CacheControl.SMaxAge, names)
+		collectVariables(stmt.Only, names)
+
+		if stmt.When != nil {
+			collectVariables(stmt.When.Left, names)
+			collectVariables(stmt.When.Right, names)
+		}
+	}
+
+	return names
+}
+
+func collectVariables(value interface{}, names map[string]struct{}) {
+	switch value := value.(type) {
+	case domain.Variable:
+		names[value.Target] = struct{}{}
+	case domain.Chain:
+		for _, item := range value {
+			collectVariables(item, names)
+		}
+	case domain.Match:
+		collectVariables(value.Value, names)
+		collectVariables(value.Arg, names)
+	case domain.Predicate:
+		collectVariables(value.Value, names)
+		collectVariables(value.Arg, names)
+	case domain.Function:
+		collectVariables(value.Target(), names)
+	case []interface{}:
+		for _, v := range value {
+			collectVariables(v, names)
+		}
+	case map[string]interface{}:
+		for _, v := range value {
+			collectVariables(v, names)
+		}
+	}
+}
@@ -0,0 +1,175 @@
+package eval_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/b2wdigital/restQL-golang/v4/internal/domain"
+	"github.com/b2wdigital/restQL-golang/v4/internal/eval"
+	"github.com/b2wdigital/restQL-golang/v4/pkg/restql"
+	"github.com/b2wdigital/restQL-golang/v4/test"
+)
+
+func TestResultCache(t *testing.T) {
+	options := restql.QueryOptions{Tenant: "dc", Namespace: "heroes", Id: "batman", Revision: 1}
+	query := domain.Query{Statements: []domain.Statement{{
+		Method:   domain.FromMethod,
+		Resource: "hero",
+		With:     domain.Params{Values: map[string]interface{}{"city": domain.Variable{Target: "X-City"}}},
+	}}}
+	input := restql.QueryInput{
+		Params:  map[string]interface{}{"id": "1", "name": "bruce"},
+		Headers: map[string]string{"Authorization": "a", "X-City": "gotham", "X-Tenant": "wayne", "X-Tid": "1"},
+	}
+	resources := domain.Resources{"hero": domain.DoneResource{Status: 200, Success: true}}
+
+	storeFor := func(d time.Duration, ok bool) eval.ResultTTL {
+		return func(domain.Resources) (time.Duration, bool) { return d, ok }
+	}
+
+	tests := []struct {
+		name        string
+		varyHeaders []string
+		ttl         eval.ResultTTL
+		options     restql.QueryOptions
+		input       restql.QueryInput
+		expected    bool
+	}{
+		{
+			"should return cached result for same query and input",
+			nil,
+			storeFor(time.Minute, true),
+			options,
+			input,
+			true,
+		},
+		{
+			"should not return cached result for different forwarded header when no vary headers are set",
+			nil,
+			storeFor(time.Minute, true),
+			options,
+			restql.QueryInput{
+				Params:  map[string]interface{}{"id": "1", "name": "bruce"},
+				Headers: map[string]string{"Authorization": "a", "X-City": "gotham", "X-Tenant": "kent", "X-Tid": "1"},
+			},
+			false,
+		},
+		{
+			"should ignore per request headers when no vary headers are set",
+			nil,
+			storeFor(time.Minute, true),
+			options,
+			restql.QueryInput{
+				Params: map[string]interface{}{"id": "1", "name": "bruce"},
+				Headers: map[string]string{
+					"Authorization": "a",
+					"X-City":        "gotham",
+					"X-Tenant":      "wayne",
+					"X-Tid":         "2",
+					"traceparent":   "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01",
+					"Cache-Control": "max-age=60",
+				},
+			},
+			true,
+		},
+		{
+			"should ignore headers not forwarded upstream when no vary headers are set",
+			nil,
+			storeFor(time.Minute, true),
+			options,
+			restql.QueryInput{
+				Params:  map[string]interface{}{"id": "1", "name": "bruce"},
+				Headers: map[string]string{"Authorization": "a", "X-City": "gotham", "X-Tenant": "wayne", "X-Tid": "1", "Accept-Encoding": "gzip"},
+			},
+			true,
+		},
+		{
+			"should ignore headers outside vary headers",
+			[]string{"authorization"},
+			storeFor(time.Minute, true),
+			options,
+			restql.QueryInput{
+				Params:  map[string]interface{}{"name": "bruce", "id": "1"},
+				Headers: map[string]string{"authorization": "a", "X-City": "gotham", "X-Tenant": "kent", "X-Tid": "2"},
+			},
+			true,
+		},
+		{
+			"should not return cached result for different vary header",
+			[]string{"authorization"},
+			storeFor(time.Minute, true),
+			options,
+			restql.QueryInput{
+				Params:  map[string]interface{}{"id": "1", "name": "bruce"},
+				Headers: map[string]string{"Authorization": "b", "X-City": "gotham", "X-Tid": "1"},
+			},
+			false,
+		},
+		{
+			"should not return cached result for different header read by variable",
+			[]string{"authorization"},
+			storeFor(time.Minute, true),
+			options,
+			restql.QueryInput{
+				Params:  map[string]interface{}{"id": "1", "name": "bruce"},
+				Headers: map[string]string{"Authorization": "a", "X-City": "metropolis", "X-Tid": "1"},
+			},
+			false,
+		},
+		{
+			"should not return cached result for different params",
+			nil,
+			storeFor(time.Minute, true),
+			options,
+			restql.QueryInput{
+				Params:  map[string]interface{}{"id": "2", "name": "bruce"},
+				Headers: map[string]string{"Authorization": "a", "X-City": "gotham", "X-Tid": "1"},
+			},
+			false,
+		},
+		{
+			"should not return cached result for different revision",
+			nil,
+			storeFor(time.Minute, true),
+			restql.QueryOptions{Tenant: "dc", Namespace: "heroes", Id: "batman", Revision: 2},
+			input,
+			false,
+		},
+		{
+			"should not store result not allowed by ttl",
+			nil,
+			storeFor(time.Minute, false),
+			options,
+			input,
+			false,
+		},
+		{
+			"should not store result with zero ttl",
+			nil,
+			storeFor(0, true),
+			options,
+			input,
+			false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rc := eval.NewResultCache(test.NoOpLogger{}, 10, tt.varyHeaders, []string{"x-tid"}, tt.ttl)
+			rc.Set(options, query, input, resources)
+
+			got, _, found := rc.Get(tt.options, query, tt.input)
+
+			test.Equal(t, found, tt.expected)
+			if found {
+				test.Equal(t, got, resources)
+			}
+		})
+	}
+
+	t.Run("should not create cache without maximum size", func(t *testing.T) {
+		rc := eval.NewResultCache(test.NoOpLogger{}, 0, nil, nil, storeFor(time.Minute, true))
+
+		test.Equal(t, rc == nil, true)
+	})
+}
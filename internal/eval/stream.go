@@ -0,0 +1,106 @@
+package eval
+
+import (
+	"github.com/b2wdigital/restQL-golang/v4/internal/domain"
+	"github.com/b2wdigital/restQL-golang/v4/internal/runner"
+	"github.com/b2wdigital/restQL-golang/v4/pkg/restql"
+)
+
+// StatementListener receives the final result of a statement,
+// with the `only` filters applied, as soon as it is available.
+// Hidden statements are never sent to the listener.
+type StatementListener func(resourceID domain.ResourceID, resource interface{})
+
+// streamStatements builds a runner listener that sends the statements
+// to the given listener as they are done. Statements involved in `in`
+// aggregations, either as origin or target, depend on other results
+// and are only sent after the query finishes, by streamAggregated.
+func streamStatements(log restql.Logger, query domain.Query, listener StatementListener) runner.StatementListener {
+	statements := make(map[domain.ResourceID]domain.Statement)
+	for _, stmt := range query.Statements {
+		statements[domain.NewResourceID(stmt)] = stmt
+	}
+
+	aggregated := aggregatedStatements(query)
+
+	return func(resourceID domain.ResourceID, response interface{}) {
+		stmt, found := statements[resourceID]
+		if !found || stmt.Hidden || aggregated[resourceID] {
+			return
+		}
+
+		// The response is still referenced by the query execution,
+		// so the filters are applied on a copy of it.
+		filtered, err := applyOnlyFilters(stmt.Only, copyResource(response))
+		if err != nil {
+			log.Debug("failed to apply filter on streamed statement", "error", err, "resource", resourceID)
+			return
+		}
+
+		listener(resourceID, filtered)
+	}
+}
+
+// streamAggregated sends to the listener the statements
+// held back by streamStatements, with their final results.
+func streamAggregated(query domain.Query, resources domain.Resources, listener StatementListener) {
+	aggregated := aggregatedStatements(query)
+
+	for _, stmt := range query.Statements {
+		resourceID := domain.NewResourceID(stmt)
+		if stmt.Hidden || !aggregated[resourceID] {
+			continue
+		}
+
+		listener(resourceID, resources[resourceID])
+	}
+}
+
+func aggregatedStatements(query domain.Query) map[domain.ResourceID]bool {
+	aggregated := make(map[domain.ResourceID]bool)
+	for _, stmt := range query.Statements {
+		if len(stmt.In) == 0 {
+			continue
+		}
+
+		aggregated[domain.NewResourceID(stmt)] = true
+		aggregated[domain.ResourceID(stmt.In[0])] = true
+	}
+
+	return aggregated
+}
+
+func copyResource(resource interface{}) interface{} {
+	switch resource := resource.(type) {
+	case domain.DoneResource:
+		resource.ResponseBody = copyValue(resource.ResponseBody)
+		return resource
+	case domain.DoneResources:
+		result := make(domain.DoneResources, len(resource))
+		for i, r := range resource {
+			result[i] = copyResource(r)
+		}
+		return result
+	default:
+		return resource
+	}
+}
+
+func copyValue(value interface{}) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(value))
+		for k, v := range value {
+			result[k] = copyValue(v)
+		}
+		return result
+	case []interface{}:
+		result := make([]interface{}, len(value))
+		for i, v := range value {
+			result[i] = copyValue(v)
+		}
+		return result
+	default:
+		return value
+	}
+}
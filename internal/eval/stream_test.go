@@ -0,0 +1,50 @@
+package eval
+
+import (
+	"testing"
+
+	"github.com/b2wdigital/restQL-golang/v4/internal/domain"
+	"github.com/b2wdigital/restQL-golang/v4/test"
+)
+
+func TestStreamStatements(t *testing.T) {
+	query := domain.Query{Statements: []domain.Statement{
+		{Resource: "hero", Only: []interface{}{[]string{"name"}}},
+		{Resource: "sidekick", Hidden: true},
+		{Resource: "villain"},
+		{Resource: "weapon", In: []string{"villain", "weapon"}},
+	}}
+
+	streamed := make(domain.Resources)
+	listener := streamStatements(test.NoOpLogger{}, query, func(resourceID domain.ResourceID, resource interface{}) {
+		streamed[resourceID] = resource
+	})
+
+	heroBody := map[string]interface{}{"name": "batman", "city": "gotham"}
+	listener("hero", domain.DoneResource{Status: 200, ResponseBody: heroBody})
+	listener("sidekick", domain.DoneResource{Status: 200, ResponseBody: map[string]interface{}{"name": "robin"}})
+	listener("villain", domain.DoneResource{Status: 200, ResponseBody: map[string]interface{}{"name": "joker"}})
+	listener("weapon", domain.DoneResource{Status: 200, ResponseBody: map[string]interface{}{"name": "gun"}})
+
+	expected := domain.Resources{
+		"hero": domain.DoneResource{Status: 200, ResponseBody: map[string]interface{}{"name": "batman"}},
+	}
+	test.Equal(t, streamed, expected)
+	test.Equal(t, heroBody, map[string]interface{}{"name": "batman", "city": "gotham"})
+
+	resources := domain.Resources{
+		"hero":    domain.DoneResource{Status: 200, ResponseBody: map[string]interface{}{"name": "batman"}},
+		"villain": domain.DoneResource{Status: 200, ResponseBody: map[string]interface{}{"name": "joker", "weapon": "gun"}},
+		"weapon":  domain.DoneResource{Status: 200},
+	}
+	streamAggregated(query, resources, func(resourceID domain.ResourceID, resource interface{}) {
+		streamed[resourceID] = resource
+	})
+
+	expected = domain.Resources{
+		"hero":    domain.DoneResource{Status: 200, ResponseBody: map[string]interface{}{"name": "batman"}},
+		"villain": domain.DoneResource{Status: 200, ResponseBody: map[string]interface{}{"name": "joker", "weapon": "gun"}},
+		"weapon":  domain.DoneResource{Status: 200},
+	}
+	test.Equal(t, streamed, expected)
+}
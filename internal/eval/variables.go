@@ -21,11 +21,12 @@ func ResolveVariables(query domain.Query, input restql.QueryInput) domain.Query
 		copyStmt.Headers = resolveHeaders(copyStmt.Headers, input)
 		copyStmt.CacheControl = resolveCacheControl(copyStmt.CacheControl, input)
 		copyStmt.Only = resolveOnly(copyStmt.Only, input)
+		copyStmt.When = resolveWhen(copyStmt.When, input)
 
 		result[i] = copyStmt
 	}
 
-	return domain.Query{Use: query.Use, Statements: result}
+	return domain.Query{Use: query.Use, Params: query.Params, Statements: result}
 }
 
 func resolveWith(with domain.Params, input restql.QueryInput) domain.Params {
@@ -234,6 +235,39 @@ func resolveTimeout(timeout interface{}, input restql.QueryInput) interface{} {
 	}
 }
 
+func resolveWhen(condition *domain.Condition, input restql.QueryInput) *domain.Condition {
+	if condition == nil {
+		return nil
+	}
+
+	result := *condition
+	result.Left = resolveConditionOperand(condition.Left, input)
+	result.Right = resolveConditionOperand(condition.Right, input)
+
+	return &result
+}
+
+func resolveConditionOperand(operand interface{}, input restql.QueryInput) interface{} {
+	switch operand := operand.(type) {
+	case domain.Variable:
+		paramValue, found := getUniqueParamValue(operand.Target, input)
+		if !found {
+			return nil
+		}
+
+		return paramValue
+	case domain.Chain:
+		rc, ok := resolveChain(operand, input)
+		if !ok {
+			return nil
+		}
+
+		return rc
+	default:
+		return operand
+	}
+}
+
 func resolveChain(chain domain.Chain, input restql.QueryInput) (domain.Chain, bool) {
 	result := make(domain.Chain, len(chain))
 	for i, pathItem := range chain {
@@ -260,21 +294,37 @@ func resolveOnly(only []interface{}, input restql.QueryInput) []interface{} {
 
 	result := make([]interface{}, len(only))
 	for i, filter := range only {
-		switch filter := filter.(type) {
-		case domain.Match:
-			match, ok := resolveMatch(filter, input)
-			if !ok {
-				continue
-			}
-			result[i] = match
-		default:
-			result[i] = filter
+		resolved, ok := resolveFilter(filter, input)
+		if !ok {
+			continue
 		}
+		result[i] = resolved
 	}
 
 	return result
 }
 
+func resolveFilter(filter interface{}, input restql.QueryInput) (interface{}, bool) {
+	switch filter := filter.(type) {
+	case domain.Match:
+		return resolveMatch(filter, input)
+	case domain.Predicate:
+		arg, ok := resolvePredicateArg(filter.Arg, input)
+		if !ok {
+			return nil, false
+		}
+		return domain.Predicate{Value: filter.Value, Operator: filter.Operator, Arg: arg}, true
+	case domain.Alias:
+		value, ok := resolveFilter(filter.Value, input)
+		if !ok {
+			return nil, false
+		}
+		return domain.Alias{Value: value, Name: filter.Name}, true
+	default:
+		return filter, true
+	}
+}
+
 func resolveMatch(match domain.Match, input restql.QueryInput) (interface{}, bool) {
 	switch matchArg := match.Arg.(type) {
 	case domain.Variable:
@@ -285,6 +335,24 @@ func resolveMatch(match domain.Match, input restql.QueryInput) (interface{}, boo
 	}
 }
 
+func resolvePredicateArg(arg interface{}, input restql.QueryInput) (interface{}, bool) {
+	switch arg := arg.(type) {
+	case domain.Variable:
+		return getUniqueParamValue(arg.Target, input)
+	case []interface{}:
+		result := make([]interface{}, 0, len(arg))
+		for _, item := range arg {
+			value, ok := resolvePredicateArg(item, input)
+			if ok {
+				result = append(result, value)
+			}
+		}
+		return result, true
+	default:
+		return arg, true
+	}
+}
+
 func castToInt(value interface{}) (int, bool) {
 	switch value := value.(type) {
 	case string:
@@ -254,6 +254,32 @@ func TestResolveVariables(t *testing.T) {
 			restql.QueryInput{Body: map[string]interface{}{"heroName": "^Super"}},
 			domain.Query{Statements: []domain.Statement{{Method: "from", Resource: "hero", Only: []interface{}{domain.Match{Value: "name", Arg: "^Super"}}}}},
 		},
+		{
+			"resolve variables in only predicates and renamed fields",
+			domain.Query{Statements: []domain.Statement{{Method: "from", Resource: "hero", Only: []interface{}{
+				domain.Predicate{Value: []string{"age"}, Operator: domain.GreaterThanOperator, Arg: domain.Variable{Target: "minAge"}},
+				domain.Predicate{Value: []string{"id"}, Operator: domain.InOperator, Arg: []interface{}{"1", domain.Variable{Target: "id"}, domain.Variable{Target: "missing"}}},
+				domain.Alias{Value: domain.Match{Value: []string{"name"}, Arg: domain.Variable{Target: "heroName"}}, Name: "heroName"},
+			}}}},
+			restql.QueryInput{Params: map[string]interface{}{"minAge": "18", "id": "2", "heroName": "^Super"}},
+			domain.Query{Statements: []domain.Statement{{Method: "from", Resource: "hero", Only: []interface{}{
+				domain.Predicate{Value: []string{"age"}, Operator: domain.GreaterThanOperator, Arg: "18"},
+				domain.Predicate{Value: []string{"id"}, Operator: domain.InOperator, Arg: []interface{}{"1", "2"}},
+				domain.Alias{Value: domain.Match{Value: []string{"name"}, Arg: "^Super"}, Name: "heroName"},
+			}}}},
+		},
+		{
+			"resolve variable in when from params",
+			domain.Query{Statements: []domain.Statement{{Method: "from", Resource: "hero", When: &domain.Condition{Left: domain.Variable{Target: "includeHero"}}}}},
+			restql.QueryInput{Params: map[string]interface{}{"includeHero": "true"}},
+			domain.Query{Statements: []domain.Statement{{Method: "from", Resource: "hero", When: &domain.Condition{Left: "true"}}}},
+		},
+		{
+			"resolve missing variable in when to nil",
+			domain.Query{Statements: []domain.Statement{{Method: "from", Resource: "hero", When: &domain.Condition{Left: domain.Chain{"done-resource", domain.Variable{Target: "field"}}, Operator: "==", Right: domain.Variable{Target: "heroType"}}}}},
+			restql.QueryInput{Params: map[string]interface{}{"field": "type"}},
+			domain.Query{Statements: []domain.Statement{{Method: "from", Resource: "hero", When: &domain.Condition{Left: domain.Chain{"done-resource", "type"}, Operator: "==", Right: nil}}}},
+		},
 	}
 
 	for _, tt := range tests {
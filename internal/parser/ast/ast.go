@@ -2,29 +2,58 @@ package ast
 
 // restQL language keywords.
 const (
-	FromMethod          = "from"
-	IntoMethod          = "into"
-	UpdateMethod        = "update"
-	ToMethod            = "to"
-	DeleteMethod        = "delete"
-	WithKeyword         = "with"
-	OnlyKeyword         = "only"
-	HeadersKeyword      = "headers"
-	HiddenKeyword       = "hidden"
-	TimeoutKeyword      = "timeout"
-	MaxAgeKeyword       = "max-age"
-	SmaxAgeKeyword      = "s-max-age"
-	IgnoreErrorsKeyword = "ignore-errors"
-	NoMultiplex         = "no-multiplex"
-	Base64              = "base64"
-	JSON                = "json"
-	AsBody              = "as-body"
-	Flatten             = "flatten"
+	FromMethod                  = "from"
+	IntoMethod                  = "into"
+	UpdateMethod                = "update"
+	ToMethod                    = "to"
+	DeleteMethod                = "delete"
+	WithKeyword                 = "with"
+	OnlyKeyword                 = "only"
+	HeadersKeyword              = "headers"
+	HiddenKeyword               = "hidden"
+	TimeoutKeyword              = "timeout"
+	MaxAgeKeyword               = "max-age"
+	SmaxAgeKeyword              = "s-max-age"
+	OnTimeoutKeyword            = "on-timeout"
+	FailFastKeyword             = "fail-fast"
+	MaxConcurrencyKeyword       = "max-concurrency"
+	IgnoreErrorsKeyword         = "ignore-errors"
+	WhenKeyword                 = "when"
+	ParamsKeyword               = "params"
+	RetryKeyword                = "retry"
+	RetryAttempts               = "attempts"
+	RetryBackoff                = "backoff"
+	RetryJitter                 = "jitter"
+	RetryStatus                 = "status"
+	PaginateKeyword             = "paginate"
+	PaginateNext                = "next"
+	PaginateLink                = "link"
+	PaginatePage                = "page"
+	PaginateOffset              = "offset"
+	PaginateItems               = "items"
+	PaginateMax                 = "max"
+	NotMatchesPredicate         = "not-matches"
+	EqualsPredicate             = "equals"
+	GreaterThanPredicate        = "gt"
+	GreaterThanOrEqualPredicate = "gte"
+	LessThanPredicate           = "lt"
+	LessThanOrEqualPredicate    = "lte"
+	InPredicate                 = "in"
+	ContainsPredicate           = "contains"
+	CountExpression             = "count"
+	SumExpression               = "sum"
+	ConcatExpression            = "concat"
+	NoMultiplex                 = "no-multiplex"
+	Base64                      = "base64"
+	JSON                        = "json"
+	AsBody                      = "as-body"
+	Flatten                     = "flatten"
 )
 
 // Query is the root of the restQL AST.
 type Query struct {
 	Use    []Use
+	Params []ParamDeclaration
 	Blocks []Block
 }
 
@@ -37,8 +66,25 @@ type Use struct {
 // UseValue is the syntax node representing
 // the `use` clause possible values.
 type UseValue struct {
-	Int    *int
-	String *string
+	Int     *int
+	String  *string
+	Boolean *bool
+}
+
+// ParamDeclaration is the syntax node representing
+// a parameter declared in the `params` clause.
+type ParamDeclaration struct {
+	Name     string
+	Type     ParamType
+	Required bool
+	Default  *Value
+}
+
+// ParamType is the syntax node representing
+// the type of a declared parameter.
+type ParamType struct {
+	Name string
+	List bool
 }
 
 // Block is the syntax node representing a statement.
@@ -47,12 +93,22 @@ type Block struct {
 	Resource   string
 	Alias      string
 	In         []string
+	Join       *Join
 	Qualifiers []Qualifier
 }
 
+// Join is the syntax node representing the `on` clause
+// of an `in` aggregation, matching the target items by the
+// Target field with the origin items by the Origin field.
+type Join struct {
+	Target []string
+	Origin []string
+	Many   bool
+}
+
 // Qualifier is the syntax node representing statement
 // clauses: `with`, `only`, `hidden`, `headers`, `timeout`
-// `max-age`, `s-max-age` and `ignore-errors`.
+// `max-age`, `s-max-age`, `retry`, `paginate`, `when` and `ignore-errors`.
 type Qualifier struct {
 	With         *Parameters
 	Only         []Filter
@@ -61,14 +117,31 @@ type Qualifier struct {
 	Timeout      *TimeoutValue
 	MaxAge       *MaxAgeValue
 	SMaxAge      *SMaxAgeValue
+	Retry        *RetryValue
+	Paginate     *PaginateValue
+	When         *Condition
 	IgnoreErrors bool
 }
 
+// Condition is the syntax node representing
+// the predicate in the `when` clause.
+// If no Operator is present only the Left
+// value is evaluated.
+type Condition struct {
+	Negated  bool
+	Left     Value
+	Operator string
+	Right    *Value
+}
+
 // Filter is the syntax node representing entries
 // in the `only` clause.
 type Filter struct {
-	Field []string
-	Match *Match
+	Field      []string
+	Match      *Match
+	Predicate  *Predicate
+	Expression *Expression
+	Alias      *string
 }
 
 // Match is the syntax node representing the
@@ -78,6 +151,28 @@ type Match struct {
 	Variable *string
 }
 
+// Predicate is the syntax node representing the
+// comparison functions in the `only` clause, like
+// `equals`, `gt` or `in`.
+type Predicate struct {
+	Operator string
+	Value    Value
+}
+
+// Expression is the syntax node representing a computed
+// field in the `only` clause, like `count` or `concat`.
+type Expression struct {
+	Function  string
+	Arguments []ExpressionArg
+}
+
+// ExpressionArg is the syntax node representing an argument
+// of a computed field, either a field path or a literal value.
+type ExpressionArg struct {
+	Field []string
+	Value *Primitive
+}
+
 // Parameters is the syntax node representing
 // the `with` clause.
 type Parameters struct {
@@ -98,6 +193,14 @@ type KeyValue struct {
 	Key       string
 	Value     Value
 	Functions []string
+	Batch     *Batch
+}
+
+// Batch is the syntax node representing
+// the `batch` function.
+type Batch struct {
+	Size int
+	Key  *string
 }
 
 // Value is the syntax node representing
@@ -167,6 +270,26 @@ type MaxAgeValue variableOrInt
 // the value in the `s-max-age` clause.
 type SMaxAgeValue variableOrInt
 
+// RetryValue is the syntax node representing
+// the options in the `retry` clause.
+type RetryValue struct {
+	Attempts *int
+	Backoff  *int
+	Jitter   *int
+	Status   []int
+}
+
+// PaginateValue is the syntax node representing
+// the options in the `paginate` clause.
+type PaginateValue struct {
+	Next   *string
+	Link   *string
+	Page   *string
+	Offset *string
+	Items  *string
+	Max    *int
+}
+
 // Generator encapsulate the parsing implementation
 // used to transform a query string into an AST.
 type Generator struct{}
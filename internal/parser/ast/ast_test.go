@@ -59,6 +59,65 @@ func TestAstGenerator(t *testing.T) {
 				Blocks: []ast.Block{{Method: ast.FromMethod, Resource: "cart"}},
 			},
 		},
+		{
+			"Simple from resource query with on-timeout and fail-fast modifiers",
+			`
+							use timeout = 8000
+							use on-timeout = "partial"
+							use fail-fast = true
+							use max-concurrency = 16
+
+							from cart
+					`,
+			ast.Query{
+				Use: []ast.Use{
+					{Key: ast.TimeoutKeyword, Value: ast.UseValue{Int: Int(8000)}},
+					{Key: ast.OnTimeoutKeyword, Value: ast.UseValue{String: String("partial")}},
+					{Key: ast.FailFastKeyword, Value: ast.UseValue{Boolean: Boolean(true)}},
+					{Key: ast.MaxConcurrencyKeyword, Value: ast.UseValue{Int: Int(16)}},
+				},
+				Blocks: []ast.Block{{Method: ast.FromMethod, Resource: "cart"}},
+			},
+		},
+		{
+			"Simple from resource query with params declaration",
+			`
+							use timeout 8000
+							params $id: int!, $page: int = 1, $tags: [string]
+
+							from cart
+					`,
+			ast.Query{
+				Use: []ast.Use{
+					{Key: ast.TimeoutKeyword, Value: ast.UseValue{Int: Int(8000)}},
+				},
+				Params: []ast.ParamDeclaration{
+					{Name: "id", Type: ast.ParamType{Name: "int"}, Required: true},
+					{Name: "page", Type: ast.ParamType{Name: "int"}, Default: &ast.Value{Primitive: &ast.Primitive{Int: Int(1)}}},
+					{Name: "tags", Type: ast.ParamType{Name: "string", List: true}},
+				},
+				Blocks: []ast.Block{{Method: ast.FromMethod, Resource: "cart"}},
+			},
+		},
+		{
+			"Simple from resource query with params declaration in multiple lines",
+			`
+							params
+								$name: string = "batman"
+								$filters: object
+								$active: boolean!
+
+							from cart
+					`,
+			ast.Query{
+				Params: []ast.ParamDeclaration{
+					{Name: "name", Type: ast.ParamType{Name: "string"}, Default: &ast.Value{Primitive: &ast.Primitive{String: String("batman")}}},
+					{Name: "filters", Type: ast.ParamType{Name: "object"}},
+					{Name: "active", Type: ast.ParamType{Name: "boolean"}, Required: true},
+				},
+				Blocks: []ast.Block{{Method: ast.FromMethod, Resource: "cart"}},
+			},
+		},
 		{
 			"query with two from statements",
 			`
@@ -348,6 +407,76 @@ func TestAstGenerator(t *testing.T) {
 				}},
 			}}},
 		},
+		{
+			"Get query with custom functions applied to parameters",
+			`from hero with name = "batman" -> url-encode -> base64, ids = [1, 2] -> csv_join -> batch(10)`,
+			ast.Query{Blocks: []ast.Block{{
+				Method:   ast.FromMethod,
+				Resource: "hero",
+				Qualifiers: []ast.Qualifier{{
+					With: &ast.Parameters{
+						KeyValues: []ast.KeyValue{
+							{
+								Key:       "name",
+								Value:     ast.Value{Primitive: &ast.Primitive{String: String("batman")}},
+								Functions: []string{"url-encode", "base64"},
+							},
+							{
+								Key: "ids",
+								Value: ast.Value{List: []ast.Value{
+									{Primitive: &ast.Primitive{Int: Int(1)}},
+									{Primitive: &ast.Primitive{Int: Int(2)}},
+								}},
+								Functions: []string{"csv_join"},
+								Batch:     &ast.Batch{Size: 10},
+							},
+						},
+					},
+				}},
+			}}},
+		},
+		{
+			"Get query with batched query parameters",
+			`from hero with id = [1, 2, 3] -> batch(2, "hero.id")`,
+			ast.Query{Blocks: []ast.Block{{
+				Method:   ast.FromMethod,
+				Resource: "hero",
+				Qualifiers: []ast.Qualifier{{
+					With: &ast.Parameters{
+						KeyValues: []ast.KeyValue{
+							{
+								Key: "id",
+								Value: ast.Value{List: []ast.Value{
+									{Primitive: &ast.Primitive{Int: Int(1)}},
+									{Primitive: &ast.Primitive{Int: Int(2)}},
+									{Primitive: &ast.Primitive{Int: Int(3)}},
+								}},
+								Batch: &ast.Batch{Size: 2, Key: String("hero.id")},
+							},
+						},
+					},
+				}},
+			}}},
+		},
+		{
+			"Get query with chained query parameters batched without key",
+			`from hero with id = done-resource.id -> batch(10)`,
+			ast.Query{Blocks: []ast.Block{{
+				Method:   ast.FromMethod,
+				Resource: "hero",
+				Qualifiers: []ast.Qualifier{{
+					With: &ast.Parameters{
+						KeyValues: []ast.KeyValue{
+							{
+								Key:   "id",
+								Value: ast.Value{Primitive: &ast.Primitive{Chain: []ast.Chained{{PathItem: "done-resource"}, {PathItem: "id"}}}},
+								Batch: &ast.Batch{Size: 10},
+							},
+						},
+					},
+				}},
+			}}},
+		},
 		{
 			"Get query with query parameters encoded in base64",
 			`from hero with id = "abcdefg12345" -> base64`,
@@ -573,6 +702,59 @@ func TestAstGenerator(t *testing.T) {
 				},
 			}}},
 		},
+		{
+			"Get query with select filters and predicate functions",
+			`from hero
+								only
+										name -> not-matches("^Super")
+										age -> gte(18)
+										height -> lt(1.9)
+										id -> in([1, $id])
+										weapons -> contains("sword")
+										city -> equals($city)`,
+			ast.Query{Blocks: []ast.Block{{
+				Method:   ast.FromMethod,
+				Resource: "hero",
+				Qualifiers: []ast.Qualifier{
+					{Only: []ast.Filter{
+						{Field: []string{"name"}, Predicate: &ast.Predicate{Operator: ast.NotMatchesPredicate, Value: ast.Value{Primitive: &ast.Primitive{String: String("^Super")}}}},
+						{Field: []string{"age"}, Predicate: &ast.Predicate{Operator: ast.GreaterThanOrEqualPredicate, Value: ast.Value{Primitive: &ast.Primitive{Int: Int(18)}}}},
+						{Field: []string{"height"}, Predicate: &ast.Predicate{Operator: ast.LessThanPredicate, Value: ast.Value{Primitive: &ast.Primitive{Float: Float(1.9)}}}},
+						{Field: []string{"id"}, Predicate: &ast.Predicate{Operator: ast.InPredicate, Value: ast.Value{List: []ast.Value{{Primitive: &ast.Primitive{Int: Int(1)}}, {Variable: String("id")}}}}},
+						{Field: []string{"weapons"}, Predicate: &ast.Predicate{Operator: ast.ContainsPredicate, Value: ast.Value{Primitive: &ast.Primitive{String: String("sword")}}}},
+						{Field: []string{"city"}, Predicate: &ast.Predicate{Operator: ast.EqualsPredicate, Value: ast.Value{Variable: String("city")}}},
+					}},
+				},
+			}}},
+		},
+		{
+			"Get query with renamed and computed fields",
+			`from hero
+								only
+										name as heroName
+										stats.power as power
+										weapons -> matches("^b") as bWeapons
+										count(items)
+										concat(first, " ", last, 2) as fullName`,
+			ast.Query{Blocks: []ast.Block{{
+				Method:   ast.FromMethod,
+				Resource: "hero",
+				Qualifiers: []ast.Qualifier{
+					{Only: []ast.Filter{
+						{Field: []string{"name"}, Alias: String("heroName")},
+						{Field: []string{"stats", "power"}, Alias: String("power")},
+						{Field: []string{"weapons"}, Match: &ast.Match{String: String("^b")}, Alias: String("bWeapons")},
+						{Expression: &ast.Expression{Function: ast.CountExpression, Arguments: []ast.ExpressionArg{{Field: []string{"items"}}}}},
+						{Expression: &ast.Expression{Function: ast.ConcatExpression, Arguments: []ast.ExpressionArg{
+							{Field: []string{"first"}},
+							{Value: &ast.Primitive{String: String(" ")}},
+							{Field: []string{"last"}},
+							{Value: &ast.Primitive{Int: Int(2)}},
+						}}, Alias: String("fullName")},
+					}},
+				},
+			}}},
+		},
 		{
 			"Get query with hidden",
 			"from hero hidden",
@@ -583,11 +765,72 @@ func TestAstGenerator(t *testing.T) {
 			"from hero ignore-errors",
 			ast.Query{Blocks: []ast.Block{{Method: ast.FromMethod, Resource: "hero", Qualifiers: []ast.Qualifier{{IgnoreErrors: true}}}}},
 		},
+		{
+			"Get query with when variable condition",
+			"from hero when $includeHero",
+			ast.Query{Blocks: []ast.Block{{Method: ast.FromMethod, Resource: "hero", Qualifiers: []ast.Qualifier{{When: &ast.Condition{Left: ast.Value{Variable: String("includeHero")}}}}}}},
+		},
+		{
+			"Get query with negated when condition",
+			"from hero when !$skipHero",
+			ast.Query{Blocks: []ast.Block{{Method: ast.FromMethod, Resource: "hero", Qualifiers: []ast.Qualifier{{When: &ast.Condition{Negated: true, Left: ast.Value{Variable: String("skipHero")}}}}}}},
+		},
+		{
+			"Get query with when comparison condition",
+			`from sidekick with id = hero.id when hero.type == "premium" only name`,
+			ast.Query{Blocks: []ast.Block{{Method: ast.FromMethod, Resource: "sidekick", Qualifiers: []ast.Qualifier{
+				{With: &ast.Parameters{KeyValues: []ast.KeyValue{
+					{Key: "id", Value: ast.Value{Primitive: &ast.Primitive{Chain: []ast.Chained{{PathItem: "hero"}, {PathItem: "id"}}}}},
+				}}},
+				{When: &ast.Condition{
+					Left:     ast.Value{Primitive: &ast.Primitive{Chain: []ast.Chained{{PathItem: "hero"}, {PathItem: "type"}}}},
+					Operator: "==",
+					Right:    &ast.Value{Primitive: &ast.Primitive{String: String("premium")}},
+				}},
+				{Only: []ast.Filter{{Field: []string{"name"}}}},
+			}}}},
+		},
 		{
 			"Get query with integer timeout",
 			`from hero timeout 200`,
 			ast.Query{Blocks: []ast.Block{{Method: ast.FromMethod, Resource: "hero", Qualifiers: []ast.Qualifier{{Timeout: &ast.TimeoutValue{Int: Int(200)}}}}}},
 		},
+		{
+			"Get query with retry",
+			`from hero retry attempts = 3, backoff = 100, jitter = 20, status = [502, 503] with id = 1`,
+			ast.Query{Blocks: []ast.Block{{Method: ast.FromMethod, Resource: "hero", Qualifiers: []ast.Qualifier{
+				{Retry: &ast.RetryValue{Attempts: Int(3), Backoff: Int(100), Jitter: Int(20), Status: []int{502, 503}}},
+				{With: &ast.Parameters{KeyValues: []ast.KeyValue{{Key: "id", Value: ast.Value{Primitive: &ast.Primitive{Int: Int(1)}}}}}},
+			}}}},
+		},
+		{
+			"Get query with retry and timeout",
+			`from hero
+				timeout 500
+				retry attempts = 2, status = 500`,
+			ast.Query{Blocks: []ast.Block{{Method: ast.FromMethod, Resource: "hero", Qualifiers: []ast.Qualifier{
+				{Timeout: &ast.TimeoutValue{Int: Int(500)}},
+				{Retry: &ast.RetryValue{Attempts: Int(2), Status: []int{500}}},
+			}}}},
+		},
+		{
+			"Get query with paginate",
+			`from hero paginate next = "links.next", items = "data", max = 5 with id = 1`,
+			ast.Query{Blocks: []ast.Block{{Method: ast.FromMethod, Resource: "hero", Qualifiers: []ast.Qualifier{
+				{Paginate: &ast.PaginateValue{Next: String("links.next"), Items: String("data"), Max: Int(5)}},
+				{With: &ast.Parameters{KeyValues: []ast.KeyValue{{Key: "id", Value: ast.Value{Primitive: &ast.Primitive{Int: Int(1)}}}}}},
+			}}}},
+		},
+		{
+			"Get query with paginate by link header and timeout",
+			`from hero
+				timeout 500
+				paginate link = "next"`,
+			ast.Query{Blocks: []ast.Block{{Method: ast.FromMethod, Resource: "hero", Qualifiers: []ast.Qualifier{
+				{Timeout: &ast.TimeoutValue{Int: Int(500)}},
+				{Paginate: &ast.PaginateValue{Link: String("next")}},
+			}}}},
+		},
 		{
 			"Get query with variable timeout",
 			`from hero timeout $some-time`,
@@ -623,6 +866,19 @@ func TestAstGenerator(t *testing.T) {
 				{Method: ast.FromMethod, Resource: "sidekick", In: []string{"hero", "sidekick"}},
 			}},
 		},
+		{
+			"Simple from resource query with aggregation joined by field",
+			`
+							from hero
+							from weapon in hero.weapons on many id = owner.id
+							from sidekick in hero.sidekick on sidekickId = id
+						`,
+			ast.Query{Blocks: []ast.Block{
+				{Method: ast.FromMethod, Resource: "hero"},
+				{Method: ast.FromMethod, Resource: "weapon", In: []string{"hero", "weapons"}, Join: &ast.Join{Target: []string{"id"}, Origin: []string{"owner", "id"}, Many: true}},
+				{Method: ast.FromMethod, Resource: "sidekick", In: []string{"hero", "sidekick"}, Join: &ast.Join{Target: []string{"sidekickId"}, Origin: []string{"id"}}},
+			}},
+		},
 		{
 			"Full query",
 			`from hero as h
@@ -7,7 +7,7 @@ import (
 	"strings"
 )
 
-func newQuery(uses, firstBlock, otherBlocks interface{}) (Query, error) {
+func newQuery(uses, params, firstBlock, otherBlocks interface{}) (Query, error) {
 	var q Query
 
 	useList := uses.([]interface{})
@@ -20,6 +20,10 @@ func newQuery(uses, firstBlock, otherBlocks interface{}) (Query, error) {
 		q.Use = us
 	}
 
+	if params != nil {
+		q.Params = params.([]ParamDeclaration)
+	}
+
 	fb := firstBlock.(Block)
 	blocks := []interface{}{fb}
 
@@ -71,16 +75,79 @@ func newUseValue(value interface{}) (UseValue, error) {
 		return UseValue{String: &sInt}, nil
 	}
 
+	vBool, ok := value.(bool)
+	if ok {
+		return UseValue{Boolean: &vBool}, nil
+	}
+
 	return UseValue{}, errors.Errorf("unknown use value type : %T", value)
 }
 
-func newBlock(action, modifiers, with, filter, ignore interface{}) (Block, error) {
+func newParamDeclarationList(first, others interface{}) ([]ParamDeclaration, error) {
+	f := first.(ParamDeclaration)
+	result := []ParamDeclaration{f}
+
+	if others != nil {
+		os := others.([]interface{})
+		os = flatten(os)
+
+		for _, o := range os {
+			if o, ok := o.(ParamDeclaration); ok {
+				result = append(result, o)
+			}
+		}
+	}
+
+	return result, nil
+}
+
+func newParamDeclaration(name, paramType, required, defaultValue interface{}) (ParamDeclaration, error) {
+	n := name.(string)
+	t := paramType.(ParamType)
+	pd := ParamDeclaration{Name: n, Type: t}
+
+	if required != nil {
+		pd.Required = bool(required.(paramRequired))
+	}
+
+	if defaultValue != nil {
+		d := defaultValue.(Value)
+		pd.Default = &d
+	}
+
+	return pd, nil
+}
+
+func newParamType(paramType interface{}) (ParamType, error) {
+	switch t := paramType.(type) {
+	case ParamType:
+		return t, nil
+	case string:
+		return ParamType{Name: t}, nil
+	default:
+		return ParamType{}, errors.Errorf("unknown param type : %T", paramType)
+	}
+}
+
+func newListParamType(paramType interface{}) (ParamType, error) {
+	t := paramType.(string)
+	return ParamType{Name: t, List: true}, nil
+}
+
+type paramRequired bool
+
+func newParamRequired() (paramRequired, error) {
+	return true, nil
+}
+
+func newBlock(action, modifiers, with, when, filter, ignore interface{}) (Block, error) {
 	ac := action.(actionRule)
 	block := Block{
 		Method:   ac.Method,
 		Resource: ac.Resource,
 		Alias:    ac.Alias,
 		In:       ac.In,
+		Join:     ac.Join,
 	}
 
 	if modifiers != nil {
@@ -98,6 +165,10 @@ func newBlock(action, modifiers, with, filter, ignore interface{}) (Block, error
 				q = Qualifier{MaxAge: m}
 			case *SMaxAgeValue:
 				q = Qualifier{SMaxAge: m}
+			case *RetryValue:
+				q = Qualifier{Retry: m}
+			case *PaginateValue:
+				q = Qualifier{Paginate: m}
 			default:
 				continue
 			}
@@ -113,6 +184,13 @@ func newBlock(action, modifiers, with, filter, ignore interface{}) (Block, error
 		block.Qualifiers = append(block.Qualifiers, q)
 	}
 
+	if when != nil {
+		c := when.(*Condition)
+		q := Qualifier{When: c}
+
+		block.Qualifiers = append(block.Qualifiers, q)
+	}
+
 	if filter != nil {
 		var q Qualifier
 
@@ -143,6 +221,7 @@ type actionRule struct {
 	Resource string
 	Alias    string
 	In       []string
+	Join     *Join
 }
 
 func newActionRule(method, resource, alias, in interface{}) (actionRule, error) {
@@ -157,17 +236,40 @@ func newActionRule(method, resource, alias, in interface{}) (actionRule, error)
 	}
 
 	if in != nil {
-		i := in.([]string)
-		ar.In = i
+		i := in.(inClause)
+		ar.In = i.Path
+		ar.Join = i.Join
 	}
 
 	return ar, nil
 }
 
-func newIn(target interface{}) ([]string, error) {
+type inClause struct {
+	Path []string
+	Join *Join
+}
+
+func newIn(target, join interface{}) (inClause, error) {
 	t := target.(string)
 	path := strings.Split(t, ".")
-	return path, nil
+
+	i := inClause{Path: path}
+	if j, ok := join.(*Join); ok {
+		i.Join = j
+	}
+
+	return i, nil
+}
+
+func newJoin(many, target, origin interface{}) (*Join, error) {
+	t := target.(string)
+	o := origin.(string)
+
+	return &Join{
+		Target: strings.Split(t, "."),
+		Origin: strings.Split(o, "."),
+		Many:   many != nil,
+	}, nil
 }
 
 func newWith(parameterBody, keyValues interface{}) (*Parameters, error) {
@@ -236,7 +338,7 @@ func castKeyValueList(kvs []interface{}) []KeyValue {
 	return result
 }
 
-func newKeyValue(key, value, functions interface{}) (KeyValue, error) {
+func newKeyValue(key, value, functions, batch interface{}) (KeyValue, error) {
 	k := key.(string)
 	v := value.(Value)
 
@@ -246,9 +348,27 @@ func newKeyValue(key, value, functions interface{}) (KeyValue, error) {
 		kv.Functions = newFunctionList(functions)
 	}
 
+	if batch, ok := batch.(*Batch); ok {
+		kv.Batch = batch
+	}
+
 	return kv, nil
 }
 
+func newBatch(size, key interface{}) (*Batch, error) {
+	s, ok := size.(int)
+	if !ok || s <= 0 {
+		return nil, fmt.Errorf("batch size must be a positive integer : %v", size)
+	}
+
+	batch := &Batch{Size: s}
+	if k, ok := key.(string); ok {
+		batch.Key = &k
+	}
+
+	return batch, nil
+}
+
 func newFunctionList(functions interface{}) []string {
 	fns := functions.([]interface{})
 	var result []string
@@ -429,12 +549,107 @@ func newFilter(identifier, matchArg interface{}) (Filter, error) {
 		case variable:
 			matchVar := string(m)
 			filter.Match = &Match{Variable: &matchVar}
+		case Predicate:
+			filter.Predicate = &m
 		}
 	}
 
 	return filter, nil
 }
 
+func newPredicate(operator, arg interface{}) (Predicate, error) {
+	op := operator.(string)
+	value := arg.(Value)
+
+	isVariable := value.Variable != nil
+	isNumber := value.Primitive != nil && (value.Primitive.Int != nil || value.Primitive.Float != nil)
+	isString := value.Primitive != nil && value.Primitive.String != nil
+
+	switch op {
+	case NotMatchesPredicate:
+		if !isVariable && !isString {
+			return Predicate{}, errors.Errorf("%s function argument must be a string or variable", op)
+		}
+	case GreaterThanPredicate, GreaterThanOrEqualPredicate, LessThanPredicate, LessThanOrEqualPredicate:
+		if !isVariable && !isNumber {
+			return Predicate{}, errors.Errorf("%s function argument must be a number or variable", op)
+		}
+	case InPredicate:
+		if !isVariable && value.List == nil {
+			return Predicate{}, errors.Errorf("%s function argument must be a list or variable", op)
+		}
+	case EqualsPredicate, ContainsPredicate:
+		if value.List != nil {
+			return Predicate{}, errors.Errorf("%s function argument must be a primitive or variable", op)
+		}
+	}
+
+	return Predicate{Operator: op, Value: value}, nil
+}
+
+func newAliasedFilter(filter, alias interface{}) (Filter, error) {
+	var f Filter
+	switch filter := filter.(type) {
+	case Filter:
+		f = filter
+	case Expression:
+		f = Filter{Expression: &filter}
+	default:
+		return Filter{}, fmt.Errorf("got an unknown filter of type %T", filter)
+	}
+
+	if alias != nil {
+		if len(f.Field) == 1 && f.Field[0] == "*" {
+			return Filter{}, errors.New("the select all filter cannot be renamed")
+		}
+
+		a := alias.(string)
+		f.Alias = &a
+	}
+
+	return f, nil
+}
+
+func newExpression(function, first, others interface{}) (Expression, error) {
+	fn := function.(string)
+	args := []ExpressionArg{first.(ExpressionArg)}
+
+	if others != nil {
+		for _, o := range flatten(others.([]interface{})) {
+			if arg, ok := o.(ExpressionArg); ok {
+				args = append(args, arg)
+			}
+		}
+	}
+
+	switch fn {
+	case CountExpression, SumExpression:
+		if len(args) != 1 || args[0].Field == nil {
+			return Expression{}, errors.Errorf("%s function takes a single field argument", fn)
+		}
+	}
+
+	return Expression{Function: fn, Arguments: args}, nil
+}
+
+func newExpressionArg(arg interface{}) (ExpressionArg, error) {
+	if field, ok := arg.([]string); ok {
+		return ExpressionArg{Field: field}, nil
+	}
+
+	p, err := newPrimitive(arg)
+	if err != nil {
+		return ExpressionArg{}, err
+	}
+
+	return ExpressionArg{Value: p}, nil
+}
+
+func newExpressionField(field interface{}) ([]string, error) {
+	f := field.(string)
+	return strings.Split(f, "."), nil
+}
+
 func newFilterValue(value interface{}) (string, error) {
 	switch value := value.(type) {
 	case string:
@@ -446,6 +661,41 @@ func newFilterValue(value interface{}) (string, error) {
 	}
 }
 
+type negation bool
+
+func newNegation() (negation, error) {
+	return true, nil
+}
+
+type comparison struct {
+	Operator string
+	Value    Value
+}
+
+func newComparison(operator, value interface{}) (comparison, error) {
+	o := operator.(string)
+	v := value.(Value)
+
+	return comparison{Operator: o, Value: v}, nil
+}
+
+func newCondition(negated, left, cmp interface{}) (*Condition, error) {
+	l := left.(Value)
+	c := Condition{Left: l}
+
+	if negated != nil {
+		c.Negated = bool(negated.(negation))
+	}
+
+	if cmp != nil {
+		cp := cmp.(comparison)
+		c.Operator = cp.Operator
+		c.Right = &cp.Value
+	}
+
+	return &c, nil
+}
+
 type hidden bool
 
 func newHidden() (hidden, error) {
@@ -509,6 +759,134 @@ func newTimeout(value interface{}) (*TimeoutValue, error) {
 	}
 }
 
+type retryOption struct {
+	Key   string
+	Value interface{}
+}
+
+func newRetry(first, others interface{}) (*RetryValue, error) {
+	options := []interface{}{first}
+	if others != nil {
+		options = append(options, flatten(others.([]interface{}))...)
+	}
+
+	var r RetryValue
+	for _, o := range options {
+		o, ok := o.(retryOption)
+		if !ok {
+			continue
+		}
+
+		if o.Key == RetryStatus {
+			switch v := o.Value.(type) {
+			case int:
+				r.Status = []int{v}
+			case []int:
+				r.Status = v
+			}
+			continue
+		}
+
+		v, ok := o.Value.(int)
+		if !ok {
+			return nil, errors.Errorf("retry option %s must be an integer", o.Key)
+		}
+
+		switch o.Key {
+		case RetryAttempts:
+			r.Attempts = &v
+		case RetryBackoff:
+			r.Backoff = &v
+		case RetryJitter:
+			r.Jitter = &v
+		}
+	}
+
+	return &r, nil
+}
+
+func newRetryOption(key, value interface{}) (retryOption, error) {
+	k := key.(string)
+	return retryOption{Key: k, Value: value}, nil
+}
+
+type paginateOption struct {
+	Key   string
+	Value interface{}
+}
+
+func newPaginate(first, others interface{}) (*PaginateValue, error) {
+	options := []interface{}{first}
+	if others != nil {
+		options = append(options, flatten(others.([]interface{}))...)
+	}
+
+	var p PaginateValue
+	strategies := 0
+	for _, o := range options {
+		o, ok := o.(paginateOption)
+		if !ok {
+			continue
+		}
+
+		if o.Key == PaginateMax {
+			v, ok := o.Value.(int)
+			if !ok || v <= 0 {
+				return nil, errors.Errorf("paginate option %s must be a positive integer", o.Key)
+			}
+			p.Max = &v
+			continue
+		}
+
+		v, ok := o.Value.(string)
+		if !ok {
+			return nil, errors.Errorf("paginate option %s must be a string", o.Key)
+		}
+
+		switch o.Key {
+		case PaginateNext:
+			p.Next = &v
+			strategies++
+		case PaginateLink:
+			p.Link = &v
+			strategies++
+		case PaginatePage:
+			p.Page = &v
+			strategies++
+		case PaginateOffset:
+			p.Offset = &v
+			strategies++
+		case PaginateItems:
+			p.Items = &v
+		}
+	}
+
+	if strategies != 1 {
+		return nil, errors.New("paginate must have exactly one of the next, link, page or offset options")
+	}
+
+	return &p, nil
+}
+
+func newPaginateOption(key, value interface{}) (paginateOption, error) {
+	k := key.(string)
+	return paginateOption{Key: k, Value: value}, nil
+}
+
+func newIntegerList(first, others interface{}) ([]int, error) {
+	list := []int{first.(int)}
+
+	if others != nil {
+		for _, i := range flatten(others.([]interface{})) {
+			if i, ok := i.(int); ok {
+				list = append(list, i)
+			}
+		}
+	}
+
+	return list, nil
+}
+
 func newMaxAge(value interface{}) (*MaxAgeValue, error) {
 	switch value := value.(type) {
 	case variable:
@@ -86,26 +86,61 @@ var g = &grammar{
 						},
 						&labeledExpr{
 							pos:   position{line: 17, col: 66, offset: 183},
+							label: "ps",
+							expr: &zeroOrOneExpr{
+								pos: position{line: 17, col: 70, offset: 187},
+								expr: &ruleRefExpr{
+									pos:  position{line: 17, col: 70, offset: 187},
+									name: "PARAMS",
+								},
+							},
+						},
+						&ruleRefExpr{
+							pos:  position{line: 17, col: 79, offset: 196},
+							name: "WS",
+						},
+						&zeroOrMoreExpr{
+							pos: position{line: 17, col: 82, offset: 199},
+							expr: &choiceExpr{
+								pos: position{line: 17, col: 83, offset: 200},
+								alternatives: []interface{}{
+									&ruleRefExpr{
+										pos:  position{line: 17, col: 83, offset: 200},
+										name: "NL",
+									},
+									&ruleRefExpr{
+										pos:  position{line: 17, col: 88, offset: 205},
+										name: "COMMENT",
+									},
+								},
+							},
+						},
+						&ruleRefExpr{
+							pos:  position{line: 17, col: 98, offset: 215},
+							name: "WS",
+						},
+						&labeledExpr{
+							pos:   position{line: 17, col: 101, offset: 218},
 							label: "firstBlock",
 							expr: &ruleRefExpr{
-								pos:  position{line: 17, col: 77, offset: 194},
+								pos:  position{line: 17, col: 112, offset: 229},
 								name: "BLOCK",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 17, col: 83, offset: 200},
+							pos:   position{line: 17, col: 118, offset: 235},
 							label: "otherBlocks",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 17, col: 95, offset: 212},
+								pos: position{line: 17, col: 130, offset: 247},
 								expr: &seqExpr{
-									pos: position{line: 17, col: 96, offset: 213},
+									pos: position{line: 17, col: 131, offset: 248},
 									exprs: []interface{}{
 										&ruleRefExpr{
-											pos:  position{line: 17, col: 96, offset: 213},
+											pos:  position{line: 17, col: 131, offset: 248},
 											name: "BS",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 17, col: 99, offset: 216},
+											pos:  position{line: 17, col: 134, offset: 251},
 											name: "BLOCK",
 										},
 									},
@@ -113,27 +148,27 @@ var g = &grammar{
 							},
 						},
 						&zeroOrMoreExpr{
-							pos: position{line: 17, col: 107, offset: 224},
+							pos: position{line: 17, col: 142, offset: 259},
 							expr: &choiceExpr{
-								pos: position{line: 17, col: 108, offset: 225},
+								pos: position{line: 17, col: 143, offset: 260},
 								alternatives: []interface{}{
 									&ruleRefExpr{
-										pos:  position{line: 17, col: 108, offset: 225},
+										pos:  position{line: 17, col: 143, offset: 260},
 										name: "NL",
 									},
 									&ruleRefExpr{
-										pos:  position{line: 17, col: 113, offset: 230},
+										pos:  position{line: 17, col: 148, offset: 265},
 										name: "SPACE",
 									},
 									&ruleRefExpr{
-										pos:  position{line: 17, col: 121, offset: 238},
+										pos:  position{line: 17, col: 156, offset: 273},
 										name: "COMMENT",
 									},
 								},
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 17, col: 131, offset: 248},
+							pos:  position{line: 17, col: 166, offset: 283},
 							name: "EOF",
 						},
 					},
@@ -142,56 +177,74 @@ var g = &grammar{
 		},
 		{
 			name: "USE",
-			pos:  position{line: 21, col: 1, offset: 303},
+			pos:  position{line: 21, col: 1, offset: 342},
 			expr: &actionExpr{
-				pos: position{line: 21, col: 8, offset: 310},
+				pos: position{line: 21, col: 8, offset: 349},
 				run: (*parser).callonUSE1,
 				expr: &seqExpr{
-					pos: position{line: 21, col: 8, offset: 310},
+					pos: position{line: 21, col: 8, offset: 349},
 					exprs: []interface{}{
 						&litMatcher{
-							pos:        position{line: 21, col: 8, offset: 310},
+							pos:        position{line: 21, col: 8, offset: 349},
 							val:        "use",
 							ignoreCase: false,
 							want:       "\"use\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 21, col: 14, offset: 316},
+							pos:  position{line: 21, col: 14, offset: 355},
 							name: "WS_MAND",
 						},
 						&labeledExpr{
-							pos:   position{line: 21, col: 22, offset: 324},
+							pos:   position{line: 21, col: 22, offset: 363},
 							label: "r",
 							expr: &ruleRefExpr{
-								pos:  position{line: 21, col: 25, offset: 327},
+								pos:  position{line: 21, col: 25, offset: 366},
 								name: "USE_ACTION",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 21, col: 37, offset: 339},
+							pos:  position{line: 21, col: 37, offset: 378},
 							name: "WS",
 						},
+						&zeroOrOneExpr{
+							pos: position{line: 21, col: 40, offset: 381},
+							expr: &seqExpr{
+								pos: position{line: 21, col: 41, offset: 382},
+								exprs: []interface{}{
+									&litMatcher{
+										pos:        position{line: 21, col: 41, offset: 382},
+										val:        "=",
+										ignoreCase: false,
+										want:       "\"=\"",
+									},
+									&ruleRefExpr{
+										pos:  position{line: 21, col: 45, offset: 386},
+										name: "WS",
+									},
+								},
+							},
+						},
 						&labeledExpr{
-							pos:   position{line: 21, col: 40, offset: 342},
+							pos:   position{line: 21, col: 50, offset: 391},
 							label: "v",
 							expr: &ruleRefExpr{
-								pos:  position{line: 21, col: 43, offset: 345},
+								pos:  position{line: 21, col: 53, offset: 394},
 								name: "USE_VALUE",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 21, col: 54, offset: 356},
+							pos:  position{line: 21, col: 64, offset: 405},
 							name: "WS",
 						},
 						&zeroOrMoreExpr{
-							pos: position{line: 21, col: 57, offset: 359},
+							pos: position{line: 21, col: 67, offset: 408},
 							expr: &ruleRefExpr{
-								pos:  position{line: 21, col: 57, offset: 359},
+								pos:  position{line: 21, col: 67, offset: 408},
 								name: "LS",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 21, col: 61, offset: 363},
+							pos:  position{line: 21, col: 71, offset: 412},
 							name: "WS",
 						},
 					},
@@ -200,132 +253,158 @@ var g = &grammar{
 		},
 		{
 			name: "USE_ACTION",
-			pos:  position{line: 25, col: 1, offset: 392},
+			pos:  position{line: 25, col: 1, offset: 441},
 			expr: &actionExpr{
-				pos: position{line: 25, col: 15, offset: 406},
+				pos: position{line: 25, col: 15, offset: 455},
 				run: (*parser).callonUSE_ACTION1,
 				expr: &choiceExpr{
-					pos: position{line: 25, col: 16, offset: 407},
+					pos: position{line: 25, col: 16, offset: 456},
 					alternatives: []interface{}{
 						&litMatcher{
-							pos:        position{line: 25, col: 16, offset: 407},
+							pos:        position{line: 25, col: 16, offset: 456},
 							val:        "timeout",
 							ignoreCase: false,
 							want:       "\"timeout\"",
 						},
 						&litMatcher{
-							pos:        position{line: 25, col: 28, offset: 419},
+							pos:        position{line: 25, col: 28, offset: 468},
 							val:        "max-age",
 							ignoreCase: false,
 							want:       "\"max-age\"",
 						},
 						&litMatcher{
-							pos:        position{line: 25, col: 40, offset: 431},
+							pos:        position{line: 25, col: 40, offset: 480},
 							val:        "s-max-age",
 							ignoreCase: false,
 							want:       "\"s-max-age\"",
 						},
+						&litMatcher{
+							pos:        position{line: 25, col: 54, offset: 494},
+							val:        "on-timeout",
+							ignoreCase: false,
+							want:       "\"on-timeout\"",
+						},
+						&litMatcher{
+							pos:        position{line: 25, col: 69, offset: 509},
+							val:        "fail-fast",
+							ignoreCase: false,
+							want:       "\"fail-fast\"",
+						},
+						&litMatcher{
+							pos:        position{line: 25, col: 83, offset: 523},
+							val:        "max-concurrency",
+							ignoreCase: false,
+							want:       "\"max-concurrency\"",
+						},
 					},
 				},
 			},
 		},
 		{
 			name: "USE_VALUE",
-			pos:  position{line: 29, col: 1, offset: 475},
+			pos:  position{line: 29, col: 1, offset: 573},
 			expr: &actionExpr{
-				pos: position{line: 29, col: 14, offset: 488},
+				pos: position{line: 29, col: 14, offset: 586},
 				run: (*parser).callonUSE_VALUE1,
 				expr: &labeledExpr{
-					pos:   position{line: 29, col: 14, offset: 488},
+					pos:   position{line: 29, col: 14, offset: 586},
 					label: "v",
 					expr: &choiceExpr{
-						pos: position{line: 29, col: 17, offset: 491},
+						pos: position{line: 29, col: 17, offset: 589},
 						alternatives: []interface{}{
 							&ruleRefExpr{
-								pos:  position{line: 29, col: 17, offset: 491},
+								pos:  position{line: 29, col: 17, offset: 589},
 								name: "String",
 							},
 							&ruleRefExpr{
-								pos:  position{line: 29, col: 26, offset: 500},
+								pos:  position{line: 29, col: 26, offset: 598},
 								name: "Integer",
 							},
+							&ruleRefExpr{
+								pos:  position{line: 29, col: 36, offset: 608},
+								name: "Boolean",
+							},
 						},
 					},
 				},
 			},
 		},
 		{
-			name: "BLOCK",
-			pos:  position{line: 33, col: 1, offset: 537},
+			name: "PARAMS",
+			pos:  position{line: 33, col: 1, offset: 645},
 			expr: &actionExpr{
-				pos: position{line: 33, col: 10, offset: 546},
-				run: (*parser).callonBLOCK1,
+				pos: position{line: 33, col: 11, offset: 655},
+				run: (*parser).callonPARAMS1,
 				expr: &seqExpr{
-					pos: position{line: 33, col: 10, offset: 546},
+					pos: position{line: 33, col: 11, offset: 655},
 					exprs: []interface{}{
-						&labeledExpr{
-							pos:   position{line: 33, col: 10, offset: 546},
-							label: "action",
-							expr: &ruleRefExpr{
-								pos:  position{line: 33, col: 18, offset: 554},
-								name: "ACTION_RULE",
-							},
+						&litMatcher{
+							pos:        position{line: 33, col: 11, offset: 655},
+							val:        "params",
+							ignoreCase: false,
+							want:       "\"params\"",
 						},
-						&labeledExpr{
-							pos:   position{line: 33, col: 31, offset: 567},
-							label: "m",
-							expr: &zeroOrOneExpr{
-								pos: position{line: 33, col: 34, offset: 570},
-								expr: &ruleRefExpr{
-									pos:  position{line: 33, col: 34, offset: 570},
-									name: "MODIFIER_RULE",
-								},
-							},
+						&ruleRefExpr{
+							pos:  position{line: 33, col: 20, offset: 664},
+							name: "WS_MAND",
 						},
 						&labeledExpr{
-							pos:   position{line: 33, col: 50, offset: 586},
-							label: "w",
-							expr: &zeroOrOneExpr{
-								pos: position{line: 33, col: 53, offset: 589},
-								expr: &ruleRefExpr{
-									pos:  position{line: 33, col: 53, offset: 589},
-									name: "WITH_RULE",
-								},
+							pos:   position{line: 33, col: 28, offset: 672},
+							label: "first",
+							expr: &ruleRefExpr{
+								pos:  position{line: 33, col: 34, offset: 678},
+								name: "PARAM_DECLARATION",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 33, col: 65, offset: 601},
-							label: "f",
-							expr: &zeroOrOneExpr{
-								pos: position{line: 33, col: 67, offset: 603},
-								expr: &choiceExpr{
-									pos: position{line: 33, col: 68, offset: 604},
-									alternatives: []interface{}{
+							pos:   position{line: 33, col: 52, offset: 696},
+							label: "others",
+							expr: &zeroOrMoreExpr{
+								pos: position{line: 33, col: 59, offset: 703},
+								expr: &seqExpr{
+									pos: position{line: 33, col: 60, offset: 704},
+									exprs: []interface{}{
+										&oneOrMoreExpr{
+											pos: position{line: 33, col: 60, offset: 704},
+											expr: &seqExpr{
+												pos: position{line: 33, col: 61, offset: 705},
+												exprs: []interface{}{
+													&ruleRefExpr{
+														pos:  position{line: 33, col: 61, offset: 705},
+														name: "WS",
+													},
+													&ruleRefExpr{
+														pos:  position{line: 33, col: 64, offset: 708},
+														name: "LS",
+													},
+												},
+											},
+										},
 										&ruleRefExpr{
-											pos:  position{line: 33, col: 68, offset: 604},
-											name: "HIDDEN_RULE",
+											pos:  position{line: 33, col: 69, offset: 713},
+											name: "WS",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 33, col: 82, offset: 618},
-											name: "ONLY_RULE",
+											pos:  position{line: 33, col: 72, offset: 716},
+											name: "PARAM_DECLARATION",
 										},
 									},
 								},
 							},
 						},
-						&labeledExpr{
-							pos:   position{line: 33, col: 94, offset: 630},
-							label: "fl",
-							expr: &zeroOrOneExpr{
-								pos: position{line: 33, col: 98, offset: 634},
-								expr: &ruleRefExpr{
-									pos:  position{line: 33, col: 98, offset: 634},
-									name: "FLAGS_RULE",
-								},
+						&ruleRefExpr{
+							pos:  position{line: 33, col: 92, offset: 736},
+							name: "WS",
+						},
+						&zeroOrMoreExpr{
+							pos: position{line: 33, col: 95, offset: 739},
+							expr: &ruleRefExpr{
+								pos:  position{line: 33, col: 95, offset: 739},
+								name: "LS",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 33, col: 111, offset: 647},
+							pos:  position{line: 33, col: 99, offset: 743},
 							name: "WS",
 						},
 					},
@@ -333,53 +412,69 @@ var g = &grammar{
 			},
 		},
 		{
-			name: "ACTION_RULE",
-			pos:  position{line: 37, col: 1, offset: 693},
+			name: "PARAM_DECLARATION",
+			pos:  position{line: 37, col: 1, offset: 798},
 			expr: &actionExpr{
-				pos: position{line: 37, col: 16, offset: 708},
-				run: (*parser).callonACTION_RULE1,
+				pos: position{line: 37, col: 22, offset: 819},
+				run: (*parser).callonPARAM_DECLARATION1,
 				expr: &seqExpr{
-					pos: position{line: 37, col: 16, offset: 708},
+					pos: position{line: 37, col: 22, offset: 819},
 					exprs: []interface{}{
+						&litMatcher{
+							pos:        position{line: 37, col: 22, offset: 819},
+							val:        "$",
+							ignoreCase: false,
+							want:       "\"$\"",
+						},
 						&labeledExpr{
-							pos:   position{line: 37, col: 16, offset: 708},
-							label: "m",
+							pos:   position{line: 37, col: 26, offset: 823},
+							label: "n",
 							expr: &ruleRefExpr{
-								pos:  position{line: 37, col: 19, offset: 711},
-								name: "METHOD",
+								pos:  position{line: 37, col: 29, offset: 826},
+								name: "IDENT",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 37, col: 27, offset: 719},
-							name: "WS_MAND",
+							pos:  position{line: 37, col: 36, offset: 833},
+							name: "WS",
+						},
+						&litMatcher{
+							pos:        position{line: 37, col: 39, offset: 836},
+							val:        ":",
+							ignoreCase: false,
+							want:       "\":\"",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 37, col: 43, offset: 840},
+							name: "WS",
 						},
 						&labeledExpr{
-							pos:   position{line: 37, col: 35, offset: 727},
-							label: "r",
+							pos:   position{line: 37, col: 46, offset: 843},
+							label: "t",
 							expr: &ruleRefExpr{
-								pos:  position{line: 37, col: 38, offset: 730},
-								name: "IDENT",
+								pos:  position{line: 37, col: 49, offset: 846},
+								name: "PARAM_TYPE",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 37, col: 45, offset: 737},
-							label: "a",
+							pos:   position{line: 37, col: 61, offset: 858},
+							label: "r",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 37, col: 48, offset: 740},
+								pos: position{line: 37, col: 64, offset: 861},
 								expr: &ruleRefExpr{
-									pos:  position{line: 37, col: 48, offset: 740},
-									name: "ALIAS",
+									pos:  position{line: 37, col: 64, offset: 861},
+									name: "PARAM_REQUIRED",
 								},
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 37, col: 56, offset: 748},
-							label: "i",
+							pos:   position{line: 37, col: 81, offset: 878},
+							label: "d",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 37, col: 59, offset: 751},
+								pos: position{line: 37, col: 84, offset: 881},
 								expr: &ruleRefExpr{
-									pos:  position{line: 37, col: 59, offset: 751},
-									name: "IN",
+									pos:  position{line: 37, col: 84, offset: 881},
+									name: "PARAM_DEFAULT",
 								},
 							},
 						},
@@ -388,147 +483,178 @@ var g = &grammar{
 			},
 		},
 		{
-			name: "METHOD",
-			pos:  position{line: 41, col: 1, offset: 795},
+			name: "PARAM_TYPE",
+			pos:  position{line: 41, col: 1, offset: 942},
 			expr: &actionExpr{
-				pos: position{line: 41, col: 11, offset: 805},
-				run: (*parser).callonMETHOD1,
+				pos: position{line: 41, col: 15, offset: 956},
+				run: (*parser).callonPARAM_TYPE1,
+				expr: &labeledExpr{
+					pos:   position{line: 41, col: 15, offset: 956},
+					label: "t",
+					expr: &choiceExpr{
+						pos: position{line: 41, col: 18, offset: 959},
+						alternatives: []interface{}{
+							&ruleRefExpr{
+								pos:  position{line: 41, col: 18, offset: 959},
+								name: "PARAM_LIST_TYPE",
+							},
+							&ruleRefExpr{
+								pos:  position{line: 41, col: 36, offset: 977},
+								name: "PARAM_TYPE_NAME",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "PARAM_LIST_TYPE",
+			pos:  position{line: 45, col: 1, offset: 1023},
+			expr: &actionExpr{
+				pos: position{line: 45, col: 20, offset: 1042},
+				run: (*parser).callonPARAM_LIST_TYPE1,
+				expr: &seqExpr{
+					pos: position{line: 45, col: 20, offset: 1042},
+					exprs: []interface{}{
+						&litMatcher{
+							pos:        position{line: 45, col: 20, offset: 1042},
+							val:        "[",
+							ignoreCase: false,
+							want:       "\"[\"",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 45, col: 24, offset: 1046},
+							name: "WS",
+						},
+						&labeledExpr{
+							pos:   position{line: 45, col: 27, offset: 1049},
+							label: "t",
+							expr: &ruleRefExpr{
+								pos:  position{line: 45, col: 30, offset: 1052},
+								name: "PARAM_TYPE_NAME",
+							},
+						},
+						&ruleRefExpr{
+							pos:  position{line: 45, col: 47, offset: 1069},
+							name: "WS",
+						},
+						&litMatcher{
+							pos:        position{line: 45, col: 50, offset: 1072},
+							val:        "]",
+							ignoreCase: false,
+							want:       "\"]\"",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "PARAM_TYPE_NAME",
+			pos:  position{line: 49, col: 1, offset: 1109},
+			expr: &actionExpr{
+				pos: position{line: 49, col: 20, offset: 1128},
+				run: (*parser).callonPARAM_TYPE_NAME1,
 				expr: &choiceExpr{
-					pos: position{line: 41, col: 12, offset: 806},
+					pos: position{line: 49, col: 21, offset: 1129},
 					alternatives: []interface{}{
 						&litMatcher{
-							pos:        position{line: 41, col: 12, offset: 806},
-							val:        "from",
+							pos:        position{line: 49, col: 21, offset: 1129},
+							val:        "string",
 							ignoreCase: false,
-							want:       "\"from\"",
+							want:       "\"string\"",
 						},
 						&litMatcher{
-							pos:        position{line: 41, col: 21, offset: 815},
-							val:        "to",
+							pos:        position{line: 49, col: 32, offset: 1140},
+							val:        "int",
 							ignoreCase: false,
-							want:       "\"to\"",
+							want:       "\"int\"",
 						},
 						&litMatcher{
-							pos:        position{line: 41, col: 28, offset: 822},
-							val:        "into",
+							pos:        position{line: 49, col: 40, offset: 1148},
+							val:        "float",
 							ignoreCase: false,
-							want:       "\"into\"",
+							want:       "\"float\"",
 						},
 						&litMatcher{
-							pos:        position{line: 41, col: 36, offset: 830},
-							val:        "update",
+							pos:        position{line: 49, col: 50, offset: 1158},
+							val:        "boolean",
 							ignoreCase: false,
-							want:       "\"update\"",
+							want:       "\"boolean\"",
 						},
 						&litMatcher{
-							pos:        position{line: 41, col: 47, offset: 841},
-							val:        "delete",
+							pos:        position{line: 49, col: 62, offset: 1170},
+							val:        "object",
 							ignoreCase: false,
-							want:       "\"delete\"",
+							want:       "\"object\"",
 						},
 					},
 				},
 			},
 		},
 		{
-			name: "ALIAS",
-			pos:  position{line: 45, col: 1, offset: 882},
+			name: "PARAM_REQUIRED",
+			pos:  position{line: 53, col: 1, offset: 1211},
 			expr: &actionExpr{
-				pos: position{line: 45, col: 10, offset: 891},
-				run: (*parser).callonALIAS1,
+				pos: position{line: 53, col: 19, offset: 1229},
+				run: (*parser).callonPARAM_REQUIRED1,
 				expr: &seqExpr{
-					pos: position{line: 45, col: 10, offset: 891},
+					pos: position{line: 53, col: 19, offset: 1229},
 					exprs: []interface{}{
 						&ruleRefExpr{
-							pos:  position{line: 45, col: 10, offset: 891},
-							name: "WS_MAND",
+							pos:  position{line: 53, col: 19, offset: 1229},
+							name: "WS",
 						},
 						&litMatcher{
-							pos:        position{line: 45, col: 18, offset: 899},
-							val:        "as",
+							pos:        position{line: 53, col: 22, offset: 1232},
+							val:        "!",
 							ignoreCase: false,
-							want:       "\"as\"",
-						},
-						&ruleRefExpr{
-							pos:  position{line: 45, col: 23, offset: 904},
-							name: "WS_MAND",
-						},
-						&labeledExpr{
-							pos:   position{line: 45, col: 31, offset: 912},
-							label: "a",
-							expr: &ruleRefExpr{
-								pos:  position{line: 45, col: 34, offset: 915},
-								name: "IDENT",
-							},
+							want:       "\"!\"",
 						},
 					},
 				},
 			},
 		},
 		{
-			name: "IN",
-			pos:  position{line: 49, col: 1, offset: 942},
+			name: "PARAM_DEFAULT",
+			pos:  position{line: 57, col: 1, offset: 1268},
 			expr: &actionExpr{
-				pos: position{line: 49, col: 7, offset: 948},
-				run: (*parser).callonIN1,
+				pos: position{line: 57, col: 18, offset: 1285},
+				run: (*parser).callonPARAM_DEFAULT1,
 				expr: &seqExpr{
-					pos: position{line: 49, col: 7, offset: 948},
+					pos: position{line: 57, col: 18, offset: 1285},
 					exprs: []interface{}{
 						&ruleRefExpr{
-							pos:  position{line: 49, col: 7, offset: 948},
-							name: "WS_MAND",
+							pos:  position{line: 57, col: 18, offset: 1285},
+							name: "WS",
 						},
 						&litMatcher{
-							pos:        position{line: 49, col: 15, offset: 956},
-							val:        "in",
+							pos:        position{line: 57, col: 21, offset: 1288},
+							val:        "=",
 							ignoreCase: false,
-							want:       "\"in\"",
+							want:       "\"=\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 49, col: 20, offset: 961},
-							name: "WS_MAND",
+							pos:  position{line: 57, col: 25, offset: 1292},
+							name: "WS",
 						},
 						&labeledExpr{
-							pos:   position{line: 49, col: 28, offset: 969},
-							label: "t",
-							expr: &ruleRefExpr{
-								pos:  position{line: 49, col: 31, offset: 972},
-								name: "IDENT_WITH_DOT",
-							},
-						},
-					},
-				},
-			},
-		},
-		{
-			name: "MODIFIER_RULE",
-			pos:  position{line: 53, col: 1, offset: 1010},
-			expr: &actionExpr{
-				pos: position{line: 53, col: 18, offset: 1027},
-				run: (*parser).callonMODIFIER_RULE1,
-				expr: &labeledExpr{
-					pos:   position{line: 53, col: 18, offset: 1027},
-					label: "m",
-					expr: &oneOrMoreExpr{
-						pos: position{line: 53, col: 20, offset: 1029},
-						expr: &choiceExpr{
-							pos: position{line: 53, col: 21, offset: 1030},
-							alternatives: []interface{}{
-								&ruleRefExpr{
-									pos:  position{line: 53, col: 21, offset: 1030},
-									name: "HEADERS",
-								},
-								&ruleRefExpr{
-									pos:  position{line: 53, col: 31, offset: 1040},
-									name: "TIMEOUT",
-								},
-								&ruleRefExpr{
-									pos:  position{line: 53, col: 41, offset: 1050},
-									name: "MAX_AGE",
-								},
-								&ruleRefExpr{
-									pos:  position{line: 53, col: 51, offset: 1060},
-									name: "S_MAX_AGE",
+							pos:   position{line: 57, col: 28, offset: 1295},
+							label: "v",
+							expr: &choiceExpr{
+								pos: position{line: 57, col: 31, offset: 1298},
+								alternatives: []interface{}{
+									&ruleRefExpr{
+										pos:  position{line: 57, col: 31, offset: 1298},
+										name: "LIST",
+									},
+									&ruleRefExpr{
+										pos:  position{line: 57, col: 38, offset: 1305},
+										name: "OBJECT",
+									},
+									&ruleRefExpr{
+										pos:  position{line: 57, col: 47, offset: 1314},
+										name: "PRIMITIVE",
+									},
 								},
 							},
 						},
@@ -537,48 +663,1018 @@ var g = &grammar{
 			},
 		},
 		{
-			name: "WITH_RULE",
-			pos:  position{line: 57, col: 1, offset: 1092},
+			name: "BLOCK",
+			pos:  position{line: 61, col: 1, offset: 1350},
 			expr: &actionExpr{
-				pos: position{line: 57, col: 14, offset: 1105},
-				run: (*parser).callonWITH_RULE1,
+				pos: position{line: 61, col: 10, offset: 1359},
+				run: (*parser).callonBLOCK1,
 				expr: &seqExpr{
-					pos: position{line: 57, col: 14, offset: 1105},
+					pos: position{line: 61, col: 10, offset: 1359},
 					exprs: []interface{}{
-						&ruleRefExpr{
-							pos:  position{line: 57, col: 14, offset: 1105},
-							name: "WS_MAND",
-						},
+						&labeledExpr{
+							pos:   position{line: 61, col: 10, offset: 1359},
+							label: "action",
+							expr: &ruleRefExpr{
+								pos:  position{line: 61, col: 18, offset: 1367},
+								name: "ACTION_RULE",
+							},
+						},
+						&labeledExpr{
+							pos:   position{line: 61, col: 31, offset: 1380},
+							label: "m",
+							expr: &zeroOrOneExpr{
+								pos: position{line: 61, col: 34, offset: 1383},
+								expr: &ruleRefExpr{
+									pos:  position{line: 61, col: 34, offset: 1383},
+									name: "MODIFIER_RULE",
+								},
+							},
+						},
+						&labeledExpr{
+							pos:   position{line: 61, col: 50, offset: 1399},
+							label: "w",
+							expr: &zeroOrOneExpr{
+								pos: position{line: 61, col: 53, offset: 1402},
+								expr: &ruleRefExpr{
+									pos:  position{line: 61, col: 53, offset: 1402},
+									name: "WITH_RULE",
+								},
+							},
+						},
+						&labeledExpr{
+							pos:   position{line: 61, col: 65, offset: 1414},
+							label: "wh",
+							expr: &zeroOrOneExpr{
+								pos: position{line: 61, col: 69, offset: 1418},
+								expr: &ruleRefExpr{
+									pos:  position{line: 61, col: 69, offset: 1418},
+									name: "WHEN_RULE",
+								},
+							},
+						},
+						&labeledExpr{
+							pos:   position{line: 61, col: 81, offset: 1430},
+							label: "f",
+							expr: &zeroOrOneExpr{
+								pos: position{line: 61, col: 83, offset: 1432},
+								expr: &choiceExpr{
+									pos: position{line: 61, col: 84, offset: 1433},
+									alternatives: []interface{}{
+										&ruleRefExpr{
+											pos:  position{line: 61, col: 84, offset: 1433},
+											name: "HIDDEN_RULE",
+										},
+										&ruleRefExpr{
+											pos:  position{line: 61, col: 98, offset: 1447},
+											name: "ONLY_RULE",
+										},
+									},
+								},
+							},
+						},
+						&labeledExpr{
+							pos:   position{line: 61, col: 110, offset: 1459},
+							label: "fl",
+							expr: &zeroOrOneExpr{
+								pos: position{line: 61, col: 114, offset: 1463},
+								expr: &ruleRefExpr{
+									pos:  position{line: 61, col: 114, offset: 1463},
+									name: "FLAGS_RULE",
+								},
+							},
+						},
+						&ruleRefExpr{
+							pos:  position{line: 61, col: 127, offset: 1476},
+							name: "WS",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "ACTION_RULE",
+			pos:  position{line: 65, col: 1, offset: 1526},
+			expr: &actionExpr{
+				pos: position{line: 65, col: 16, offset: 1541},
+				run: (*parser).callonACTION_RULE1,
+				expr: &seqExpr{
+					pos: position{line: 65, col: 16, offset: 1541},
+					exprs: []interface{}{
+						&labeledExpr{
+							pos:   position{line: 65, col: 16, offset: 1541},
+							label: "m",
+							expr: &ruleRefExpr{
+								pos:  position{line: 65, col: 19, offset: 1544},
+								name: "METHOD",
+							},
+						},
+						&ruleRefExpr{
+							pos:  position{line: 65, col: 27, offset: 1552},
+							name: "WS_MAND",
+						},
+						&labeledExpr{
+							pos:   position{line: 65, col: 35, offset: 1560},
+							label: "r",
+							expr: &ruleRefExpr{
+								pos:  position{line: 65, col: 38, offset: 1563},
+								name: "IDENT",
+							},
+						},
+						&labeledExpr{
+							pos:   position{line: 65, col: 45, offset: 1570},
+							label: "a",
+							expr: &zeroOrOneExpr{
+								pos: position{line: 65, col: 48, offset: 1573},
+								expr: &ruleRefExpr{
+									pos:  position{line: 65, col: 48, offset: 1573},
+									name: "ALIAS",
+								},
+							},
+						},
+						&labeledExpr{
+							pos:   position{line: 65, col: 56, offset: 1581},
+							label: "i",
+							expr: &zeroOrOneExpr{
+								pos: position{line: 65, col: 59, offset: 1584},
+								expr: &ruleRefExpr{
+									pos:  position{line: 65, col: 59, offset: 1584},
+									name: "IN",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "METHOD",
+			pos:  position{line: 69, col: 1, offset: 1628},
+			expr: &actionExpr{
+				pos: position{line: 69, col: 11, offset: 1638},
+				run: (*parser).callonMETHOD1,
+				expr: &choiceExpr{
+					pos: position{line: 69, col: 12, offset: 1639},
+					alternatives: []interface{}{
 						&litMatcher{
-							pos:        position{line: 57, col: 22, offset: 1113},
-							val:        "with",
+							pos:        position{line: 69, col: 12, offset: 1639},
+							val:        "from",
 							ignoreCase: false,
-							want:       "\"with\"",
+							want:       "\"from\"",
+						},
+						&litMatcher{
+							pos:        position{line: 69, col: 21, offset: 1648},
+							val:        "to",
+							ignoreCase: false,
+							want:       "\"to\"",
+						},
+						&litMatcher{
+							pos:        position{line: 69, col: 28, offset: 1655},
+							val:        "into",
+							ignoreCase: false,
+							want:       "\"into\"",
+						},
+						&litMatcher{
+							pos:        position{line: 69, col: 36, offset: 1663},
+							val:        "update",
+							ignoreCase: false,
+							want:       "\"update\"",
+						},
+						&litMatcher{
+							pos:        position{line: 69, col: 47, offset: 1674},
+							val:        "delete",
+							ignoreCase: false,
+							want:       "\"delete\"",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "ALIAS",
+			pos:  position{line: 73, col: 1, offset: 1715},
+			expr: &actionExpr{
+				pos: position{line: 73, col: 10, offset: 1724},
+				run: (*parser).callonALIAS1,
+				expr: &seqExpr{
+					pos: position{line: 73, col: 10, offset: 1724},
+					exprs: []interface{}{
+						&ruleRefExpr{
+							pos:  position{line: 73, col: 10, offset: 1724},
+							name: "WS_MAND",
+						},
+						&litMatcher{
+							pos:        position{line: 73, col: 18, offset: 1732},
+							val:        "as",
+							ignoreCase: false,
+							want:       "\"as\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 57, col: 29, offset: 1120},
+							pos:  position{line: 73, col: 23, offset: 1737},
 							name: "WS_MAND",
 						},
 						&labeledExpr{
-							pos:   position{line: 57, col: 37, offset: 1128},
-							label: "pb",
+							pos:   position{line: 73, col: 31, offset: 1745},
+							label: "a",
+							expr: &ruleRefExpr{
+								pos:  position{line: 73, col: 34, offset: 1748},
+								name: "IDENT",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "IN",
+			pos:  position{line: 77, col: 1, offset: 1775},
+			expr: &actionExpr{
+				pos: position{line: 77, col: 7, offset: 1781},
+				run: (*parser).callonIN1,
+				expr: &seqExpr{
+					pos: position{line: 77, col: 7, offset: 1781},
+					exprs: []interface{}{
+						&ruleRefExpr{
+							pos:  position{line: 77, col: 7, offset: 1781},
+							name: "WS_MAND",
+						},
+						&litMatcher{
+							pos:        position{line: 77, col: 15, offset: 1789},
+							val:        "in",
+							ignoreCase: false,
+							want:       "\"in\"",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 77, col: 20, offset: 1794},
+							name: "WS_MAND",
+						},
+						&labeledExpr{
+							pos:   position{line: 77, col: 28, offset: 1802},
+							label: "t",
+							expr: &ruleRefExpr{
+								pos:  position{line: 77, col: 31, offset: 1805},
+								name: "IDENT_WITH_DOT",
+							},
+						},
+						&labeledExpr{
+							pos:   position{line: 77, col: 47, offset: 1821},
+							label: "j",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 57, col: 40, offset: 1131},
+								pos: position{line: 77, col: 50, offset: 1824},
 								expr: &ruleRefExpr{
-									pos:  position{line: 57, col: 40, offset: 1131},
-									name: "PARAMETER_BODY",
+									pos:  position{line: 77, col: 50, offset: 1824},
+									name: "JOIN",
 								},
 							},
 						},
+					},
+				},
+			},
+		},
+		{
+			name: "JOIN",
+			pos:  position{line: 81, col: 1, offset: 1856},
+			expr: &actionExpr{
+				pos: position{line: 81, col: 9, offset: 1864},
+				run: (*parser).callonJOIN1,
+				expr: &seqExpr{
+					pos: position{line: 81, col: 9, offset: 1864},
+					exprs: []interface{}{
+						&ruleRefExpr{
+							pos:  position{line: 81, col: 9, offset: 1864},
+							name: "WS_MAND",
+						},
+						&litMatcher{
+							pos:        position{line: 81, col: 17, offset: 1872},
+							val:        "on",
+							ignoreCase: false,
+							want:       "\"on\"",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 81, col: 22, offset: 1877},
+							name: "WS_MAND",
+						},
 						&labeledExpr{
-							pos:   position{line: 57, col: 56, offset: 1147},
-							label: "kvs",
+							pos:   position{line: 81, col: 30, offset: 1885},
+							label: "m",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 57, col: 60, offset: 1151},
+								pos: position{line: 81, col: 33, offset: 1888},
 								expr: &ruleRefExpr{
-									pos:  position{line: 57, col: 60, offset: 1151},
-									name: "KEY_VALUE_LIST",
+									pos:  position{line: 81, col: 33, offset: 1888},
+									name: "JOIN_MANY",
+								},
+							},
+						},
+						&labeledExpr{
+							pos:   position{line: 81, col: 45, offset: 1900},
+							label: "l",
+							expr: &ruleRefExpr{
+								pos:  position{line: 81, col: 48, offset: 1903},
+								name: "IDENT_WITH_DOT",
+							},
+						},
+						&ruleRefExpr{
+							pos:  position{line: 81, col: 64, offset: 1919},
+							name: "WS",
+						},
+						&litMatcher{
+							pos:        position{line: 81, col: 67, offset: 1922},
+							val:        "=",
+							ignoreCase: false,
+							want:       "\"=\"",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 81, col: 71, offset: 1926},
+							name: "WS",
+						},
+						&labeledExpr{
+							pos:   position{line: 81, col: 74, offset: 1929},
+							label: "r",
+							expr: &ruleRefExpr{
+								pos:  position{line: 81, col: 77, offset: 1932},
+								name: "IDENT_WITH_DOT",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "JOIN_MANY",
+			pos:  position{line: 85, col: 1, offset: 1978},
+			expr: &actionExpr{
+				pos: position{line: 85, col: 14, offset: 1991},
+				run: (*parser).callonJOIN_MANY1,
+				expr: &seqExpr{
+					pos: position{line: 85, col: 14, offset: 1991},
+					exprs: []interface{}{
+						&litMatcher{
+							pos:        position{line: 85, col: 14, offset: 1991},
+							val:        "many",
+							ignoreCase: false,
+							want:       "\"many\"",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 85, col: 21, offset: 1998},
+							name: "WS_MAND",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "MODIFIER_RULE",
+			pos:  position{line: 89, col: 1, offset: 2029},
+			expr: &actionExpr{
+				pos: position{line: 89, col: 18, offset: 2046},
+				run: (*parser).callonMODIFIER_RULE1,
+				expr: &labeledExpr{
+					pos:   position{line: 89, col: 18, offset: 2046},
+					label: "m",
+					expr: &oneOrMoreExpr{
+						pos: position{line: 89, col: 20, offset: 2048},
+						expr: &choiceExpr{
+							pos: position{line: 89, col: 21, offset: 2049},
+							alternatives: []interface{}{
+								&ruleRefExpr{
+									pos:  position{line: 89, col: 21, offset: 2049},
+									name: "HEADERS",
 								},
+								&ruleRefExpr{
+									pos:  position{line: 89, col: 31, offset: 2059},
+									name: "TIMEOUT",
+								},
+								&ruleRefExpr{
+									pos:  position{line: 89, col: 41, offset: 2069},
+									name: "MAX_AGE",
+								},
+								&ruleRefExpr{
+									pos:  position{line: 89, col: 51, offset: 2079},
+									name: "S_MAX_AGE",
+								},
+								&ruleRefExpr{
+									pos:  position{line: 89, col: 63, offset: 2091},
+									name: "RETRY",
+								},
+								&ruleRefExpr{
+									pos:  position{line: 89, col: 71, offset: 2099},
+									name: "PAGINATE",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "WITH_RULE",
+			pos:  position{line: 93, col: 1, offset: 2130},
+			expr: &actionExpr{
+				pos: position{line: 93, col: 14, offset: 2143},
+				run: (*parser).callonWITH_RULE1,
+				expr: &seqExpr{
+					pos: position{line: 93, col: 14, offset: 2143},
+					exprs: []interface{}{
+						&ruleRefExpr{
+							pos:  position{line: 93, col: 14, offset: 2143},
+							name: "WS_MAND",
+						},
+						&litMatcher{
+							pos:        position{line: 93, col: 22, offset: 2151},
+							val:        "with",
+							ignoreCase: false,
+							want:       "\"with\"",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 93, col: 29, offset: 2158},
+							name: "WS_MAND",
+						},
+						&labeledExpr{
+							pos:   position{line: 93, col: 37, offset: 2166},
+							label: "pb",
+							expr: &zeroOrOneExpr{
+								pos: position{line: 93, col: 40, offset: 2169},
+								expr: &ruleRefExpr{
+									pos:  position{line: 93, col: 40, offset: 2169},
+									name: "PARAMETER_BODY",
+								},
+							},
+						},
+						&labeledExpr{
+							pos:   position{line: 93, col: 56, offset: 2185},
+							label: "kvs",
+							expr: &zeroOrOneExpr{
+								pos: position{line: 93, col: 60, offset: 2189},
+								expr: &ruleRefExpr{
+									pos:  position{line: 93, col: 60, offset: 2189},
+									name: "KEY_VALUE_LIST",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "PARAMETER_BODY",
+			pos:  position{line: 97, col: 1, offset: 2235},
+			expr: &actionExpr{
+				pos: position{line: 97, col: 19, offset: 2253},
+				run: (*parser).callonPARAMETER_BODY1,
+				expr: &seqExpr{
+					pos: position{line: 97, col: 19, offset: 2253},
+					exprs: []interface{}{
+						&litMatcher{
+							pos:        position{line: 97, col: 19, offset: 2253},
+							val:        "$",
+							ignoreCase: false,
+							want:       "\"$\"",
+						},
+						&labeledExpr{
+							pos:   position{line: 97, col: 23, offset: 2257},
+							label: "t",
+							expr: &ruleRefExpr{
+								pos:  position{line: 97, col: 26, offset: 2260},
+								name: "IDENT",
+							},
+						},
+						&labeledExpr{
+							pos:   position{line: 97, col: 33, offset: 2267},
+							label: "fn",
+							expr: &zeroOrMoreExpr{
+								pos: position{line: 97, col: 36, offset: 2270},
+								expr: &ruleRefExpr{
+									pos:  position{line: 97, col: 37, offset: 2271},
+									name: "APPLY_FN",
+								},
+							},
+						},
+						&ruleRefExpr{
+							pos:  position{line: 97, col: 48, offset: 2282},
+							name: "WS",
+						},
+						&zeroOrOneExpr{
+							pos: position{line: 97, col: 51, offset: 2285},
+							expr: &ruleRefExpr{
+								pos:  position{line: 97, col: 51, offset: 2285},
+								name: "LS",
+							},
+						},
+						&ruleRefExpr{
+							pos:  position{line: 97, col: 55, offset: 2289},
+							name: "WS",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "KEY_VALUE_LIST",
+			pos:  position{line: 101, col: 1, offset: 2329},
+			expr: &actionExpr{
+				pos: position{line: 101, col: 19, offset: 2347},
+				run: (*parser).callonKEY_VALUE_LIST1,
+				expr: &seqExpr{
+					pos: position{line: 101, col: 19, offset: 2347},
+					exprs: []interface{}{
+						&labeledExpr{
+							pos:   position{line: 101, col: 19, offset: 2347},
+							label: "first",
+							expr: &ruleRefExpr{
+								pos:  position{line: 101, col: 25, offset: 2353},
+								name: "KEY_VALUE",
+							},
+						},
+						&labeledExpr{
+							pos:   position{line: 101, col: 35, offset: 2363},
+							label: "others",
+							expr: &zeroOrMoreExpr{
+								pos: position{line: 101, col: 42, offset: 2370},
+								expr: &seqExpr{
+									pos: position{line: 101, col: 43, offset: 2371},
+									exprs: []interface{}{
+										&ruleRefExpr{
+											pos:  position{line: 101, col: 43, offset: 2371},
+											name: "WS",
+										},
+										&choiceExpr{
+											pos: position{line: 101, col: 47, offset: 2375},
+											alternatives: []interface{}{
+												&seqExpr{
+													pos: position{line: 101, col: 47, offset: 2375},
+													exprs: []interface{}{
+														&ruleRefExpr{
+															pos:  position{line: 101, col: 47, offset: 2375},
+															name: "LS",
+														},
+														&zeroOrMoreExpr{
+															pos: position{line: 101, col: 50, offset: 2378},
+															expr: &seqExpr{
+																pos: position{line: 101, col: 51, offset: 2379},
+																exprs: []interface{}{
+																	&ruleRefExpr{
+																		pos:  position{line: 101, col: 51, offset: 2379},
+																		name: "WS",
+																	},
+																	&ruleRefExpr{
+																		pos:  position{line: 101, col: 54, offset: 2382},
+																		name: "NL",
+																	},
+																	&ruleRefExpr{
+																		pos:  position{line: 101, col: 57, offset: 2385},
+																		name: "WS",
+																	},
+																},
+															},
+														},
+													},
+												},
+												&ruleRefExpr{
+													pos:  position{line: 101, col: 64, offset: 2392},
+													name: "LS",
+												},
+											},
+										},
+										&ruleRefExpr{
+											pos:  position{line: 101, col: 68, offset: 2396},
+											name: "WS",
+										},
+										&ruleRefExpr{
+											pos:  position{line: 101, col: 71, offset: 2399},
+											name: "KEY_VALUE",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "KEY_VALUE",
+			pos:  position{line: 105, col: 1, offset: 2455},
+			expr: &actionExpr{
+				pos: position{line: 105, col: 14, offset: 2468},
+				run: (*parser).callonKEY_VALUE1,
+				expr: &seqExpr{
+					pos: position{line: 105, col: 14, offset: 2468},
+					exprs: []interface{}{
+						&labeledExpr{
+							pos:   position{line: 105, col: 14, offset: 2468},
+							label: "k",
+							expr: &ruleRefExpr{
+								pos:  position{line: 105, col: 17, offset: 2471},
+								name: "IDENT_WITH_DOT",
+							},
+						},
+						&ruleRefExpr{
+							pos:  position{line: 105, col: 33, offset: 2487},
+							name: "WS",
+						},
+						&litMatcher{
+							pos:        position{line: 105, col: 36, offset: 2490},
+							val:        "=",
+							ignoreCase: false,
+							want:       "\"=\"",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 105, col: 40, offset: 2494},
+							name: "WS",
+						},
+						&labeledExpr{
+							pos:   position{line: 105, col: 43, offset: 2497},
+							label: "v",
+							expr: &ruleRefExpr{
+								pos:  position{line: 105, col: 46, offset: 2500},
+								name: "VALUE",
+							},
+						},
+						&labeledExpr{
+							pos:   position{line: 105, col: 53, offset: 2507},
+							label: "fn",
+							expr: &zeroOrMoreExpr{
+								pos: position{line: 105, col: 56, offset: 2510},
+								expr: &ruleRefExpr{
+									pos:  position{line: 105, col: 57, offset: 2511},
+									name: "APPLY_FN",
+								},
+							},
+						},
+						&labeledExpr{
+							pos:   position{line: 105, col: 68, offset: 2522},
+							label: "b",
+							expr: &zeroOrOneExpr{
+								pos: position{line: 105, col: 71, offset: 2525},
+								expr: &ruleRefExpr{
+									pos:  position{line: 105, col: 71, offset: 2525},
+									name: "BATCH_FN",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "APPLY_FN",
+			pos:  position{line: 109, col: 1, offset: 2574},
+			expr: &actionExpr{
+				pos: position{line: 109, col: 13, offset: 2586},
+				run: (*parser).callonAPPLY_FN1,
+				expr: &seqExpr{
+					pos: position{line: 109, col: 13, offset: 2586},
+					exprs: []interface{}{
+						&ruleRefExpr{
+							pos:  position{line: 109, col: 13, offset: 2586},
+							name: "WS",
+						},
+						&litMatcher{
+							pos:        position{line: 109, col: 16, offset: 2589},
+							val:        "->",
+							ignoreCase: false,
+							want:       "\"->\"",
+						},
+						&zeroOrOneExpr{
+							pos: position{line: 109, col: 21, offset: 2594},
+							expr: &ruleRefExpr{
+								pos:  position{line: 109, col: 21, offset: 2594},
+								name: "WS",
+							},
+						},
+						&labeledExpr{
+							pos:   position{line: 109, col: 25, offset: 2598},
+							label: "fn",
+							expr: &ruleRefExpr{
+								pos:  position{line: 109, col: 29, offset: 2602},
+								name: "FUNCTION",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "BATCH_FN",
+			pos:  position{line: 113, col: 1, offset: 2633},
+			expr: &actionExpr{
+				pos: position{line: 113, col: 13, offset: 2645},
+				run: (*parser).callonBATCH_FN1,
+				expr: &seqExpr{
+					pos: position{line: 113, col: 13, offset: 2645},
+					exprs: []interface{}{
+						&ruleRefExpr{
+							pos:  position{line: 113, col: 13, offset: 2645},
+							name: "WS",
+						},
+						&litMatcher{
+							pos:        position{line: 113, col: 16, offset: 2648},
+							val:        "->",
+							ignoreCase: false,
+							want:       "\"->\"",
+						},
+						&zeroOrOneExpr{
+							pos: position{line: 113, col: 21, offset: 2653},
+							expr: &ruleRefExpr{
+								pos:  position{line: 113, col: 21, offset: 2653},
+								name: "WS",
+							},
+						},
+						&litMatcher{
+							pos:        position{line: 113, col: 25, offset: 2657},
+							val:        "batch",
+							ignoreCase: false,
+							want:       "\"batch\"",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 113, col: 33, offset: 2665},
+							name: "WS",
+						},
+						&litMatcher{
+							pos:        position{line: 113, col: 36, offset: 2668},
+							val:        "(",
+							ignoreCase: false,
+							want:       "\"(\"",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 113, col: 40, offset: 2672},
+							name: "WS",
+						},
+						&labeledExpr{
+							pos:   position{line: 113, col: 43, offset: 2675},
+							label: "s",
+							expr: &ruleRefExpr{
+								pos:  position{line: 113, col: 46, offset: 2678},
+								name: "Integer",
+							},
+						},
+						&labeledExpr{
+							pos:   position{line: 113, col: 55, offset: 2687},
+							label: "k",
+							expr: &zeroOrOneExpr{
+								pos: position{line: 113, col: 58, offset: 2690},
+								expr: &ruleRefExpr{
+									pos:  position{line: 113, col: 58, offset: 2690},
+									name: "BATCH_KEY",
+								},
+							},
+						},
+						&ruleRefExpr{
+							pos:  position{line: 113, col: 70, offset: 2702},
+							name: "WS",
+						},
+						&litMatcher{
+							pos:        position{line: 113, col: 73, offset: 2705},
+							val:        ")",
+							ignoreCase: false,
+							want:       "\")\"",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "BATCH_KEY",
+			pos:  position{line: 117, col: 1, offset: 2737},
+			expr: &actionExpr{
+				pos: position{line: 117, col: 14, offset: 2750},
+				run: (*parser).callonBATCH_KEY1,
+				expr: &seqExpr{
+					pos: position{line: 117, col: 14, offset: 2750},
+					exprs: []interface{}{
+						&ruleRefExpr{
+							pos:  position{line: 117, col: 14, offset: 2750},
+							name: "WS",
+						},
+						&litMatcher{
+							pos:        position{line: 117, col: 17, offset: 2753},
+							val:        ",",
+							ignoreCase: false,
+							want:       "\",\"",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 117, col: 21, offset: 2757},
+							name: "WS",
+						},
+						&labeledExpr{
+							pos:   position{line: 117, col: 24, offset: 2760},
+							label: "k",
+							expr: &ruleRefExpr{
+								pos:  position{line: 117, col: 27, offset: 2763},
+								name: "String",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "FUNCTION",
+			pos:  position{line: 121, col: 1, offset: 2791},
+			expr: &actionExpr{
+				pos: position{line: 121, col: 13, offset: 2803},
+				run: (*parser).callonFUNCTION1,
+				expr: &seqExpr{
+					pos: position{line: 121, col: 13, offset: 2803},
+					exprs: []interface{}{
+						&labeledExpr{
+							pos:   position{line: 121, col: 13, offset: 2803},
+							label: "fn",
+							expr: &ruleRefExpr{
+								pos:  position{line: 121, col: 17, offset: 2807},
+								name: "IDENT",
+							},
+						},
+						&notExpr{
+							pos: position{line: 121, col: 24, offset: 2814},
+							expr: &litMatcher{
+								pos:        position{line: 121, col: 25, offset: 2815},
+								val:        "(",
+								ignoreCase: false,
+								want:       "\"(\"",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "VALUE",
+			pos:  position{line: 125, col: 1, offset: 2840},
+			expr: &actionExpr{
+				pos: position{line: 125, col: 10, offset: 2849},
+				run: (*parser).callonVALUE1,
+				expr: &labeledExpr{
+					pos:   position{line: 125, col: 10, offset: 2849},
+					label: "v",
+					expr: &choiceExpr{
+						pos: position{line: 125, col: 13, offset: 2852},
+						alternatives: []interface{}{
+							&ruleRefExpr{
+								pos:  position{line: 125, col: 13, offset: 2852},
+								name: "LIST",
+							},
+							&ruleRefExpr{
+								pos:  position{line: 125, col: 20, offset: 2859},
+								name: "OBJECT",
+							},
+							&ruleRefExpr{
+								pos:  position{line: 125, col: 29, offset: 2868},
+								name: "VARIABLE",
+							},
+							&ruleRefExpr{
+								pos:  position{line: 125, col: 40, offset: 2879},
+								name: "PRIMITIVE",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "LIST",
+			pos:  position{line: 129, col: 1, offset: 2915},
+			expr: &actionExpr{
+				pos: position{line: 129, col: 9, offset: 2923},
+				run: (*parser).callonLIST1,
+				expr: &labeledExpr{
+					pos:   position{line: 129, col: 9, offset: 2923},
+					label: "l",
+					expr: &choiceExpr{
+						pos: position{line: 129, col: 12, offset: 2926},
+						alternatives: []interface{}{
+							&ruleRefExpr{
+								pos:  position{line: 129, col: 12, offset: 2926},
+								name: "EMPTY_LIST",
+							},
+							&ruleRefExpr{
+								pos:  position{line: 129, col: 25, offset: 2939},
+								name: "POPULATED_LIST",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "EMPTY_LIST",
+			pos:  position{line: 133, col: 1, offset: 2975},
+			expr: &actionExpr{
+				pos: position{line: 133, col: 15, offset: 2989},
+				run: (*parser).callonEMPTY_LIST1,
+				expr: &seqExpr{
+					pos: position{line: 133, col: 15, offset: 2989},
+					exprs: []interface{}{
+						&litMatcher{
+							pos:        position{line: 133, col: 15, offset: 2989},
+							val:        "[",
+							ignoreCase: false,
+							want:       "\"[\"",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 133, col: 19, offset: 2993},
+							name: "WS",
+						},
+						&litMatcher{
+							pos:        position{line: 133, col: 22, offset: 2996},
+							val:        "]",
+							ignoreCase: false,
+							want:       "\"]\"",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "POPULATED_LIST",
+			pos:  position{line: 137, col: 1, offset: 3028},
+			expr: &actionExpr{
+				pos: position{line: 137, col: 19, offset: 3046},
+				run: (*parser).callonPOPULATED_LIST1,
+				expr: &seqExpr{
+					pos: position{line: 137, col: 19, offset: 3046},
+					exprs: []interface{}{
+						&litMatcher{
+							pos:        position{line: 137, col: 19, offset: 3046},
+							val:        "[",
+							ignoreCase: false,
+							want:       "\"[\"",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 137, col: 23, offset: 3050},
+							name: "WS",
+						},
+						&labeledExpr{
+							pos:   position{line: 137, col: 26, offset: 3053},
+							label: "i",
+							expr: &ruleRefExpr{
+								pos:  position{line: 137, col: 28, offset: 3055},
+								name: "VALUE",
+							},
+						},
+						&labeledExpr{
+							pos:   position{line: 137, col: 34, offset: 3061},
+							label: "ii",
+							expr: &zeroOrMoreExpr{
+								pos: position{line: 137, col: 37, offset: 3064},
+								expr: &seqExpr{
+									pos: position{line: 137, col: 38, offset: 3065},
+									exprs: []interface{}{
+										&ruleRefExpr{
+											pos:  position{line: 137, col: 38, offset: 3065},
+											name: "WS",
+										},
+										&zeroOrMoreExpr{
+											pos: position{line: 137, col: 41, offset: 3068},
+											expr: &ruleRefExpr{
+												pos:  position{line: 137, col: 41, offset: 3068},
+												name: "LS",
+											},
+										},
+										&ruleRefExpr{
+											pos:  position{line: 137, col: 45, offset: 3072},
+											name: "WS",
+										},
+										&ruleRefExpr{
+											pos:  position{line: 137, col: 48, offset: 3075},
+											name: "VALUE",
+										},
+									},
+								},
+							},
+						},
+						&ruleRefExpr{
+							pos:  position{line: 137, col: 56, offset: 3083},
+							name: "WS",
+						},
+						&litMatcher{
+							pos:        position{line: 137, col: 59, offset: 3086},
+							val:        "]",
+							ignoreCase: false,
+							want:       "\"]\"",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "OBJECT",
+			pos:  position{line: 141, col: 1, offset: 3118},
+			expr: &actionExpr{
+				pos: position{line: 141, col: 11, offset: 3128},
+				run: (*parser).callonOBJECT1,
+				expr: &labeledExpr{
+					pos:   position{line: 141, col: 11, offset: 3128},
+					label: "o",
+					expr: &choiceExpr{
+						pos: position{line: 141, col: 14, offset: 3131},
+						alternatives: []interface{}{
+							&ruleRefExpr{
+								pos:  position{line: 141, col: 14, offset: 3131},
+								name: "EMPTY_OBJ",
+							},
+							&ruleRefExpr{
+								pos:  position{line: 141, col: 26, offset: 3143},
+								name: "POPULATED_OBJ",
 							},
 						},
 					},
@@ -586,227 +1682,195 @@ var g = &grammar{
 			},
 		},
 		{
-			name: "PARAMETER_BODY",
-			pos:  position{line: 61, col: 1, offset: 1197},
+			name: "EMPTY_OBJ",
+			pos:  position{line: 145, col: 1, offset: 3178},
 			expr: &actionExpr{
-				pos: position{line: 61, col: 19, offset: 1215},
-				run: (*parser).callonPARAMETER_BODY1,
+				pos: position{line: 145, col: 14, offset: 3191},
+				run: (*parser).callonEMPTY_OBJ1,
 				expr: &seqExpr{
-					pos: position{line: 61, col: 19, offset: 1215},
+					pos: position{line: 145, col: 14, offset: 3191},
 					exprs: []interface{}{
 						&litMatcher{
-							pos:        position{line: 61, col: 19, offset: 1215},
-							val:        "$",
+							pos:        position{line: 145, col: 14, offset: 3191},
+							val:        "{",
 							ignoreCase: false,
-							want:       "\"$\"",
-						},
-						&labeledExpr{
-							pos:   position{line: 61, col: 23, offset: 1219},
-							label: "t",
-							expr: &ruleRefExpr{
-								pos:  position{line: 61, col: 26, offset: 1222},
-								name: "IDENT",
-							},
-						},
-						&labeledExpr{
-							pos:   position{line: 61, col: 33, offset: 1229},
-							label: "fn",
-							expr: &zeroOrMoreExpr{
-								pos: position{line: 61, col: 36, offset: 1232},
-								expr: &ruleRefExpr{
-									pos:  position{line: 61, col: 37, offset: 1233},
-									name: "APPLY_FN",
-								},
-							},
+							want:       "\"{\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 61, col: 48, offset: 1244},
+							pos:  position{line: 145, col: 18, offset: 3195},
 							name: "WS",
 						},
-						&zeroOrOneExpr{
-							pos: position{line: 61, col: 51, offset: 1247},
+						&zeroOrMoreExpr{
+							pos: position{line: 145, col: 21, offset: 3198},
 							expr: &ruleRefExpr{
-								pos:  position{line: 61, col: 51, offset: 1247},
-								name: "LS",
+								pos:  position{line: 145, col: 21, offset: 3198},
+								name: "NL",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 61, col: 55, offset: 1251},
+							pos:  position{line: 145, col: 25, offset: 3202},
 							name: "WS",
 						},
+						&litMatcher{
+							pos:        position{line: 145, col: 28, offset: 3205},
+							val:        "}",
+							ignoreCase: false,
+							want:       "\"}\"",
+						},
 					},
 				},
 			},
 		},
 		{
-			name: "KEY_VALUE_LIST",
-			pos:  position{line: 65, col: 1, offset: 1291},
+			name: "POPULATED_OBJ",
+			pos:  position{line: 149, col: 1, offset: 3239},
 			expr: &actionExpr{
-				pos: position{line: 65, col: 19, offset: 1309},
-				run: (*parser).callonKEY_VALUE_LIST1,
+				pos: position{line: 149, col: 18, offset: 3256},
+				run: (*parser).callonPOPULATED_OBJ1,
 				expr: &seqExpr{
-					pos: position{line: 65, col: 19, offset: 1309},
+					pos: position{line: 149, col: 18, offset: 3256},
 					exprs: []interface{}{
+						&litMatcher{
+							pos:        position{line: 149, col: 18, offset: 3256},
+							val:        "{",
+							ignoreCase: false,
+							want:       "\"{\"",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 149, col: 22, offset: 3260},
+							name: "WS",
+						},
+						&zeroOrMoreExpr{
+							pos: position{line: 149, col: 25, offset: 3263},
+							expr: &ruleRefExpr{
+								pos:  position{line: 149, col: 25, offset: 3263},
+								name: "NL",
+							},
+						},
+						&ruleRefExpr{
+							pos:  position{line: 149, col: 29, offset: 3267},
+							name: "WS",
+						},
 						&labeledExpr{
-							pos:   position{line: 65, col: 19, offset: 1309},
-							label: "first",
+							pos:   position{line: 149, col: 32, offset: 3270},
+							label: "oe",
 							expr: &ruleRefExpr{
-								pos:  position{line: 65, col: 25, offset: 1315},
-								name: "KEY_VALUE",
+								pos:  position{line: 149, col: 36, offset: 3274},
+								name: "OBJ_ENTRY",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 65, col: 35, offset: 1325},
-							label: "others",
+							pos:   position{line: 149, col: 47, offset: 3285},
+							label: "oes",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 65, col: 42, offset: 1332},
+								pos: position{line: 149, col: 51, offset: 3289},
 								expr: &seqExpr{
-									pos: position{line: 65, col: 43, offset: 1333},
+									pos: position{line: 149, col: 52, offset: 3290},
 									exprs: []interface{}{
 										&ruleRefExpr{
-											pos:  position{line: 65, col: 43, offset: 1333},
+											pos:  position{line: 149, col: 52, offset: 3290},
 											name: "WS",
 										},
-										&choiceExpr{
-											pos: position{line: 65, col: 47, offset: 1337},
-											alternatives: []interface{}{
-												&seqExpr{
-													pos: position{line: 65, col: 47, offset: 1337},
-													exprs: []interface{}{
-														&ruleRefExpr{
-															pos:  position{line: 65, col: 47, offset: 1337},
-															name: "LS",
-														},
-														&zeroOrMoreExpr{
-															pos: position{line: 65, col: 50, offset: 1340},
-															expr: &seqExpr{
-																pos: position{line: 65, col: 51, offset: 1341},
-																exprs: []interface{}{
-																	&ruleRefExpr{
-																		pos:  position{line: 65, col: 51, offset: 1341},
-																		name: "WS",
-																	},
-																	&ruleRefExpr{
-																		pos:  position{line: 65, col: 54, offset: 1344},
-																		name: "NL",
-																	},
-																	&ruleRefExpr{
-																		pos:  position{line: 65, col: 57, offset: 1347},
-																		name: "WS",
-																	},
-																},
-															},
-														},
-													},
-												},
-												&ruleRefExpr{
-													pos:  position{line: 65, col: 64, offset: 1354},
-													name: "LS",
-												},
+										&litMatcher{
+											pos:        position{line: 149, col: 55, offset: 3293},
+											val:        ",",
+											ignoreCase: false,
+											want:       "\",\"",
+										},
+										&ruleRefExpr{
+											pos:  position{line: 149, col: 59, offset: 3297},
+											name: "WS",
+										},
+										&zeroOrMoreExpr{
+											pos: position{line: 149, col: 62, offset: 3300},
+											expr: &ruleRefExpr{
+												pos:  position{line: 149, col: 62, offset: 3300},
+												name: "NL",
 											},
 										},
 										&ruleRefExpr{
-											pos:  position{line: 65, col: 68, offset: 1358},
+											pos:  position{line: 149, col: 66, offset: 3304},
 											name: "WS",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 65, col: 71, offset: 1361},
-											name: "KEY_VALUE",
+											pos:  position{line: 149, col: 69, offset: 3307},
+											name: "OBJ_ENTRY",
 										},
 									},
 								},
 							},
 						},
-					},
-				},
-			},
-		},
-		{
-			name: "KEY_VALUE",
-			pos:  position{line: 69, col: 1, offset: 1417},
-			expr: &actionExpr{
-				pos: position{line: 69, col: 14, offset: 1430},
-				run: (*parser).callonKEY_VALUE1,
-				expr: &seqExpr{
-					pos: position{line: 69, col: 14, offset: 1430},
-					exprs: []interface{}{
-						&labeledExpr{
-							pos:   position{line: 69, col: 14, offset: 1430},
-							label: "k",
+						&ruleRefExpr{
+							pos:  position{line: 149, col: 81, offset: 3319},
+							name: "WS",
+						},
+						&zeroOrMoreExpr{
+							pos: position{line: 149, col: 84, offset: 3322},
 							expr: &ruleRefExpr{
-								pos:  position{line: 69, col: 17, offset: 1433},
-								name: "IDENT_WITH_DOT",
+								pos:  position{line: 149, col: 84, offset: 3322},
+								name: "NL",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 69, col: 33, offset: 1449},
+							pos:  position{line: 149, col: 88, offset: 3326},
 							name: "WS",
 						},
 						&litMatcher{
-							pos:        position{line: 69, col: 36, offset: 1452},
-							val:        "=",
+							pos:        position{line: 149, col: 91, offset: 3329},
+							val:        "}",
 							ignoreCase: false,
-							want:       "\"=\"",
-						},
-						&ruleRefExpr{
-							pos:  position{line: 69, col: 40, offset: 1456},
-							name: "WS",
-						},
-						&labeledExpr{
-							pos:   position{line: 69, col: 43, offset: 1459},
-							label: "v",
-							expr: &ruleRefExpr{
-								pos:  position{line: 69, col: 46, offset: 1462},
-								name: "VALUE",
-							},
-						},
-						&labeledExpr{
-							pos:   position{line: 69, col: 53, offset: 1469},
-							label: "fn",
-							expr: &zeroOrMoreExpr{
-								pos: position{line: 69, col: 56, offset: 1472},
-								expr: &ruleRefExpr{
-									pos:  position{line: 69, col: 57, offset: 1473},
-									name: "APPLY_FN",
-								},
-							},
+							want:       "\"}\"",
 						},
 					},
 				},
 			},
 		},
 		{
-			name: "APPLY_FN",
-			pos:  position{line: 73, col: 1, offset: 1519},
+			name: "OBJ_ENTRY",
+			pos:  position{line: 153, col: 1, offset: 3374},
 			expr: &actionExpr{
-				pos: position{line: 73, col: 13, offset: 1531},
-				run: (*parser).callonAPPLY_FN1,
+				pos: position{line: 153, col: 14, offset: 3387},
+				run: (*parser).callonOBJ_ENTRY1,
 				expr: &seqExpr{
-					pos: position{line: 73, col: 13, offset: 1531},
+					pos: position{line: 153, col: 14, offset: 3387},
 					exprs: []interface{}{
+						&labeledExpr{
+							pos:   position{line: 153, col: 14, offset: 3387},
+							label: "k",
+							expr: &choiceExpr{
+								pos: position{line: 153, col: 17, offset: 3390},
+								alternatives: []interface{}{
+									&ruleRefExpr{
+										pos:  position{line: 153, col: 17, offset: 3390},
+										name: "String",
+									},
+									&ruleRefExpr{
+										pos:  position{line: 153, col: 26, offset: 3399},
+										name: "IDENT",
+									},
+								},
+							},
+						},
 						&ruleRefExpr{
-							pos:  position{line: 73, col: 13, offset: 1531},
+							pos:  position{line: 153, col: 33, offset: 3406},
 							name: "WS",
 						},
 						&litMatcher{
-							pos:        position{line: 73, col: 16, offset: 1534},
-							val:        "->",
+							pos:        position{line: 153, col: 36, offset: 3409},
+							val:        ":",
 							ignoreCase: false,
-							want:       "\"->\"",
+							want:       "\":\"",
 						},
-						&zeroOrOneExpr{
-							pos: position{line: 73, col: 21, offset: 1539},
-							expr: &ruleRefExpr{
-								pos:  position{line: 73, col: 21, offset: 1539},
-								name: "WS",
-							},
+						&ruleRefExpr{
+							pos:  position{line: 153, col: 40, offset: 3413},
+							name: "WS",
 						},
 						&labeledExpr{
-							pos:   position{line: 73, col: 25, offset: 1543},
-							label: "fn",
+							pos:   position{line: 153, col: 43, offset: 3416},
+							label: "v",
 							expr: &ruleRefExpr{
-								pos:  position{line: 73, col: 29, offset: 1547},
-								name: "FUNCTION",
+								pos:  position{line: 153, col: 46, offset: 3419},
+								name: "VALUE",
 							},
 						},
 					},
@@ -814,75 +1878,40 @@ var g = &grammar{
 			},
 		},
 		{
-			name: "FUNCTION",
-			pos:  position{line: 77, col: 1, offset: 1578},
-			expr: &actionExpr{
-				pos: position{line: 77, col: 13, offset: 1590},
-				run: (*parser).callonFUNCTION1,
-				expr: &choiceExpr{
-					pos: position{line: 77, col: 14, offset: 1591},
-					alternatives: []interface{}{
-						&litMatcher{
-							pos:        position{line: 77, col: 14, offset: 1591},
-							val:        "no-multiplex",
-							ignoreCase: false,
-							want:       "\"no-multiplex\"",
-						},
-						&litMatcher{
-							pos:        position{line: 77, col: 31, offset: 1608},
-							val:        "base64",
-							ignoreCase: false,
-							want:       "\"base64\"",
-						},
-						&litMatcher{
-							pos:        position{line: 77, col: 42, offset: 1619},
-							val:        "json",
-							ignoreCase: false,
-							want:       "\"json\"",
-						},
-						&litMatcher{
-							pos:        position{line: 77, col: 50, offset: 1627},
-							val:        "as-body",
-							ignoreCase: false,
-							want:       "\"as-body\"",
-						},
-						&litMatcher{
-							pos:        position{line: 77, col: 62, offset: 1639},
-							val:        "flatten",
-							ignoreCase: false,
-							want:       "\"flatten\"",
-						},
-					},
-				},
-			},
-		},
-		{
-			name: "VALUE",
-			pos:  position{line: 81, col: 1, offset: 1681},
+			name: "PRIMITIVE",
+			pos:  position{line: 157, col: 1, offset: 3460},
 			expr: &actionExpr{
-				pos: position{line: 81, col: 10, offset: 1690},
-				run: (*parser).callonVALUE1,
+				pos: position{line: 157, col: 14, offset: 3473},
+				run: (*parser).callonPRIMITIVE1,
 				expr: &labeledExpr{
-					pos:   position{line: 81, col: 10, offset: 1690},
-					label: "v",
+					pos:   position{line: 157, col: 14, offset: 3473},
+					label: "p",
 					expr: &choiceExpr{
-						pos: position{line: 81, col: 13, offset: 1693},
+						pos: position{line: 157, col: 17, offset: 3476},
 						alternatives: []interface{}{
 							&ruleRefExpr{
-								pos:  position{line: 81, col: 13, offset: 1693},
-								name: "LIST",
+								pos:  position{line: 157, col: 17, offset: 3476},
+								name: "Null",
 							},
 							&ruleRefExpr{
-								pos:  position{line: 81, col: 20, offset: 1700},
-								name: "OBJECT",
+								pos:  position{line: 157, col: 24, offset: 3483},
+								name: "Boolean",
 							},
 							&ruleRefExpr{
-								pos:  position{line: 81, col: 29, offset: 1709},
-								name: "VARIABLE",
+								pos:  position{line: 157, col: 34, offset: 3493},
+								name: "String",
 							},
 							&ruleRefExpr{
-								pos:  position{line: 81, col: 40, offset: 1720},
-								name: "PRIMITIVE",
+								pos:  position{line: 157, col: 43, offset: 3502},
+								name: "Float",
+							},
+							&ruleRefExpr{
+								pos:  position{line: 157, col: 51, offset: 3510},
+								name: "Integer",
+							},
+							&ruleRefExpr{
+								pos:  position{line: 157, col: 61, offset: 3520},
+								name: "CHAIN",
 							},
 						},
 					},
@@ -890,24 +1919,56 @@ var g = &grammar{
 			},
 		},
 		{
-			name: "LIST",
-			pos:  position{line: 85, col: 1, offset: 1756},
+			name: "WHEN_RULE",
+			pos:  position{line: 163, col: 1, offset: 3558},
 			expr: &actionExpr{
-				pos: position{line: 85, col: 9, offset: 1764},
-				run: (*parser).callonLIST1,
-				expr: &labeledExpr{
-					pos:   position{line: 85, col: 9, offset: 1764},
-					label: "l",
-					expr: &choiceExpr{
-						pos: position{line: 85, col: 12, offset: 1767},
-						alternatives: []interface{}{
-							&ruleRefExpr{
-								pos:  position{line: 85, col: 12, offset: 1767},
-								name: "EMPTY_LIST",
+				pos: position{line: 163, col: 14, offset: 3571},
+				run: (*parser).callonWHEN_RULE1,
+				expr: &seqExpr{
+					pos: position{line: 163, col: 14, offset: 3571},
+					exprs: []interface{}{
+						&ruleRefExpr{
+							pos:  position{line: 163, col: 14, offset: 3571},
+							name: "WS_MAND",
+						},
+						&litMatcher{
+							pos:        position{line: 163, col: 22, offset: 3579},
+							val:        "when",
+							ignoreCase: false,
+							want:       "\"when\"",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 163, col: 29, offset: 3586},
+							name: "WS_MAND",
+						},
+						&labeledExpr{
+							pos:   position{line: 163, col: 37, offset: 3594},
+							label: "n",
+							expr: &zeroOrOneExpr{
+								pos: position{line: 163, col: 40, offset: 3597},
+								expr: &ruleRefExpr{
+									pos:  position{line: 163, col: 40, offset: 3597},
+									name: "NEGATION",
+								},
 							},
-							&ruleRefExpr{
-								pos:  position{line: 85, col: 25, offset: 1780},
-								name: "POPULATED_LIST",
+						},
+						&labeledExpr{
+							pos:   position{line: 163, col: 51, offset: 3608},
+							label: "l",
+							expr: &ruleRefExpr{
+								pos:  position{line: 163, col: 54, offset: 3611},
+								name: "CONDITION_OPERAND",
+							},
+						},
+						&labeledExpr{
+							pos:   position{line: 163, col: 73, offset: 3630},
+							label: "cmp",
+							expr: &zeroOrOneExpr{
+								pos: position{line: 163, col: 78, offset: 3635},
+								expr: &ruleRefExpr{
+									pos:  position{line: 163, col: 78, offset: 3635},
+									name: "CONDITION_COMPARISON",
+								},
 							},
 						},
 					},
@@ -915,125 +1976,109 @@ var g = &grammar{
 			},
 		},
 		{
-			name: "EMPTY_LIST",
-			pos:  position{line: 89, col: 1, offset: 1816},
+			name: "NEGATION",
+			pos:  position{line: 167, col: 1, offset: 3695},
 			expr: &actionExpr{
-				pos: position{line: 89, col: 15, offset: 1830},
-				run: (*parser).callonEMPTY_LIST1,
+				pos: position{line: 167, col: 13, offset: 3707},
+				run: (*parser).callonNEGATION1,
 				expr: &seqExpr{
-					pos: position{line: 89, col: 15, offset: 1830},
+					pos: position{line: 167, col: 13, offset: 3707},
 					exprs: []interface{}{
 						&litMatcher{
-							pos:        position{line: 89, col: 15, offset: 1830},
-							val:        "[",
+							pos:        position{line: 167, col: 13, offset: 3707},
+							val:        "!",
 							ignoreCase: false,
-							want:       "\"[\"",
+							want:       "\"!\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 89, col: 19, offset: 1834},
+							pos:  position{line: 167, col: 17, offset: 3711},
 							name: "WS",
 						},
-						&litMatcher{
-							pos:        position{line: 89, col: 22, offset: 1837},
-							val:        "]",
-							ignoreCase: false,
-							want:       "\"]\"",
-						},
 					},
 				},
 			},
 		},
 		{
-			name: "POPULATED_LIST",
-			pos:  position{line: 93, col: 1, offset: 1869},
+			name: "CONDITION_COMPARISON",
+			pos:  position{line: 171, col: 1, offset: 3741},
 			expr: &actionExpr{
-				pos: position{line: 93, col: 19, offset: 1887},
-				run: (*parser).callonPOPULATED_LIST1,
+				pos: position{line: 171, col: 25, offset: 3765},
+				run: (*parser).callonCONDITION_COMPARISON1,
 				expr: &seqExpr{
-					pos: position{line: 93, col: 19, offset: 1887},
+					pos: position{line: 171, col: 25, offset: 3765},
 					exprs: []interface{}{
-						&litMatcher{
-							pos:        position{line: 93, col: 19, offset: 1887},
-							val:        "[",
-							ignoreCase: false,
-							want:       "\"[\"",
-						},
 						&ruleRefExpr{
-							pos:  position{line: 93, col: 23, offset: 1891},
+							pos:  position{line: 171, col: 25, offset: 3765},
 							name: "WS",
 						},
 						&labeledExpr{
-							pos:   position{line: 93, col: 26, offset: 1894},
-							label: "i",
+							pos:   position{line: 171, col: 28, offset: 3768},
+							label: "o",
 							expr: &ruleRefExpr{
-								pos:  position{line: 93, col: 28, offset: 1896},
-								name: "VALUE",
+								pos:  position{line: 171, col: 31, offset: 3771},
+								name: "CONDITION_OPERATOR",
 							},
 						},
+						&ruleRefExpr{
+							pos:  position{line: 171, col: 51, offset: 3791},
+							name: "WS",
+						},
 						&labeledExpr{
-							pos:   position{line: 93, col: 34, offset: 1902},
-							label: "ii",
-							expr: &zeroOrMoreExpr{
-								pos: position{line: 93, col: 37, offset: 1905},
-								expr: &seqExpr{
-									pos: position{line: 93, col: 38, offset: 1906},
-									exprs: []interface{}{
-										&ruleRefExpr{
-											pos:  position{line: 93, col: 38, offset: 1906},
-											name: "WS",
-										},
-										&zeroOrMoreExpr{
-											pos: position{line: 93, col: 41, offset: 1909},
-											expr: &ruleRefExpr{
-												pos:  position{line: 93, col: 41, offset: 1909},
-												name: "LS",
-											},
-										},
-										&ruleRefExpr{
-											pos:  position{line: 93, col: 45, offset: 1913},
-											name: "WS",
-										},
-										&ruleRefExpr{
-											pos:  position{line: 93, col: 48, offset: 1916},
-											name: "VALUE",
-										},
-									},
-								},
+							pos:   position{line: 171, col: 54, offset: 3794},
+							label: "v",
+							expr: &ruleRefExpr{
+								pos:  position{line: 171, col: 57, offset: 3797},
+								name: "CONDITION_OPERAND",
 							},
 						},
-						&ruleRefExpr{
-							pos:  position{line: 93, col: 56, offset: 1924},
-							name: "WS",
+					},
+				},
+			},
+		},
+		{
+			name: "CONDITION_OPERATOR",
+			pos:  position{line: 175, col: 1, offset: 3849},
+			expr: &actionExpr{
+				pos: position{line: 175, col: 23, offset: 3871},
+				run: (*parser).callonCONDITION_OPERATOR1,
+				expr: &choiceExpr{
+					pos: position{line: 175, col: 24, offset: 3872},
+					alternatives: []interface{}{
+						&litMatcher{
+							pos:        position{line: 175, col: 24, offset: 3872},
+							val:        "==",
+							ignoreCase: false,
+							want:       "\"==\"",
 						},
 						&litMatcher{
-							pos:        position{line: 93, col: 59, offset: 1927},
-							val:        "]",
+							pos:        position{line: 175, col: 31, offset: 3879},
+							val:        "!=",
 							ignoreCase: false,
-							want:       "\"]\"",
+							want:       "\"!=\"",
 						},
 					},
 				},
 			},
 		},
 		{
-			name: "OBJECT",
-			pos:  position{line: 97, col: 1, offset: 1959},
+			name: "CONDITION_OPERAND",
+			pos:  position{line: 179, col: 1, offset: 3916},
 			expr: &actionExpr{
-				pos: position{line: 97, col: 11, offset: 1969},
-				run: (*parser).callonOBJECT1,
+				pos: position{line: 179, col: 22, offset: 3937},
+				run: (*parser).callonCONDITION_OPERAND1,
 				expr: &labeledExpr{
-					pos:   position{line: 97, col: 11, offset: 1969},
-					label: "o",
+					pos:   position{line: 179, col: 22, offset: 3937},
+					label: "v",
 					expr: &choiceExpr{
-						pos: position{line: 97, col: 14, offset: 1972},
+						pos: position{line: 179, col: 25, offset: 3940},
 						alternatives: []interface{}{
 							&ruleRefExpr{
-								pos:  position{line: 97, col: 14, offset: 1972},
-								name: "EMPTY_OBJ",
+								pos:  position{line: 179, col: 25, offset: 3940},
+								name: "VARIABLE",
 							},
 							&ruleRefExpr{
-								pos:  position{line: 97, col: 26, offset: 1984},
-								name: "POPULATED_OBJ",
+								pos:  position{line: 179, col: 36, offset: 3951},
+								name: "PRIMITIVE",
 							},
 						},
 					},
@@ -1041,195 +2086,162 @@ var g = &grammar{
 			},
 		},
 		{
-			name: "EMPTY_OBJ",
-			pos:  position{line: 101, col: 1, offset: 2019},
+			name: "ONLY_RULE",
+			pos:  position{line: 183, col: 1, offset: 3987},
 			expr: &actionExpr{
-				pos: position{line: 101, col: 14, offset: 2032},
-				run: (*parser).callonEMPTY_OBJ1,
+				pos: position{line: 183, col: 14, offset: 4000},
+				run: (*parser).callonONLY_RULE1,
 				expr: &seqExpr{
-					pos: position{line: 101, col: 14, offset: 2032},
+					pos: position{line: 183, col: 14, offset: 4000},
 					exprs: []interface{}{
-						&litMatcher{
-							pos:        position{line: 101, col: 14, offset: 2032},
-							val:        "{",
-							ignoreCase: false,
-							want:       "\"{\"",
-						},
-						&ruleRefExpr{
-							pos:  position{line: 101, col: 18, offset: 2036},
-							name: "WS",
-						},
-						&zeroOrMoreExpr{
-							pos: position{line: 101, col: 21, offset: 2039},
-							expr: &ruleRefExpr{
-								pos:  position{line: 101, col: 21, offset: 2039},
-								name: "NL",
-							},
-						},
 						&ruleRefExpr{
-							pos:  position{line: 101, col: 25, offset: 2043},
-							name: "WS",
-						},
-						&litMatcher{
-							pos:        position{line: 101, col: 28, offset: 2046},
-							val:        "}",
-							ignoreCase: false,
-							want:       "\"}\"",
+							pos:  position{line: 183, col: 14, offset: 4000},
+							name: "WS_MAND",
 						},
-					},
-				},
-			},
-		},
-		{
-			name: "POPULATED_OBJ",
-			pos:  position{line: 105, col: 1, offset: 2080},
-			expr: &actionExpr{
-				pos: position{line: 105, col: 18, offset: 2097},
-				run: (*parser).callonPOPULATED_OBJ1,
-				expr: &seqExpr{
-					pos: position{line: 105, col: 18, offset: 2097},
-					exprs: []interface{}{
 						&litMatcher{
-							pos:        position{line: 105, col: 18, offset: 2097},
-							val:        "{",
+							pos:        position{line: 183, col: 22, offset: 4008},
+							val:        "only",
 							ignoreCase: false,
-							want:       "\"{\"",
-						},
-						&ruleRefExpr{
-							pos:  position{line: 105, col: 22, offset: 2101},
-							name: "WS",
-						},
-						&zeroOrMoreExpr{
-							pos: position{line: 105, col: 25, offset: 2104},
-							expr: &ruleRefExpr{
-								pos:  position{line: 105, col: 25, offset: 2104},
-								name: "NL",
-							},
+							want:       "\"only\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 105, col: 29, offset: 2108},
-							name: "WS",
+							pos:  position{line: 183, col: 29, offset: 4015},
+							name: "WS_MAND",
 						},
 						&labeledExpr{
-							pos:   position{line: 105, col: 32, offset: 2111},
-							label: "oe",
+							pos:   position{line: 183, col: 37, offset: 4023},
+							label: "f",
 							expr: &ruleRefExpr{
-								pos:  position{line: 105, col: 36, offset: 2115},
-								name: "OBJ_ENTRY",
+								pos:  position{line: 183, col: 40, offset: 4026},
+								name: "FILTER",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 105, col: 47, offset: 2126},
-							label: "oes",
+							pos:   position{line: 183, col: 48, offset: 4034},
+							label: "fs",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 105, col: 51, offset: 2130},
+								pos: position{line: 183, col: 51, offset: 4037},
 								expr: &seqExpr{
-									pos: position{line: 105, col: 52, offset: 2131},
+									pos: position{line: 183, col: 52, offset: 4038},
 									exprs: []interface{}{
 										&ruleRefExpr{
-											pos:  position{line: 105, col: 52, offset: 2131},
+											pos:  position{line: 183, col: 52, offset: 4038},
 											name: "WS",
 										},
-										&litMatcher{
-											pos:        position{line: 105, col: 55, offset: 2134},
-											val:        ",",
-											ignoreCase: false,
-											want:       "\",\"",
-										},
-										&ruleRefExpr{
-											pos:  position{line: 105, col: 59, offset: 2138},
-											name: "WS",
+										&notExpr{
+											pos: position{line: 183, col: 55, offset: 4041},
+											expr: &choiceExpr{
+												pos: position{line: 183, col: 57, offset: 4043},
+												alternatives: []interface{}{
+													&ruleRefExpr{
+														pos:  position{line: 183, col: 57, offset: 4043},
+														name: "FLAGS_RULE",
+													},
+													&seqExpr{
+														pos: position{line: 183, col: 70, offset: 4056},
+														exprs: []interface{}{
+															&ruleRefExpr{
+																pos:  position{line: 183, col: 70, offset: 4056},
+																name: "BS",
+															},
+															&ruleRefExpr{
+																pos:  position{line: 183, col: 73, offset: 4059},
+																name: "BLOCK",
+															},
+														},
+													},
+												},
+											},
 										},
-										&zeroOrMoreExpr{
-											pos: position{line: 105, col: 62, offset: 2141},
-											expr: &ruleRefExpr{
-												pos:  position{line: 105, col: 62, offset: 2141},
-												name: "NL",
+										&choiceExpr{
+											pos: position{line: 183, col: 81, offset: 4067},
+											alternatives: []interface{}{
+												&seqExpr{
+													pos: position{line: 183, col: 81, offset: 4067},
+													exprs: []interface{}{
+														&ruleRefExpr{
+															pos:  position{line: 183, col: 81, offset: 4067},
+															name: "LS",
+														},
+														&zeroOrMoreExpr{
+															pos: position{line: 183, col: 84, offset: 4070},
+															expr: &seqExpr{
+																pos: position{line: 183, col: 85, offset: 4071},
+																exprs: []interface{}{
+																	&ruleRefExpr{
+																		pos:  position{line: 183, col: 85, offset: 4071},
+																		name: "WS",
+																	},
+																	&ruleRefExpr{
+																		pos:  position{line: 183, col: 88, offset: 4074},
+																		name: "NL",
+																	},
+																	&ruleRefExpr{
+																		pos:  position{line: 183, col: 91, offset: 4077},
+																		name: "WS",
+																	},
+																},
+															},
+														},
+													},
+												},
+												&ruleRefExpr{
+													pos:  position{line: 183, col: 98, offset: 4084},
+													name: "LS",
+												},
 											},
 										},
 										&ruleRefExpr{
-											pos:  position{line: 105, col: 66, offset: 2145},
+											pos:  position{line: 183, col: 102, offset: 4088},
 											name: "WS",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 105, col: 69, offset: 2148},
-											name: "OBJ_ENTRY",
+											pos:  position{line: 183, col: 105, offset: 4091},
+											name: "FILTER",
 										},
 									},
 								},
 							},
 						},
-						&ruleRefExpr{
-							pos:  position{line: 105, col: 81, offset: 2160},
-							name: "WS",
-						},
-						&zeroOrMoreExpr{
-							pos: position{line: 105, col: 84, offset: 2163},
-							expr: &ruleRefExpr{
-								pos:  position{line: 105, col: 84, offset: 2163},
-								name: "NL",
-							},
-						},
-						&ruleRefExpr{
-							pos:  position{line: 105, col: 88, offset: 2167},
-							name: "WS",
-						},
-						&litMatcher{
-							pos:        position{line: 105, col: 91, offset: 2170},
-							val:        "}",
-							ignoreCase: false,
-							want:       "\"}\"",
-						},
 					},
 				},
 			},
 		},
 		{
-			name: "OBJ_ENTRY",
-			pos:  position{line: 109, col: 1, offset: 2215},
+			name: "FILTER",
+			pos:  position{line: 187, col: 1, offset: 4128},
 			expr: &actionExpr{
-				pos: position{line: 109, col: 14, offset: 2228},
-				run: (*parser).callonOBJ_ENTRY1,
+				pos: position{line: 187, col: 11, offset: 4138},
+				run: (*parser).callonFILTER1,
 				expr: &seqExpr{
-					pos: position{line: 109, col: 14, offset: 2228},
+					pos: position{line: 187, col: 11, offset: 4138},
 					exprs: []interface{}{
 						&labeledExpr{
-							pos:   position{line: 109, col: 14, offset: 2228},
-							label: "k",
+							pos:   position{line: 187, col: 11, offset: 4138},
+							label: "f",
 							expr: &choiceExpr{
-								pos: position{line: 109, col: 17, offset: 2231},
+								pos: position{line: 187, col: 14, offset: 4141},
 								alternatives: []interface{}{
 									&ruleRefExpr{
-										pos:  position{line: 109, col: 17, offset: 2231},
-										name: "String",
+										pos:  position{line: 187, col: 14, offset: 4141},
+										name: "EXPRESSION_FILTER",
 									},
 									&ruleRefExpr{
-										pos:  position{line: 109, col: 26, offset: 2240},
-										name: "IDENT",
+										pos:  position{line: 187, col: 34, offset: 4161},
+										name: "FIELD_FILTER",
 									},
 								},
 							},
 						},
-						&ruleRefExpr{
-							pos:  position{line: 109, col: 33, offset: 2247},
-							name: "WS",
-						},
-						&litMatcher{
-							pos:        position{line: 109, col: 36, offset: 2250},
-							val:        ":",
-							ignoreCase: false,
-							want:       "\":\"",
-						},
-						&ruleRefExpr{
-							pos:  position{line: 109, col: 40, offset: 2254},
-							name: "WS",
-						},
 						&labeledExpr{
-							pos:   position{line: 109, col: 43, offset: 2257},
-							label: "v",
-							expr: &ruleRefExpr{
-								pos:  position{line: 109, col: 46, offset: 2260},
-								name: "VALUE",
+							pos:   position{line: 187, col: 48, offset: 4175},
+							label: "a",
+							expr: &zeroOrOneExpr{
+								pos: position{line: 187, col: 51, offset: 4178},
+								expr: &ruleRefExpr{
+									pos:  position{line: 187, col: 51, offset: 4178},
+									name: "FILTER_ALIAS",
+								},
 							},
 						},
 					},
@@ -1237,40 +2249,81 @@ var g = &grammar{
 			},
 		},
 		{
-			name: "PRIMITIVE",
-			pos:  position{line: 113, col: 1, offset: 2301},
+			name: "FIELD_FILTER",
+			pos:  position{line: 191, col: 1, offset: 4229},
 			expr: &actionExpr{
-				pos: position{line: 113, col: 14, offset: 2314},
-				run: (*parser).callonPRIMITIVE1,
-				expr: &labeledExpr{
-					pos:   position{line: 113, col: 14, offset: 2314},
-					label: "p",
-					expr: &choiceExpr{
-						pos: position{line: 113, col: 17, offset: 2317},
-						alternatives: []interface{}{
-							&ruleRefExpr{
-								pos:  position{line: 113, col: 17, offset: 2317},
-								name: "Null",
-							},
-							&ruleRefExpr{
-								pos:  position{line: 113, col: 24, offset: 2324},
-								name: "Boolean",
+				pos: position{line: 191, col: 17, offset: 4245},
+				run: (*parser).callonFIELD_FILTER1,
+				expr: &seqExpr{
+					pos: position{line: 191, col: 17, offset: 4245},
+					exprs: []interface{}{
+						&labeledExpr{
+							pos:   position{line: 191, col: 17, offset: 4245},
+							label: "f",
+							expr: &ruleRefExpr{
+								pos:  position{line: 191, col: 20, offset: 4248},
+								name: "FILTER_VALUE",
 							},
-							&ruleRefExpr{
-								pos:  position{line: 113, col: 34, offset: 2334},
-								name: "String",
+						},
+						&labeledExpr{
+							pos:   position{line: 191, col: 34, offset: 4262},
+							label: "fn",
+							expr: &zeroOrOneExpr{
+								pos: position{line: 191, col: 37, offset: 4265},
+								expr: &choiceExpr{
+									pos: position{line: 191, col: 38, offset: 4266},
+									alternatives: []interface{}{
+										&ruleRefExpr{
+											pos:  position{line: 191, col: 38, offset: 4266},
+											name: "MATCHES_FN",
+										},
+										&ruleRefExpr{
+											pos:  position{line: 191, col: 51, offset: 4279},
+											name: "PREDICATE_FN",
+										},
+									},
+								},
 							},
-							&ruleRefExpr{
-								pos:  position{line: 113, col: 43, offset: 2343},
-								name: "Float",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "FILTER_ALIAS",
+			pos:  position{line: 195, col: 1, offset: 4324},
+			expr: &actionExpr{
+				pos: position{line: 195, col: 17, offset: 4340},
+				run: (*parser).callonFILTER_ALIAS1,
+				expr: &seqExpr{
+					pos: position{line: 195, col: 17, offset: 4340},
+					exprs: []interface{}{
+						&oneOrMoreExpr{
+							pos: position{line: 195, col: 17, offset: 4340},
+							expr: &ruleRefExpr{
+								pos:  position{line: 195, col: 17, offset: 4340},
+								name: "SPACE",
 							},
-							&ruleRefExpr{
-								pos:  position{line: 113, col: 51, offset: 2351},
-								name: "Integer",
+						},
+						&litMatcher{
+							pos:        position{line: 195, col: 24, offset: 4347},
+							val:        "as",
+							ignoreCase: false,
+							want:       "\"as\"",
+						},
+						&oneOrMoreExpr{
+							pos: position{line: 195, col: 29, offset: 4352},
+							expr: &ruleRefExpr{
+								pos:  position{line: 195, col: 29, offset: 4352},
+								name: "SPACE",
 							},
-							&ruleRefExpr{
-								pos:  position{line: 113, col: 61, offset: 2361},
-								name: "CHAIN",
+						},
+						&labeledExpr{
+							pos:   position{line: 195, col: 36, offset: 4359},
+							label: "a",
+							expr: &ruleRefExpr{
+								pos:  position{line: 195, col: 39, offset: 4362},
+								name: "IDENT",
 							},
 						},
 					},
@@ -1278,177 +2331,182 @@ var g = &grammar{
 			},
 		},
 		{
-			name: "ONLY_RULE",
-			pos:  position{line: 119, col: 1, offset: 2399},
+			name: "EXPRESSION_FILTER",
+			pos:  position{line: 199, col: 1, offset: 4389},
 			expr: &actionExpr{
-				pos: position{line: 119, col: 14, offset: 2412},
-				run: (*parser).callonONLY_RULE1,
+				pos: position{line: 199, col: 22, offset: 4410},
+				run: (*parser).callonEXPRESSION_FILTER1,
 				expr: &seqExpr{
-					pos: position{line: 119, col: 14, offset: 2412},
+					pos: position{line: 199, col: 22, offset: 4410},
 					exprs: []interface{}{
-						&ruleRefExpr{
-							pos:  position{line: 119, col: 14, offset: 2412},
-							name: "WS_MAND",
+						&labeledExpr{
+							pos:   position{line: 199, col: 22, offset: 4410},
+							label: "fn",
+							expr: &ruleRefExpr{
+								pos:  position{line: 199, col: 26, offset: 4414},
+								name: "EXPRESSION_FUNCTION",
+							},
 						},
 						&litMatcher{
-							pos:        position{line: 119, col: 22, offset: 2420},
-							val:        "only",
+							pos:        position{line: 199, col: 47, offset: 4435},
+							val:        "(",
 							ignoreCase: false,
-							want:       "\"only\"",
+							want:       "\"(\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 119, col: 29, offset: 2427},
-							name: "WS_MAND",
+							pos:  position{line: 199, col: 51, offset: 4439},
+							name: "WS",
 						},
 						&labeledExpr{
-							pos:   position{line: 119, col: 37, offset: 2435},
-							label: "f",
+							pos:   position{line: 199, col: 54, offset: 4442},
+							label: "a",
 							expr: &ruleRefExpr{
-								pos:  position{line: 119, col: 40, offset: 2438},
-								name: "FILTER",
+								pos:  position{line: 199, col: 57, offset: 4445},
+								name: "EXPRESSION_ARG",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 119, col: 48, offset: 2446},
-							label: "fs",
+							pos:   position{line: 199, col: 73, offset: 4461},
+							label: "as",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 119, col: 51, offset: 2449},
+								pos: position{line: 199, col: 76, offset: 4464},
 								expr: &seqExpr{
-									pos: position{line: 119, col: 52, offset: 2450},
+									pos: position{line: 199, col: 77, offset: 4465},
 									exprs: []interface{}{
 										&ruleRefExpr{
-											pos:  position{line: 119, col: 52, offset: 2450},
+											pos:  position{line: 199, col: 77, offset: 4465},
 											name: "WS",
 										},
-										&notExpr{
-											pos: position{line: 119, col: 55, offset: 2453},
-											expr: &choiceExpr{
-												pos: position{line: 119, col: 57, offset: 2455},
-												alternatives: []interface{}{
-													&ruleRefExpr{
-														pos:  position{line: 119, col: 57, offset: 2455},
-														name: "FLAGS_RULE",
-													},
-													&seqExpr{
-														pos: position{line: 119, col: 70, offset: 2468},
-														exprs: []interface{}{
-															&ruleRefExpr{
-																pos:  position{line: 119, col: 70, offset: 2468},
-																name: "BS",
-															},
-															&ruleRefExpr{
-																pos:  position{line: 119, col: 73, offset: 2471},
-																name: "BLOCK",
-															},
-														},
-													},
-												},
-											},
-										},
-										&choiceExpr{
-											pos: position{line: 119, col: 81, offset: 2479},
-											alternatives: []interface{}{
-												&seqExpr{
-													pos: position{line: 119, col: 81, offset: 2479},
-													exprs: []interface{}{
-														&ruleRefExpr{
-															pos:  position{line: 119, col: 81, offset: 2479},
-															name: "LS",
-														},
-														&zeroOrMoreExpr{
-															pos: position{line: 119, col: 84, offset: 2482},
-															expr: &seqExpr{
-																pos: position{line: 119, col: 85, offset: 2483},
-																exprs: []interface{}{
-																	&ruleRefExpr{
-																		pos:  position{line: 119, col: 85, offset: 2483},
-																		name: "WS",
-																	},
-																	&ruleRefExpr{
-																		pos:  position{line: 119, col: 88, offset: 2486},
-																		name: "NL",
-																	},
-																	&ruleRefExpr{
-																		pos:  position{line: 119, col: 91, offset: 2489},
-																		name: "WS",
-																	},
-																},
-															},
-														},
-													},
-												},
-												&ruleRefExpr{
-													pos:  position{line: 119, col: 98, offset: 2496},
-													name: "LS",
-												},
-											},
+										&litMatcher{
+											pos:        position{line: 199, col: 80, offset: 4468},
+											val:        ",",
+											ignoreCase: false,
+											want:       "\",\"",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 119, col: 102, offset: 2500},
+											pos:  position{line: 199, col: 84, offset: 4472},
 											name: "WS",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 119, col: 105, offset: 2503},
-											name: "FILTER",
+											pos:  position{line: 199, col: 87, offset: 4475},
+											name: "EXPRESSION_ARG",
 										},
 									},
 								},
 							},
 						},
+						&ruleRefExpr{
+							pos:  position{line: 199, col: 104, offset: 4492},
+							name: "WS",
+						},
+						&litMatcher{
+							pos:        position{line: 199, col: 107, offset: 4495},
+							val:        ")",
+							ignoreCase: false,
+							want:       "\")\"",
+						},
 					},
 				},
 			},
 		},
 		{
-			name: "FILTER",
-			pos:  position{line: 123, col: 1, offset: 2540},
+			name: "EXPRESSION_FUNCTION",
+			pos:  position{line: 203, col: 1, offset: 4537},
 			expr: &actionExpr{
-				pos: position{line: 123, col: 11, offset: 2550},
-				run: (*parser).callonFILTER1,
-				expr: &seqExpr{
-					pos: position{line: 123, col: 11, offset: 2550},
-					exprs: []interface{}{
-						&labeledExpr{
-							pos:   position{line: 123, col: 11, offset: 2550},
-							label: "f",
-							expr: &ruleRefExpr{
-								pos:  position{line: 123, col: 14, offset: 2553},
-								name: "FILTER_VALUE",
-							},
+				pos: position{line: 203, col: 24, offset: 4560},
+				run: (*parser).callonEXPRESSION_FUNCTION1,
+				expr: &choiceExpr{
+					pos: position{line: 203, col: 25, offset: 4561},
+					alternatives: []interface{}{
+						&litMatcher{
+							pos:        position{line: 203, col: 25, offset: 4561},
+							val:        "count",
+							ignoreCase: false,
+							want:       "\"count\"",
 						},
-						&labeledExpr{
-							pos:   position{line: 123, col: 28, offset: 2567},
-							label: "fn",
-							expr: &zeroOrOneExpr{
-								pos: position{line: 123, col: 32, offset: 2571},
-								expr: &ruleRefExpr{
-									pos:  position{line: 123, col: 32, offset: 2571},
-									name: "MATCHES_FN",
-								},
+						&litMatcher{
+							pos:        position{line: 203, col: 35, offset: 4571},
+							val:        "sum",
+							ignoreCase: false,
+							want:       "\"sum\"",
+						},
+						&litMatcher{
+							pos:        position{line: 203, col: 43, offset: 4579},
+							val:        "concat",
+							ignoreCase: false,
+							want:       "\"concat\"",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "EXPRESSION_ARG",
+			pos:  position{line: 207, col: 1, offset: 4620},
+			expr: &actionExpr{
+				pos: position{line: 207, col: 19, offset: 4638},
+				run: (*parser).callonEXPRESSION_ARG1,
+				expr: &labeledExpr{
+					pos:   position{line: 207, col: 19, offset: 4638},
+					label: "a",
+					expr: &choiceExpr{
+						pos: position{line: 207, col: 22, offset: 4641},
+						alternatives: []interface{}{
+							&ruleRefExpr{
+								pos:  position{line: 207, col: 22, offset: 4641},
+								name: "String",
+							},
+							&ruleRefExpr{
+								pos:  position{line: 207, col: 31, offset: 4650},
+								name: "Float",
+							},
+							&ruleRefExpr{
+								pos:  position{line: 207, col: 39, offset: 4658},
+								name: "Integer",
+							},
+							&ruleRefExpr{
+								pos:  position{line: 207, col: 49, offset: 4668},
+								name: "EXPRESSION_FIELD",
 							},
 						},
 					},
 				},
 			},
 		},
+		{
+			name: "EXPRESSION_FIELD",
+			pos:  position{line: 211, col: 1, offset: 4719},
+			expr: &actionExpr{
+				pos: position{line: 211, col: 21, offset: 4739},
+				run: (*parser).callonEXPRESSION_FIELD1,
+				expr: &labeledExpr{
+					pos:   position{line: 211, col: 21, offset: 4739},
+					label: "f",
+					expr: &ruleRefExpr{
+						pos:  position{line: 211, col: 24, offset: 4742},
+						name: "IDENT_WITH_DOT",
+					},
+				},
+			},
+		},
 		{
 			name: "FILTER_VALUE",
-			pos:  position{line: 127, col: 1, offset: 2614},
+			pos:  position{line: 215, col: 1, offset: 4793},
 			expr: &actionExpr{
-				pos: position{line: 127, col: 17, offset: 2630},
+				pos: position{line: 215, col: 17, offset: 4809},
 				run: (*parser).callonFILTER_VALUE1,
 				expr: &labeledExpr{
-					pos:   position{line: 127, col: 17, offset: 2630},
+					pos:   position{line: 215, col: 17, offset: 4809},
 					label: "fv",
 					expr: &choiceExpr{
-						pos: position{line: 127, col: 21, offset: 2634},
+						pos: position{line: 215, col: 21, offset: 4813},
 						alternatives: []interface{}{
 							&ruleRefExpr{
-								pos:  position{line: 127, col: 21, offset: 2634},
+								pos:  position{line: 215, col: 21, offset: 4813},
 								name: "IDENT_WITH_DOT",
 							},
 							&litMatcher{
-								pos:        position{line: 127, col: 38, offset: 2651},
+								pos:        position{line: 215, col: 38, offset: 4830},
 								val:        "*",
 								ignoreCase: false,
 								want:       "\"*\"",
@@ -1460,61 +2518,251 @@ var g = &grammar{
 		},
 		{
 			name: "MATCHES_FN",
-			pos:  position{line: 131, col: 1, offset: 2688},
+			pos:  position{line: 219, col: 1, offset: 4867},
 			expr: &actionExpr{
-				pos: position{line: 131, col: 15, offset: 2702},
+				pos: position{line: 219, col: 15, offset: 4881},
 				run: (*parser).callonMATCHES_FN1,
 				expr: &seqExpr{
-					pos: position{line: 131, col: 15, offset: 2702},
+					pos: position{line: 219, col: 15, offset: 4881},
 					exprs: []interface{}{
 						&ruleRefExpr{
-							pos:  position{line: 131, col: 15, offset: 2702},
+							pos:  position{line: 219, col: 15, offset: 4881},
 							name: "WS",
 						},
 						&litMatcher{
-							pos:        position{line: 131, col: 18, offset: 2705},
+							pos:        position{line: 219, col: 18, offset: 4884},
 							val:        "->",
 							ignoreCase: false,
 							want:       "\"->\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 131, col: 23, offset: 2710},
+							pos:  position{line: 219, col: 23, offset: 4889},
 							name: "WS",
 						},
 						&litMatcher{
-							pos:        position{line: 131, col: 26, offset: 2713},
+							pos:        position{line: 219, col: 26, offset: 4892},
 							val:        "matches",
 							ignoreCase: false,
 							want:       "\"matches\"",
 						},
 						&litMatcher{
-							pos:        position{line: 131, col: 36, offset: 2723},
+							pos:        position{line: 219, col: 36, offset: 4902},
 							val:        "(",
 							ignoreCase: false,
 							want:       "\"(\"",
 						},
 						&labeledExpr{
-							pos:   position{line: 131, col: 40, offset: 2727},
+							pos:   position{line: 219, col: 40, offset: 4906},
 							label: "arg",
 							expr: &choiceExpr{
-								pos: position{line: 131, col: 45, offset: 2732},
+								pos: position{line: 219, col: 45, offset: 4911},
 								alternatives: []interface{}{
 									&ruleRefExpr{
-										pos:  position{line: 131, col: 45, offset: 2732},
+										pos:  position{line: 219, col: 45, offset: 4911},
 										name: "VARIABLE",
 									},
 									&ruleRefExpr{
-										pos:  position{line: 131, col: 56, offset: 2743},
+										pos:  position{line: 219, col: 56, offset: 4922},
 										name: "String",
 									},
 								},
 							},
-						},
-						&litMatcher{
-							pos:        position{line: 131, col: 64, offset: 2751},
-							val:        ")",
-							ignoreCase: false,
-							want:       "\")\"",
+						},
+						&litMatcher{
+							pos:        position{line: 219, col: 64, offset: 4930},
+							val:        ")",
+							ignoreCase: false,
+							want:       "\")\"",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "PREDICATE_FN",
+			pos:  position{line: 223, col: 1, offset: 4956},
+			expr: &actionExpr{
+				pos: position{line: 223, col: 17, offset: 4972},
+				run: (*parser).callonPREDICATE_FN1,
+				expr: &seqExpr{
+					pos: position{line: 223, col: 17, offset: 4972},
+					exprs: []interface{}{
+						&ruleRefExpr{
+							pos:  position{line: 223, col: 17, offset: 4972},
+							name: "WS",
+						},
+						&litMatcher{
+							pos:        position{line: 223, col: 20, offset: 4975},
+							val:        "->",
+							ignoreCase: false,
+							want:       "\"->\"",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 223, col: 25, offset: 4980},
+							name: "WS",
+						},
+						&labeledExpr{
+							pos:   position{line: 223, col: 28, offset: 4983},
+							label: "op",
+							expr: &ruleRefExpr{
+								pos:  position{line: 223, col: 32, offset: 4987},
+								name: "PREDICATE_OPERATOR",
+							},
+						},
+						&litMatcher{
+							pos:        position{line: 223, col: 52, offset: 5007},
+							val:        "(",
+							ignoreCase: false,
+							want:       "\"(\"",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 223, col: 56, offset: 5011},
+							name: "WS",
+						},
+						&labeledExpr{
+							pos:   position{line: 223, col: 59, offset: 5014},
+							label: "arg",
+							expr: &ruleRefExpr{
+								pos:  position{line: 223, col: 64, offset: 5019},
+								name: "PREDICATE_ARG",
+							},
+						},
+						&ruleRefExpr{
+							pos:  position{line: 223, col: 79, offset: 5034},
+							name: "WS",
+						},
+						&litMatcher{
+							pos:        position{line: 223, col: 82, offset: 5037},
+							val:        ")",
+							ignoreCase: false,
+							want:       "\")\"",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "PREDICATE_OPERATOR",
+			pos:  position{line: 227, col: 1, offset: 5076},
+			expr: &actionExpr{
+				pos: position{line: 227, col: 23, offset: 5098},
+				run: (*parser).callonPREDICATE_OPERATOR1,
+				expr: &choiceExpr{
+					pos: position{line: 227, col: 24, offset: 5099},
+					alternatives: []interface{}{
+						&litMatcher{
+							pos:        position{line: 227, col: 24, offset: 5099},
+							val:        "not-matches",
+							ignoreCase: false,
+							want:       "\"not-matches\"",
+						},
+						&litMatcher{
+							pos:        position{line: 227, col: 40, offset: 5115},
+							val:        "equals",
+							ignoreCase: false,
+							want:       "\"equals\"",
+						},
+						&litMatcher{
+							pos:        position{line: 227, col: 51, offset: 5126},
+							val:        "gte",
+							ignoreCase: false,
+							want:       "\"gte\"",
+						},
+						&litMatcher{
+							pos:        position{line: 227, col: 59, offset: 5134},
+							val:        "gt",
+							ignoreCase: false,
+							want:       "\"gt\"",
+						},
+						&litMatcher{
+							pos:        position{line: 227, col: 66, offset: 5141},
+							val:        "lte",
+							ignoreCase: false,
+							want:       "\"lte\"",
+						},
+						&litMatcher{
+							pos:        position{line: 227, col: 74, offset: 5149},
+							val:        "lt",
+							ignoreCase: false,
+							want:       "\"lt\"",
+						},
+						&litMatcher{
+							pos:        position{line: 227, col: 81, offset: 5156},
+							val:        "in",
+							ignoreCase: false,
+							want:       "\"in\"",
+						},
+						&litMatcher{
+							pos:        position{line: 227, col: 88, offset: 5163},
+							val:        "contains",
+							ignoreCase: false,
+							want:       "\"contains\"",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "PREDICATE_ARG",
+			pos:  position{line: 231, col: 1, offset: 5206},
+			expr: &actionExpr{
+				pos: position{line: 231, col: 18, offset: 5223},
+				run: (*parser).callonPREDICATE_ARG1,
+				expr: &labeledExpr{
+					pos:   position{line: 231, col: 18, offset: 5223},
+					label: "v",
+					expr: &choiceExpr{
+						pos: position{line: 231, col: 21, offset: 5226},
+						alternatives: []interface{}{
+							&ruleRefExpr{
+								pos:  position{line: 231, col: 21, offset: 5226},
+								name: "LIST",
+							},
+							&ruleRefExpr{
+								pos:  position{line: 231, col: 28, offset: 5233},
+								name: "VARIABLE",
+							},
+							&ruleRefExpr{
+								pos:  position{line: 231, col: 39, offset: 5244},
+								name: "PREDICATE_PRIMITIVE",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "PREDICATE_PRIMITIVE",
+			pos:  position{line: 235, col: 1, offset: 5290},
+			expr: &actionExpr{
+				pos: position{line: 235, col: 24, offset: 5313},
+				run: (*parser).callonPREDICATE_PRIMITIVE1,
+				expr: &labeledExpr{
+					pos:   position{line: 235, col: 24, offset: 5313},
+					label: "p",
+					expr: &choiceExpr{
+						pos: position{line: 235, col: 27, offset: 5316},
+						alternatives: []interface{}{
+							&ruleRefExpr{
+								pos:  position{line: 235, col: 27, offset: 5316},
+								name: "Null",
+							},
+							&ruleRefExpr{
+								pos:  position{line: 235, col: 34, offset: 5323},
+								name: "Boolean",
+							},
+							&ruleRefExpr{
+								pos:  position{line: 235, col: 44, offset: 5333},
+								name: "String",
+							},
+							&ruleRefExpr{
+								pos:  position{line: 235, col: 53, offset: 5342},
+								name: "Float",
+							},
+							&ruleRefExpr{
+								pos:  position{line: 235, col: 61, offset: 5350},
+								name: "Integer",
+							},
 						},
 					},
 				},
@@ -1522,57 +2770,57 @@ var g = &grammar{
 		},
 		{
 			name: "HEADERS",
-			pos:  position{line: 135, col: 1, offset: 2777},
+			pos:  position{line: 239, col: 1, offset: 5388},
 			expr: &actionExpr{
-				pos: position{line: 135, col: 12, offset: 2788},
+				pos: position{line: 239, col: 12, offset: 5399},
 				run: (*parser).callonHEADERS1,
 				expr: &seqExpr{
-					pos: position{line: 135, col: 12, offset: 2788},
+					pos: position{line: 239, col: 12, offset: 5399},
 					exprs: []interface{}{
 						&ruleRefExpr{
-							pos:  position{line: 135, col: 12, offset: 2788},
+							pos:  position{line: 239, col: 12, offset: 5399},
 							name: "WS_MAND",
 						},
 						&litMatcher{
-							pos:        position{line: 135, col: 20, offset: 2796},
+							pos:        position{line: 239, col: 20, offset: 5407},
 							val:        "headers",
 							ignoreCase: false,
 							want:       "\"headers\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 135, col: 30, offset: 2806},
+							pos:  position{line: 239, col: 30, offset: 5417},
 							name: "WS_MAND",
 						},
 						&labeledExpr{
-							pos:   position{line: 135, col: 38, offset: 2814},
+							pos:   position{line: 239, col: 38, offset: 5425},
 							label: "h",
 							expr: &ruleRefExpr{
-								pos:  position{line: 135, col: 41, offset: 2817},
+								pos:  position{line: 239, col: 41, offset: 5428},
 								name: "HEADER",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 135, col: 49, offset: 2825},
+							pos:   position{line: 239, col: 49, offset: 5436},
 							label: "hs",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 135, col: 52, offset: 2828},
+								pos: position{line: 239, col: 52, offset: 5439},
 								expr: &seqExpr{
-									pos: position{line: 135, col: 53, offset: 2829},
+									pos: position{line: 239, col: 53, offset: 5440},
 									exprs: []interface{}{
 										&ruleRefExpr{
-											pos:  position{line: 135, col: 53, offset: 2829},
+											pos:  position{line: 239, col: 53, offset: 5440},
 											name: "WS",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 135, col: 56, offset: 2832},
+											pos:  position{line: 239, col: 56, offset: 5443},
 											name: "LS",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 135, col: 59, offset: 2835},
+											pos:  position{line: 239, col: 59, offset: 5446},
 											name: "WS",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 135, col: 62, offset: 2838},
+											pos:  position{line: 239, col: 62, offset: 5449},
 											name: "HEADER",
 										},
 									},
@@ -1585,51 +2833,51 @@ var g = &grammar{
 		},
 		{
 			name: "HEADER",
-			pos:  position{line: 139, col: 1, offset: 2878},
+			pos:  position{line: 243, col: 1, offset: 5489},
 			expr: &actionExpr{
-				pos: position{line: 139, col: 11, offset: 2888},
+				pos: position{line: 243, col: 11, offset: 5499},
 				run: (*parser).callonHEADER1,
 				expr: &seqExpr{
-					pos: position{line: 139, col: 11, offset: 2888},
+					pos: position{line: 243, col: 11, offset: 5499},
 					exprs: []interface{}{
 						&labeledExpr{
-							pos:   position{line: 139, col: 11, offset: 2888},
+							pos:   position{line: 243, col: 11, offset: 5499},
 							label: "n",
 							expr: &ruleRefExpr{
-								pos:  position{line: 139, col: 14, offset: 2891},
+								pos:  position{line: 243, col: 14, offset: 5502},
 								name: "IDENT",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 139, col: 21, offset: 2898},
+							pos:  position{line: 243, col: 21, offset: 5509},
 							name: "WS",
 						},
 						&litMatcher{
-							pos:        position{line: 139, col: 24, offset: 2901},
+							pos:        position{line: 243, col: 24, offset: 5512},
 							val:        "=",
 							ignoreCase: false,
 							want:       "\"=\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 139, col: 28, offset: 2905},
+							pos:  position{line: 243, col: 28, offset: 5516},
 							name: "WS",
 						},
 						&labeledExpr{
-							pos:   position{line: 139, col: 31, offset: 2908},
+							pos:   position{line: 243, col: 31, offset: 5519},
 							label: "v",
 							expr: &choiceExpr{
-								pos: position{line: 139, col: 34, offset: 2911},
+								pos: position{line: 243, col: 34, offset: 5522},
 								alternatives: []interface{}{
 									&ruleRefExpr{
-										pos:  position{line: 139, col: 34, offset: 2911},
+										pos:  position{line: 243, col: 34, offset: 5522},
 										name: "VARIABLE",
 									},
 									&ruleRefExpr{
-										pos:  position{line: 139, col: 45, offset: 2922},
+										pos:  position{line: 243, col: 45, offset: 5533},
 										name: "CHAIN",
 									},
 									&ruleRefExpr{
-										pos:  position{line: 139, col: 53, offset: 2930},
+										pos:  position{line: 243, col: 53, offset: 5541},
 										name: "String",
 									},
 								},
@@ -1641,19 +2889,19 @@ var g = &grammar{
 		},
 		{
 			name: "HIDDEN_RULE",
-			pos:  position{line: 143, col: 1, offset: 2967},
+			pos:  position{line: 247, col: 1, offset: 5578},
 			expr: &actionExpr{
-				pos: position{line: 143, col: 16, offset: 2982},
+				pos: position{line: 247, col: 16, offset: 5593},
 				run: (*parser).callonHIDDEN_RULE1,
 				expr: &seqExpr{
-					pos: position{line: 143, col: 16, offset: 2982},
+					pos: position{line: 247, col: 16, offset: 5593},
 					exprs: []interface{}{
 						&ruleRefExpr{
-							pos:  position{line: 143, col: 16, offset: 2982},
+							pos:  position{line: 247, col: 16, offset: 5593},
 							name: "WS_MAND",
 						},
 						&litMatcher{
-							pos:        position{line: 143, col: 24, offset: 2990},
+							pos:        position{line: 247, col: 24, offset: 5601},
 							val:        "hidden",
 							ignoreCase: false,
 							want:       "\"hidden\"",
@@ -1664,39 +2912,39 @@ var g = &grammar{
 		},
 		{
 			name: "TIMEOUT",
-			pos:  position{line: 147, col: 1, offset: 3024},
+			pos:  position{line: 251, col: 1, offset: 5635},
 			expr: &actionExpr{
-				pos: position{line: 147, col: 12, offset: 3035},
+				pos: position{line: 251, col: 12, offset: 5646},
 				run: (*parser).callonTIMEOUT1,
 				expr: &seqExpr{
-					pos: position{line: 147, col: 12, offset: 3035},
+					pos: position{line: 251, col: 12, offset: 5646},
 					exprs: []interface{}{
 						&ruleRefExpr{
-							pos:  position{line: 147, col: 12, offset: 3035},
+							pos:  position{line: 251, col: 12, offset: 5646},
 							name: "WS_MAND",
 						},
 						&litMatcher{
-							pos:        position{line: 147, col: 20, offset: 3043},
+							pos:        position{line: 251, col: 20, offset: 5654},
 							val:        "timeout",
 							ignoreCase: false,
 							want:       "\"timeout\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 147, col: 30, offset: 3053},
+							pos:  position{line: 251, col: 30, offset: 5664},
 							name: "WS_MAND",
 						},
 						&labeledExpr{
-							pos:   position{line: 147, col: 38, offset: 3061},
+							pos:   position{line: 251, col: 38, offset: 5672},
 							label: "t",
 							expr: &choiceExpr{
-								pos: position{line: 147, col: 41, offset: 3064},
+								pos: position{line: 251, col: 41, offset: 5675},
 								alternatives: []interface{}{
 									&ruleRefExpr{
-										pos:  position{line: 147, col: 41, offset: 3064},
+										pos:  position{line: 251, col: 41, offset: 5675},
 										name: "VARIABLE",
 									},
 									&ruleRefExpr{
-										pos:  position{line: 147, col: 52, offset: 3075},
+										pos:  position{line: 251, col: 52, offset: 5686},
 										name: "Integer",
 									},
 								},
@@ -1708,39 +2956,39 @@ var g = &grammar{
 		},
 		{
 			name: "MAX_AGE",
-			pos:  position{line: 151, col: 1, offset: 3111},
+			pos:  position{line: 255, col: 1, offset: 5722},
 			expr: &actionExpr{
-				pos: position{line: 151, col: 12, offset: 3122},
+				pos: position{line: 255, col: 12, offset: 5733},
 				run: (*parser).callonMAX_AGE1,
 				expr: &seqExpr{
-					pos: position{line: 151, col: 12, offset: 3122},
+					pos: position{line: 255, col: 12, offset: 5733},
 					exprs: []interface{}{
 						&ruleRefExpr{
-							pos:  position{line: 151, col: 12, offset: 3122},
+							pos:  position{line: 255, col: 12, offset: 5733},
 							name: "WS_MAND",
 						},
 						&litMatcher{
-							pos:        position{line: 151, col: 20, offset: 3130},
+							pos:        position{line: 255, col: 20, offset: 5741},
 							val:        "max-age",
 							ignoreCase: false,
 							want:       "\"max-age\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 151, col: 30, offset: 3140},
+							pos:  position{line: 255, col: 30, offset: 5751},
 							name: "WS_MAND",
 						},
 						&labeledExpr{
-							pos:   position{line: 151, col: 38, offset: 3148},
+							pos:   position{line: 255, col: 38, offset: 5759},
 							label: "t",
 							expr: &choiceExpr{
-								pos: position{line: 151, col: 41, offset: 3151},
+								pos: position{line: 255, col: 41, offset: 5762},
 								alternatives: []interface{}{
 									&ruleRefExpr{
-										pos:  position{line: 151, col: 41, offset: 3151},
+										pos:  position{line: 255, col: 41, offset: 5762},
 										name: "VARIABLE",
 									},
 									&ruleRefExpr{
-										pos:  position{line: 151, col: 52, offset: 3162},
+										pos:  position{line: 255, col: 52, offset: 5773},
 										name: "Integer",
 									},
 								},
@@ -1752,91 +3000,480 @@ var g = &grammar{
 		},
 		{
 			name: "S_MAX_AGE",
-			pos:  position{line: 155, col: 1, offset: 3197},
+			pos:  position{line: 259, col: 1, offset: 5808},
 			expr: &actionExpr{
-				pos: position{line: 155, col: 14, offset: 3210},
+				pos: position{line: 259, col: 14, offset: 5821},
 				run: (*parser).callonS_MAX_AGE1,
 				expr: &seqExpr{
-					pos: position{line: 155, col: 14, offset: 3210},
+					pos: position{line: 259, col: 14, offset: 5821},
+					exprs: []interface{}{
+						&ruleRefExpr{
+							pos:  position{line: 259, col: 14, offset: 5821},
+							name: "WS_MAND",
+						},
+						&litMatcher{
+							pos:        position{line: 259, col: 22, offset: 5829},
+							val:        "s-max-age",
+							ignoreCase: false,
+							want:       "\"s-max-age\"",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 259, col: 34, offset: 5841},
+							name: "WS_MAND",
+						},
+						&labeledExpr{
+							pos:   position{line: 259, col: 42, offset: 5849},
+							label: "t",
+							expr: &choiceExpr{
+								pos: position{line: 259, col: 45, offset: 5852},
+								alternatives: []interface{}{
+									&ruleRefExpr{
+										pos:  position{line: 259, col: 45, offset: 5852},
+										name: "VARIABLE",
+									},
+									&ruleRefExpr{
+										pos:  position{line: 259, col: 56, offset: 5863},
+										name: "Integer",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "RETRY",
+			pos:  position{line: 263, col: 1, offset: 5899},
+			expr: &actionExpr{
+				pos: position{line: 263, col: 10, offset: 5908},
+				run: (*parser).callonRETRY1,
+				expr: &seqExpr{
+					pos: position{line: 263, col: 10, offset: 5908},
+					exprs: []interface{}{
+						&ruleRefExpr{
+							pos:  position{line: 263, col: 10, offset: 5908},
+							name: "WS_MAND",
+						},
+						&litMatcher{
+							pos:        position{line: 263, col: 18, offset: 5916},
+							val:        "retry",
+							ignoreCase: false,
+							want:       "\"retry\"",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 263, col: 26, offset: 5924},
+							name: "WS_MAND",
+						},
+						&labeledExpr{
+							pos:   position{line: 263, col: 34, offset: 5932},
+							label: "o",
+							expr: &ruleRefExpr{
+								pos:  position{line: 263, col: 37, offset: 5935},
+								name: "RETRY_OPTION",
+							},
+						},
+						&labeledExpr{
+							pos:   position{line: 263, col: 51, offset: 5949},
+							label: "os",
+							expr: &zeroOrMoreExpr{
+								pos: position{line: 263, col: 54, offset: 5952},
+								expr: &seqExpr{
+									pos: position{line: 263, col: 55, offset: 5953},
+									exprs: []interface{}{
+										&ruleRefExpr{
+											pos:  position{line: 263, col: 55, offset: 5953},
+											name: "WS",
+										},
+										&litMatcher{
+											pos:        position{line: 263, col: 58, offset: 5956},
+											val:        ",",
+											ignoreCase: false,
+											want:       "\",\"",
+										},
+										&ruleRefExpr{
+											pos:  position{line: 263, col: 62, offset: 5960},
+											name: "WS",
+										},
+										&ruleRefExpr{
+											pos:  position{line: 263, col: 65, offset: 5963},
+											name: "RETRY_OPTION",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "RETRY_OPTION",
+			pos:  position{line: 267, col: 1, offset: 6007},
+			expr: &actionExpr{
+				pos: position{line: 267, col: 17, offset: 6023},
+				run: (*parser).callonRETRY_OPTION1,
+				expr: &seqExpr{
+					pos: position{line: 267, col: 17, offset: 6023},
+					exprs: []interface{}{
+						&labeledExpr{
+							pos:   position{line: 267, col: 17, offset: 6023},
+							label: "k",
+							expr: &ruleRefExpr{
+								pos:  position{line: 267, col: 20, offset: 6026},
+								name: "RETRY_KEY",
+							},
+						},
+						&ruleRefExpr{
+							pos:  position{line: 267, col: 31, offset: 6037},
+							name: "WS",
+						},
+						&litMatcher{
+							pos:        position{line: 267, col: 34, offset: 6040},
+							val:        "=",
+							ignoreCase: false,
+							want:       "\"=\"",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 267, col: 38, offset: 6044},
+							name: "WS",
+						},
+						&labeledExpr{
+							pos:   position{line: 267, col: 41, offset: 6047},
+							label: "v",
+							expr: &choiceExpr{
+								pos: position{line: 267, col: 44, offset: 6050},
+								alternatives: []interface{}{
+									&ruleRefExpr{
+										pos:  position{line: 267, col: 44, offset: 6050},
+										name: "INTEGER_LIST",
+									},
+									&ruleRefExpr{
+										pos:  position{line: 267, col: 59, offset: 6065},
+										name: "Integer",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "RETRY_KEY",
+			pos:  position{line: 271, col: 1, offset: 6108},
+			expr: &actionExpr{
+				pos: position{line: 271, col: 14, offset: 6121},
+				run: (*parser).callonRETRY_KEY1,
+				expr: &choiceExpr{
+					pos: position{line: 271, col: 15, offset: 6122},
+					alternatives: []interface{}{
+						&litMatcher{
+							pos:        position{line: 271, col: 15, offset: 6122},
+							val:        "attempts",
+							ignoreCase: false,
+							want:       "\"attempts\"",
+						},
+						&litMatcher{
+							pos:        position{line: 271, col: 28, offset: 6135},
+							val:        "backoff",
+							ignoreCase: false,
+							want:       "\"backoff\"",
+						},
+						&litMatcher{
+							pos:        position{line: 271, col: 40, offset: 6147},
+							val:        "jitter",
+							ignoreCase: false,
+							want:       "\"jitter\"",
+						},
+						&litMatcher{
+							pos:        position{line: 271, col: 51, offset: 6158},
+							val:        "status",
+							ignoreCase: false,
+							want:       "\"status\"",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "PAGINATE",
+			pos:  position{line: 275, col: 1, offset: 6199},
+			expr: &actionExpr{
+				pos: position{line: 275, col: 13, offset: 6211},
+				run: (*parser).callonPAGINATE1,
+				expr: &seqExpr{
+					pos: position{line: 275, col: 13, offset: 6211},
 					exprs: []interface{}{
 						&ruleRefExpr{
-							pos:  position{line: 155, col: 14, offset: 3210},
+							pos:  position{line: 275, col: 13, offset: 6211},
+							name: "WS_MAND",
+						},
+						&litMatcher{
+							pos:        position{line: 275, col: 21, offset: 6219},
+							val:        "paginate",
+							ignoreCase: false,
+							want:       "\"paginate\"",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 275, col: 32, offset: 6230},
 							name: "WS_MAND",
 						},
+						&labeledExpr{
+							pos:   position{line: 275, col: 40, offset: 6238},
+							label: "o",
+							expr: &ruleRefExpr{
+								pos:  position{line: 275, col: 43, offset: 6241},
+								name: "PAGINATE_OPTION",
+							},
+						},
+						&labeledExpr{
+							pos:   position{line: 275, col: 60, offset: 6258},
+							label: "os",
+							expr: &zeroOrMoreExpr{
+								pos: position{line: 275, col: 63, offset: 6261},
+								expr: &seqExpr{
+									pos: position{line: 275, col: 64, offset: 6262},
+									exprs: []interface{}{
+										&ruleRefExpr{
+											pos:  position{line: 275, col: 64, offset: 6262},
+											name: "WS",
+										},
+										&litMatcher{
+											pos:        position{line: 275, col: 67, offset: 6265},
+											val:        ",",
+											ignoreCase: false,
+											want:       "\",\"",
+										},
+										&ruleRefExpr{
+											pos:  position{line: 275, col: 71, offset: 6269},
+											name: "WS",
+										},
+										&ruleRefExpr{
+											pos:  position{line: 275, col: 74, offset: 6272},
+											name: "PAGINATE_OPTION",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "PAGINATE_OPTION",
+			pos:  position{line: 279, col: 1, offset: 6322},
+			expr: &actionExpr{
+				pos: position{line: 279, col: 20, offset: 6341},
+				run: (*parser).callonPAGINATE_OPTION1,
+				expr: &seqExpr{
+					pos: position{line: 279, col: 20, offset: 6341},
+					exprs: []interface{}{
+						&labeledExpr{
+							pos:   position{line: 279, col: 20, offset: 6341},
+							label: "k",
+							expr: &ruleRefExpr{
+								pos:  position{line: 279, col: 23, offset: 6344},
+								name: "PAGINATE_KEY",
+							},
+						},
+						&ruleRefExpr{
+							pos:  position{line: 279, col: 37, offset: 6358},
+							name: "WS",
+						},
+						&litMatcher{
+							pos:        position{line: 279, col: 40, offset: 6361},
+							val:        "=",
+							ignoreCase: false,
+							want:       "\"=\"",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 279, col: 44, offset: 6365},
+							name: "WS",
+						},
+						&labeledExpr{
+							pos:   position{line: 279, col: 47, offset: 6368},
+							label: "v",
+							expr: &choiceExpr{
+								pos: position{line: 279, col: 50, offset: 6371},
+								alternatives: []interface{}{
+									&ruleRefExpr{
+										pos:  position{line: 279, col: 50, offset: 6371},
+										name: "String",
+									},
+									&ruleRefExpr{
+										pos:  position{line: 279, col: 59, offset: 6380},
+										name: "Integer",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "PAGINATE_KEY",
+			pos:  position{line: 283, col: 1, offset: 6426},
+			expr: &actionExpr{
+				pos: position{line: 283, col: 17, offset: 6442},
+				run: (*parser).callonPAGINATE_KEY1,
+				expr: &choiceExpr{
+					pos: position{line: 283, col: 18, offset: 6443},
+					alternatives: []interface{}{
+						&litMatcher{
+							pos:        position{line: 283, col: 18, offset: 6443},
+							val:        "next",
+							ignoreCase: false,
+							want:       "\"next\"",
+						},
+						&litMatcher{
+							pos:        position{line: 283, col: 27, offset: 6452},
+							val:        "link",
+							ignoreCase: false,
+							want:       "\"link\"",
+						},
+						&litMatcher{
+							pos:        position{line: 283, col: 36, offset: 6461},
+							val:        "page",
+							ignoreCase: false,
+							want:       "\"page\"",
+						},
+						&litMatcher{
+							pos:        position{line: 283, col: 45, offset: 6470},
+							val:        "offset",
+							ignoreCase: false,
+							want:       "\"offset\"",
+						},
+						&litMatcher{
+							pos:        position{line: 283, col: 56, offset: 6481},
+							val:        "items",
+							ignoreCase: false,
+							want:       "\"items\"",
+						},
+						&litMatcher{
+							pos:        position{line: 283, col: 66, offset: 6491},
+							val:        "max",
+							ignoreCase: false,
+							want:       "\"max\"",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "INTEGER_LIST",
+			pos:  position{line: 287, col: 1, offset: 6529},
+			expr: &actionExpr{
+				pos: position{line: 287, col: 17, offset: 6545},
+				run: (*parser).callonINTEGER_LIST1,
+				expr: &seqExpr{
+					pos: position{line: 287, col: 17, offset: 6545},
+					exprs: []interface{}{
 						&litMatcher{
-							pos:        position{line: 155, col: 22, offset: 3218},
-							val:        "s-max-age",
+							pos:        position{line: 287, col: 17, offset: 6545},
+							val:        "[",
 							ignoreCase: false,
-							want:       "\"s-max-age\"",
+							want:       "\"[\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 155, col: 34, offset: 3230},
-							name: "WS_MAND",
+							pos:  position{line: 287, col: 21, offset: 6549},
+							name: "WS",
 						},
 						&labeledExpr{
-							pos:   position{line: 155, col: 42, offset: 3238},
-							label: "t",
-							expr: &choiceExpr{
-								pos: position{line: 155, col: 45, offset: 3241},
-								alternatives: []interface{}{
-									&ruleRefExpr{
-										pos:  position{line: 155, col: 45, offset: 3241},
-										name: "VARIABLE",
-									},
-									&ruleRefExpr{
-										pos:  position{line: 155, col: 56, offset: 3252},
-										name: "Integer",
+							pos:   position{line: 287, col: 24, offset: 6552},
+							label: "i",
+							expr: &ruleRefExpr{
+								pos:  position{line: 287, col: 27, offset: 6555},
+								name: "Integer",
+							},
+						},
+						&labeledExpr{
+							pos:   position{line: 287, col: 36, offset: 6564},
+							label: "ii",
+							expr: &zeroOrMoreExpr{
+								pos: position{line: 287, col: 39, offset: 6567},
+								expr: &seqExpr{
+									pos: position{line: 287, col: 40, offset: 6568},
+									exprs: []interface{}{
+										&ruleRefExpr{
+											pos:  position{line: 287, col: 40, offset: 6568},
+											name: "WS",
+										},
+										&ruleRefExpr{
+											pos:  position{line: 287, col: 43, offset: 6571},
+											name: "LS",
+										},
+										&ruleRefExpr{
+											pos:  position{line: 287, col: 46, offset: 6574},
+											name: "WS",
+										},
+										&ruleRefExpr{
+											pos:  position{line: 287, col: 49, offset: 6577},
+											name: "Integer",
+										},
 									},
 								},
 							},
 						},
+						&ruleRefExpr{
+							pos:  position{line: 287, col: 59, offset: 6587},
+							name: "WS",
+						},
+						&litMatcher{
+							pos:        position{line: 287, col: 62, offset: 6590},
+							val:        "]",
+							ignoreCase: false,
+							want:       "\"]\"",
+						},
 					},
 				},
 			},
 		},
 		{
 			name: "FLAGS_RULE",
-			pos:  position{line: 159, col: 1, offset: 3288},
+			pos:  position{line: 291, col: 1, offset: 6629},
 			expr: &actionExpr{
-				pos: position{line: 159, col: 15, offset: 3302},
+				pos: position{line: 291, col: 15, offset: 6643},
 				run: (*parser).callonFLAGS_RULE1,
 				expr: &seqExpr{
-					pos: position{line: 159, col: 15, offset: 3302},
+					pos: position{line: 291, col: 15, offset: 6643},
 					exprs: []interface{}{
 						&ruleRefExpr{
-							pos:  position{line: 159, col: 15, offset: 3302},
+							pos:  position{line: 291, col: 15, offset: 6643},
 							name: "WS_MAND",
 						},
 						&labeledExpr{
-							pos:   position{line: 159, col: 23, offset: 3310},
+							pos:   position{line: 291, col: 23, offset: 6651},
 							label: "i",
 							expr: &ruleRefExpr{
-								pos:  position{line: 159, col: 25, offset: 3312},
+								pos:  position{line: 291, col: 25, offset: 6653},
 								name: "IGNORE_FLAG",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 159, col: 37, offset: 3324},
+							pos:   position{line: 291, col: 37, offset: 6665},
 							label: "is",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 159, col: 40, offset: 3327},
+								pos: position{line: 291, col: 40, offset: 6668},
 								expr: &seqExpr{
-									pos: position{line: 159, col: 41, offset: 3328},
+									pos: position{line: 291, col: 41, offset: 6669},
 									exprs: []interface{}{
 										&ruleRefExpr{
-											pos:  position{line: 159, col: 41, offset: 3328},
+											pos:  position{line: 291, col: 41, offset: 6669},
 											name: "WS",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 159, col: 44, offset: 3331},
+											pos:  position{line: 291, col: 44, offset: 6672},
 											name: "LS",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 159, col: 47, offset: 3334},
+											pos:  position{line: 291, col: 47, offset: 6675},
 											name: "WS",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 159, col: 50, offset: 3337},
+											pos:  position{line: 291, col: 50, offset: 6678},
 											name: "IGNORE_FLAG",
 										},
 									},
@@ -1849,12 +3486,12 @@ var g = &grammar{
 		},
 		{
 			name: "IGNORE_FLAG",
-			pos:  position{line: 163, col: 1, offset: 3380},
+			pos:  position{line: 295, col: 1, offset: 6721},
 			expr: &actionExpr{
-				pos: position{line: 163, col: 16, offset: 3395},
+				pos: position{line: 295, col: 16, offset: 6736},
 				run: (*parser).callonIGNORE_FLAG1,
 				expr: &litMatcher{
-					pos:        position{line: 163, col: 16, offset: 3395},
+					pos:        position{line: 295, col: 16, offset: 6736},
 					val:        "ignore-errors",
 					ignoreCase: false,
 					want:       "\"ignore-errors\"",
@@ -1863,40 +3500,40 @@ var g = &grammar{
 		},
 		{
 			name: "CHAIN",
-			pos:  position{line: 167, col: 1, offset: 3442},
+			pos:  position{line: 299, col: 1, offset: 6783},
 			expr: &actionExpr{
-				pos: position{line: 167, col: 10, offset: 3451},
+				pos: position{line: 299, col: 10, offset: 6792},
 				run: (*parser).callonCHAIN1,
 				expr: &seqExpr{
-					pos: position{line: 167, col: 10, offset: 3451},
+					pos: position{line: 299, col: 10, offset: 6792},
 					exprs: []interface{}{
 						&labeledExpr{
-							pos:   position{line: 167, col: 10, offset: 3451},
+							pos:   position{line: 299, col: 10, offset: 6792},
 							label: "i",
 							expr: &ruleRefExpr{
-								pos:  position{line: 167, col: 13, offset: 3454},
+								pos:  position{line: 299, col: 13, offset: 6795},
 								name: "CHAINED_ITEM",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 167, col: 27, offset: 3468},
+							pos:   position{line: 299, col: 27, offset: 6809},
 							label: "ii",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 167, col: 30, offset: 3471},
+								pos: position{line: 299, col: 30, offset: 6812},
 								expr: &seqExpr{
-									pos: position{line: 167, col: 31, offset: 3472},
+									pos: position{line: 299, col: 31, offset: 6813},
 									exprs: []interface{}{
 										&zeroOrOneExpr{
-											pos: position{line: 167, col: 31, offset: 3472},
+											pos: position{line: 299, col: 31, offset: 6813},
 											expr: &litMatcher{
-												pos:        position{line: 167, col: 31, offset: 3472},
+												pos:        position{line: 299, col: 31, offset: 6813},
 												val:        ".",
 												ignoreCase: false,
 												want:       "\".\"",
 											},
 										},
 										&ruleRefExpr{
-											pos:  position{line: 167, col: 36, offset: 3477},
+											pos:  position{line: 299, col: 36, offset: 6818},
 											name: "CHAINED_ITEM",
 										},
 									},
@@ -1909,22 +3546,22 @@ var g = &grammar{
 		},
 		{
 			name: "CHAINED_ITEM",
-			pos:  position{line: 171, col: 1, offset: 3521},
+			pos:  position{line: 303, col: 1, offset: 6862},
 			expr: &actionExpr{
-				pos: position{line: 171, col: 17, offset: 3537},
+				pos: position{line: 303, col: 17, offset: 6878},
 				run: (*parser).callonCHAINED_ITEM1,
 				expr: &labeledExpr{
-					pos:   position{line: 171, col: 17, offset: 3537},
+					pos:   position{line: 303, col: 17, offset: 6878},
 					label: "ci",
 					expr: &choiceExpr{
-						pos: position{line: 171, col: 21, offset: 3541},
+						pos: position{line: 303, col: 21, offset: 6882},
 						alternatives: []interface{}{
 							&ruleRefExpr{
-								pos:  position{line: 171, col: 21, offset: 3541},
+								pos:  position{line: 303, col: 21, offset: 6882},
 								name: "PATH_VARIABLE",
 							},
 							&ruleRefExpr{
-								pos:  position{line: 171, col: 37, offset: 3557},
+								pos:  position{line: 303, col: 37, offset: 6898},
 								name: "IDENT",
 							},
 						},
@@ -1934,40 +3571,40 @@ var g = &grammar{
 		},
 		{
 			name: "PATH_VARIABLE",
-			pos:  position{line: 175, col: 1, offset: 3592},
+			pos:  position{line: 307, col: 1, offset: 6933},
 			expr: &actionExpr{
-				pos: position{line: 175, col: 18, offset: 3609},
+				pos: position{line: 307, col: 18, offset: 6950},
 				run: (*parser).callonPATH_VARIABLE1,
 				expr: &seqExpr{
-					pos: position{line: 175, col: 18, offset: 3609},
+					pos: position{line: 307, col: 18, offset: 6950},
 					exprs: []interface{}{
 						&zeroOrOneExpr{
-							pos: position{line: 175, col: 18, offset: 3609},
+							pos: position{line: 307, col: 18, offset: 6950},
 							expr: &litMatcher{
-								pos:        position{line: 175, col: 18, offset: 3609},
+								pos:        position{line: 307, col: 18, offset: 6950},
 								val:        "[",
 								ignoreCase: false,
 								want:       "\"[\"",
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 175, col: 23, offset: 3614},
+							pos:        position{line: 307, col: 23, offset: 6955},
 							val:        "$",
 							ignoreCase: false,
 							want:       "\"$\"",
 						},
 						&labeledExpr{
-							pos:   position{line: 175, col: 27, offset: 3618},
+							pos:   position{line: 307, col: 27, offset: 6959},
 							label: "i",
 							expr: &ruleRefExpr{
-								pos:  position{line: 175, col: 30, offset: 3621},
+								pos:  position{line: 307, col: 30, offset: 6962},
 								name: "IDENT",
 							},
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 175, col: 37, offset: 3628},
+							pos: position{line: 307, col: 37, offset: 6969},
 							expr: &litMatcher{
-								pos:        position{line: 175, col: 37, offset: 3628},
+								pos:        position{line: 307, col: 37, offset: 6969},
 								val:        "]",
 								ignoreCase: false,
 								want:       "\"]\"",
@@ -1979,24 +3616,24 @@ var g = &grammar{
 		},
 		{
 			name: "VARIABLE",
-			pos:  position{line: 179, col: 1, offset: 3670},
+			pos:  position{line: 311, col: 1, offset: 7011},
 			expr: &actionExpr{
-				pos: position{line: 179, col: 13, offset: 3682},
+				pos: position{line: 311, col: 13, offset: 7023},
 				run: (*parser).callonVARIABLE1,
 				expr: &seqExpr{
-					pos: position{line: 179, col: 13, offset: 3682},
+					pos: position{line: 311, col: 13, offset: 7023},
 					exprs: []interface{}{
 						&litMatcher{
-							pos:        position{line: 179, col: 13, offset: 3682},
+							pos:        position{line: 311, col: 13, offset: 7023},
 							val:        "$",
 							ignoreCase: false,
 							want:       "\"$\"",
 						},
 						&labeledExpr{
-							pos:   position{line: 179, col: 17, offset: 3686},
+							pos:   position{line: 311, col: 17, offset: 7027},
 							label: "v",
 							expr: &ruleRefExpr{
-								pos:  position{line: 179, col: 20, offset: 3689},
+								pos:  position{line: 311, col: 20, offset: 7030},
 								name: "IDENT_WITH_DOT",
 							},
 						},
@@ -2006,14 +3643,14 @@ var g = &grammar{
 		},
 		{
 			name: "IDENT",
-			pos:  position{line: 183, col: 1, offset: 3733},
+			pos:  position{line: 315, col: 1, offset: 7074},
 			expr: &actionExpr{
-				pos: position{line: 183, col: 10, offset: 3742},
+				pos: position{line: 315, col: 10, offset: 7083},
 				run: (*parser).callonIDENT1,
 				expr: &oneOrMoreExpr{
-					pos: position{line: 183, col: 10, offset: 3742},
+					pos: position{line: 315, col: 10, offset: 7083},
 					expr: &charClassMatcher{
-						pos:        position{line: 183, col: 10, offset: 3742},
+						pos:        position{line: 315, col: 10, offset: 7083},
 						val:        "[A-Za-z0-9_-]",
 						chars:      []rune{'_', '-'},
 						ranges:     []rune{'A', 'Z', 'a', 'z', '0', '9'},
@@ -2025,14 +3662,14 @@ var g = &grammar{
 		},
 		{
 			name: "IDENT_WITH_DOT",
-			pos:  position{line: 187, col: 1, offset: 3788},
+			pos:  position{line: 319, col: 1, offset: 7129},
 			expr: &actionExpr{
-				pos: position{line: 187, col: 19, offset: 3806},
+				pos: position{line: 319, col: 19, offset: 7147},
 				run: (*parser).callonIDENT_WITH_DOT1,
 				expr: &oneOrMoreExpr{
-					pos: position{line: 187, col: 19, offset: 3806},
+					pos: position{line: 319, col: 19, offset: 7147},
 					expr: &charClassMatcher{
-						pos:        position{line: 187, col: 19, offset: 3806},
+						pos:        position{line: 319, col: 19, offset: 7147},
 						val:        "[a-zA-Z0-9-_.]",
 						chars:      []rune{'-', '_', '.'},
 						ranges:     []rune{'a', 'z', 'A', 'Z', '0', '9'},
@@ -2044,12 +3681,12 @@ var g = &grammar{
 		},
 		{
 			name: "Null",
-			pos:  position{line: 191, col: 1, offset: 3853},
+			pos:  position{line: 323, col: 1, offset: 7194},
 			expr: &actionExpr{
-				pos: position{line: 191, col: 9, offset: 3861},
+				pos: position{line: 323, col: 9, offset: 7202},
 				run: (*parser).callonNull1,
 				expr: &litMatcher{
-					pos:        position{line: 191, col: 9, offset: 3861},
+					pos:        position{line: 323, col: 9, offset: 7202},
 					val:        "null",
 					ignoreCase: false,
 					want:       "\"null\"",
@@ -2058,21 +3695,21 @@ var g = &grammar{
 		},
 		{
 			name: "Boolean",
-			pos:  position{line: 195, col: 1, offset: 3891},
+			pos:  position{line: 327, col: 1, offset: 7232},
 			expr: &actionExpr{
-				pos: position{line: 195, col: 12, offset: 3902},
+				pos: position{line: 327, col: 12, offset: 7243},
 				run: (*parser).callonBoolean1,
 				expr: &choiceExpr{
-					pos: position{line: 195, col: 13, offset: 3903},
+					pos: position{line: 327, col: 13, offset: 7244},
 					alternatives: []interface{}{
 						&litMatcher{
-							pos:        position{line: 195, col: 13, offset: 3903},
+							pos:        position{line: 327, col: 13, offset: 7244},
 							val:        "true",
 							ignoreCase: false,
 							want:       "\"true\"",
 						},
 						&litMatcher{
-							pos:        position{line: 195, col: 22, offset: 3912},
+							pos:        position{line: 327, col: 22, offset: 7253},
 							val:        "false",
 							ignoreCase: false,
 							want:       "\"false\"",
@@ -2083,41 +3720,41 @@ var g = &grammar{
 		},
 		{
 			name: "String",
-			pos:  position{line: 199, col: 1, offset: 3953},
+			pos:  position{line: 331, col: 1, offset: 7294},
 			expr: &actionExpr{
-				pos: position{line: 199, col: 11, offset: 3963},
+				pos: position{line: 331, col: 11, offset: 7304},
 				run: (*parser).callonString1,
 				expr: &seqExpr{
-					pos: position{line: 199, col: 11, offset: 3963},
+					pos: position{line: 331, col: 11, offset: 7304},
 					exprs: []interface{}{
 						&litMatcher{
-							pos:        position{line: 199, col: 11, offset: 3963},
+							pos:        position{line: 331, col: 11, offset: 7304},
 							val:        "\"",
 							ignoreCase: false,
 							want:       "\"\\\"\"",
 						},
 						&zeroOrMoreExpr{
-							pos: position{line: 199, col: 15, offset: 3967},
+							pos: position{line: 331, col: 15, offset: 7308},
 							expr: &seqExpr{
-								pos: position{line: 199, col: 17, offset: 3969},
+								pos: position{line: 331, col: 17, offset: 7310},
 								exprs: []interface{}{
 									&notExpr{
-										pos: position{line: 199, col: 17, offset: 3969},
+										pos: position{line: 331, col: 17, offset: 7310},
 										expr: &litMatcher{
-											pos:        position{line: 199, col: 18, offset: 3970},
+											pos:        position{line: 331, col: 18, offset: 7311},
 											val:        "\"",
 											ignoreCase: false,
 											want:       "\"\\\"\"",
 										},
 									},
 									&anyMatcher{
-										line: 199, col: 22, offset: 3974,
+										line: 331, col: 22, offset: 7315,
 									},
 								},
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 199, col: 27, offset: 3979},
+							pos:        position{line: 331, col: 27, offset: 7320},
 							val:        "\"",
 							ignoreCase: false,
 							want:       "\"\\\"\"",
@@ -2128,26 +3765,26 @@ var g = &grammar{
 		},
 		{
 			name: "Float",
-			pos:  position{line: 203, col: 1, offset: 4014},
+			pos:  position{line: 335, col: 1, offset: 7355},
 			expr: &actionExpr{
-				pos: position{line: 203, col: 10, offset: 4023},
+				pos: position{line: 335, col: 10, offset: 7364},
 				run: (*parser).callonFloat1,
 				expr: &seqExpr{
-					pos: position{line: 203, col: 10, offset: 4023},
+					pos: position{line: 335, col: 10, offset: 7364},
 					exprs: []interface{}{
 						&zeroOrOneExpr{
-							pos: position{line: 203, col: 10, offset: 4023},
+							pos: position{line: 335, col: 10, offset: 7364},
 							expr: &choiceExpr{
-								pos: position{line: 203, col: 11, offset: 4024},
+								pos: position{line: 335, col: 11, offset: 7365},
 								alternatives: []interface{}{
 									&litMatcher{
-										pos:        position{line: 203, col: 11, offset: 4024},
+										pos:        position{line: 335, col: 11, offset: 7365},
 										val:        "+",
 										ignoreCase: false,
 										want:       "\"+\"",
 									},
 									&litMatcher{
-										pos:        position{line: 203, col: 17, offset: 4030},
+										pos:        position{line: 335, col: 17, offset: 7371},
 										val:        "-",
 										ignoreCase: false,
 										want:       "\"-\"",
@@ -2156,17 +3793,17 @@ var g = &grammar{
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 203, col: 23, offset: 4036},
+							pos:  position{line: 335, col: 23, offset: 7377},
 							name: "Natural",
 						},
 						&litMatcher{
-							pos:        position{line: 203, col: 31, offset: 4044},
+							pos:        position{line: 335, col: 31, offset: 7385},
 							val:        ".",
 							ignoreCase: false,
 							want:       "\".\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 203, col: 35, offset: 4048},
+							pos:  position{line: 335, col: 35, offset: 7389},
 							name: "Natural",
 						},
 					},
@@ -2175,26 +3812,26 @@ var g = &grammar{
 		},
 		{
 			name: "Integer",
-			pos:  position{line: 207, col: 1, offset: 4086},
+			pos:  position{line: 339, col: 1, offset: 7427},
 			expr: &actionExpr{
-				pos: position{line: 207, col: 12, offset: 4097},
+				pos: position{line: 339, col: 12, offset: 7438},
 				run: (*parser).callonInteger1,
 				expr: &seqExpr{
-					pos: position{line: 207, col: 12, offset: 4097},
+					pos: position{line: 339, col: 12, offset: 7438},
 					exprs: []interface{}{
 						&zeroOrOneExpr{
-							pos: position{line: 207, col: 12, offset: 4097},
+							pos: position{line: 339, col: 12, offset: 7438},
 							expr: &choiceExpr{
-								pos: position{line: 207, col: 13, offset: 4098},
+								pos: position{line: 339, col: 13, offset: 7439},
 								alternatives: []interface{}{
 									&litMatcher{
-										pos:        position{line: 207, col: 13, offset: 4098},
+										pos:        position{line: 339, col: 13, offset: 7439},
 										val:        "+",
 										ignoreCase: false,
 										want:       "\"+\"",
 									},
 									&litMatcher{
-										pos:        position{line: 207, col: 19, offset: 4104},
+										pos:        position{line: 339, col: 19, offset: 7445},
 										val:        "-",
 										ignoreCase: false,
 										want:       "\"-\"",
@@ -2203,7 +3840,7 @@ var g = &grammar{
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 207, col: 25, offset: 4110},
+							pos:  position{line: 339, col: 25, offset: 7451},
 							name: "Natural",
 						},
 					},
@@ -2212,27 +3849,27 @@ var g = &grammar{
 		},
 		{
 			name: "Natural",
-			pos:  position{line: 211, col: 1, offset: 4150},
+			pos:  position{line: 343, col: 1, offset: 7491},
 			expr: &choiceExpr{
-				pos: position{line: 211, col: 11, offset: 4162},
+				pos: position{line: 343, col: 11, offset: 7503},
 				alternatives: []interface{}{
 					&litMatcher{
-						pos:        position{line: 211, col: 11, offset: 4162},
+						pos:        position{line: 343, col: 11, offset: 7503},
 						val:        "0",
 						ignoreCase: false,
 						want:       "\"0\"",
 					},
 					&seqExpr{
-						pos: position{line: 211, col: 17, offset: 4168},
+						pos: position{line: 343, col: 17, offset: 7509},
 						exprs: []interface{}{
 							&ruleRefExpr{
-								pos:  position{line: 211, col: 17, offset: 4168},
+								pos:  position{line: 343, col: 17, offset: 7509},
 								name: "NonZeroDecimalDigit",
 							},
 							&zeroOrMoreExpr{
-								pos: position{line: 211, col: 37, offset: 4188},
+								pos: position{line: 343, col: 37, offset: 7529},
 								expr: &ruleRefExpr{
-									pos:  position{line: 211, col: 37, offset: 4188},
+									pos:  position{line: 343, col: 37, offset: 7529},
 									name: "DecimalDigit",
 								},
 							},
@@ -2243,9 +3880,9 @@ var g = &grammar{
 		},
 		{
 			name: "DecimalDigit",
-			pos:  position{line: 213, col: 1, offset: 4203},
+			pos:  position{line: 345, col: 1, offset: 7544},
 			expr: &charClassMatcher{
-				pos:        position{line: 213, col: 16, offset: 4220},
+				pos:        position{line: 345, col: 16, offset: 7561},
 				val:        "[0-9]",
 				ranges:     []rune{'0', '9'},
 				ignoreCase: false,
@@ -2254,9 +3891,9 @@ var g = &grammar{
 		},
 		{
 			name: "NonZeroDecimalDigit",
-			pos:  position{line: 214, col: 1, offset: 4226},
+			pos:  position{line: 346, col: 1, offset: 7567},
 			expr: &charClassMatcher{
-				pos:        position{line: 214, col: 23, offset: 4250},
+				pos:        position{line: 346, col: 23, offset: 7591},
 				val:        "[1-9]",
 				ranges:     []rune{'1', '9'},
 				ignoreCase: false,
@@ -2265,9 +3902,9 @@ var g = &grammar{
 		},
 		{
 			name: "SPACE",
-			pos:  position{line: 216, col: 1, offset: 4257},
+			pos:  position{line: 348, col: 1, offset: 7598},
 			expr: &charClassMatcher{
-				pos:        position{line: 216, col: 10, offset: 4266},
+				pos:        position{line: 348, col: 10, offset: 7607},
 				val:        "[ \\t]",
 				chars:      []rune{' ', '\t'},
 				ignoreCase: false,
@@ -2277,22 +3914,22 @@ var g = &grammar{
 		{
 			name:        "WS_MAND",
 			displayName: "\"mandatory-whitespace\"",
-			pos:         position{line: 217, col: 1, offset: 4272},
+			pos:         position{line: 349, col: 1, offset: 7613},
 			expr: &oneOrMoreExpr{
-				pos: position{line: 217, col: 35, offset: 4306},
+				pos: position{line: 349, col: 35, offset: 7647},
 				expr: &choiceExpr{
-					pos: position{line: 217, col: 36, offset: 4307},
+					pos: position{line: 349, col: 36, offset: 7648},
 					alternatives: []interface{}{
 						&ruleRefExpr{
-							pos:  position{line: 217, col: 36, offset: 4307},
+							pos:  position{line: 349, col: 36, offset: 7648},
 							name: "SPACE",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 217, col: 44, offset: 4315},
+							pos:  position{line: 349, col: 44, offset: 7656},
 							name: "COMMENT",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 217, col: 54, offset: 4325},
+							pos:  position{line: 349, col: 54, offset: 7666},
 							name: "NL",
 						},
 					},
@@ -2302,18 +3939,18 @@ var g = &grammar{
 		{
 			name:        "WS",
 			displayName: "\"whitespace\"",
-			pos:         position{line: 218, col: 1, offset: 4330},
+			pos:         position{line: 350, col: 1, offset: 7671},
 			expr: &zeroOrMoreExpr{
-				pos: position{line: 218, col: 20, offset: 4349},
+				pos: position{line: 350, col: 20, offset: 7690},
 				expr: &choiceExpr{
-					pos: position{line: 218, col: 21, offset: 4350},
+					pos: position{line: 350, col: 21, offset: 7691},
 					alternatives: []interface{}{
 						&ruleRefExpr{
-							pos:  position{line: 218, col: 21, offset: 4350},
+							pos:  position{line: 350, col: 21, offset: 7691},
 							name: "SPACE",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 218, col: 29, offset: 4358},
+							pos:  position{line: 350, col: 29, offset: 7699},
 							name: "COMMENT",
 						},
 					},
@@ -2323,22 +3960,22 @@ var g = &grammar{
 		{
 			name:        "LS",
 			displayName: "\"line-separator\"",
-			pos:         position{line: 219, col: 1, offset: 4368},
+			pos:         position{line: 351, col: 1, offset: 7709},
 			expr: &choiceExpr{
-				pos: position{line: 219, col: 25, offset: 4392},
+				pos: position{line: 351, col: 25, offset: 7733},
 				alternatives: []interface{}{
 					&ruleRefExpr{
-						pos:  position{line: 219, col: 25, offset: 4392},
+						pos:  position{line: 351, col: 25, offset: 7733},
 						name: "NL",
 					},
 					&litMatcher{
-						pos:        position{line: 219, col: 30, offset: 4397},
+						pos:        position{line: 351, col: 30, offset: 7738},
 						val:        ",",
 						ignoreCase: false,
 						want:       "\",\"",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 219, col: 36, offset: 4403},
+						pos:  position{line: 351, col: 36, offset: 7744},
 						name: "COMMENT",
 					},
 				},
@@ -2347,31 +3984,31 @@ var g = &grammar{
 		{
 			name:        "BS",
 			displayName: "\"block-separator\"",
-			pos:         position{line: 220, col: 1, offset: 4412},
+			pos:         position{line: 352, col: 1, offset: 7753},
 			expr: &oneOrMoreExpr{
-				pos: position{line: 220, col: 25, offset: 4436},
+				pos: position{line: 352, col: 25, offset: 7777},
 				expr: &seqExpr{
-					pos: position{line: 220, col: 26, offset: 4437},
+					pos: position{line: 352, col: 26, offset: 7778},
 					exprs: []interface{}{
 						&ruleRefExpr{
-							pos:  position{line: 220, col: 26, offset: 4437},
+							pos:  position{line: 352, col: 26, offset: 7778},
 							name: "WS",
 						},
 						&choiceExpr{
-							pos: position{line: 220, col: 30, offset: 4441},
+							pos: position{line: 352, col: 30, offset: 7782},
 							alternatives: []interface{}{
 								&ruleRefExpr{
-									pos:  position{line: 220, col: 30, offset: 4441},
+									pos:  position{line: 352, col: 30, offset: 7782},
 									name: "NL",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 220, col: 35, offset: 4446},
+									pos:  position{line: 352, col: 35, offset: 7787},
 									name: "COMMENT",
 								},
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 220, col: 44, offset: 4455},
+							pos:  position{line: 352, col: 44, offset: 7796},
 							name: "WS",
 						},
 					},
@@ -2381,9 +4018,9 @@ var g = &grammar{
 		{
 			name:        "NL",
 			displayName: "\"new-line\"",
-			pos:         position{line: 221, col: 1, offset: 4460},
+			pos:         position{line: 353, col: 1, offset: 7801},
 			expr: &litMatcher{
-				pos:        position{line: 221, col: 18, offset: 4477},
+				pos:        position{line: 353, col: 18, offset: 7818},
 				val:        "\n",
 				ignoreCase: false,
 				want:       "\"\\n\"",
@@ -2391,47 +4028,47 @@ var g = &grammar{
 		},
 		{
 			name: "COMMENT",
-			pos:  position{line: 223, col: 1, offset: 4483},
+			pos:  position{line: 355, col: 1, offset: 7824},
 			expr: &seqExpr{
-				pos: position{line: 223, col: 12, offset: 4494},
+				pos: position{line: 355, col: 12, offset: 7835},
 				exprs: []interface{}{
 					&litMatcher{
-						pos:        position{line: 223, col: 12, offset: 4494},
+						pos:        position{line: 355, col: 12, offset: 7835},
 						val:        "//",
 						ignoreCase: false,
 						want:       "\"//\"",
 					},
 					&zeroOrMoreExpr{
-						pos: position{line: 223, col: 17, offset: 4499},
+						pos: position{line: 355, col: 17, offset: 7840},
 						expr: &seqExpr{
-							pos: position{line: 223, col: 19, offset: 4501},
+							pos: position{line: 355, col: 19, offset: 7842},
 							exprs: []interface{}{
 								&notExpr{
-									pos: position{line: 223, col: 19, offset: 4501},
+									pos: position{line: 355, col: 19, offset: 7842},
 									expr: &litMatcher{
-										pos:        position{line: 223, col: 20, offset: 4502},
+										pos:        position{line: 355, col: 20, offset: 7843},
 										val:        "\n",
 										ignoreCase: false,
 										want:       "\"\\n\"",
 									},
 								},
 								&anyMatcher{
-									line: 223, col: 25, offset: 4507,
+									line: 355, col: 25, offset: 7848,
 								},
 							},
 						},
 					},
 					&choiceExpr{
-						pos: position{line: 223, col: 31, offset: 4513},
+						pos: position{line: 355, col: 31, offset: 7854},
 						alternatives: []interface{}{
 							&litMatcher{
-								pos:        position{line: 223, col: 31, offset: 4513},
+								pos:        position{line: 355, col: 31, offset: 7854},
 								val:        "\n",
 								ignoreCase: false,
 								want:       "\"\\n\"",
 							},
 							&ruleRefExpr{
-								pos:  position{line: 223, col: 38, offset: 4520},
+								pos:  position{line: 355, col: 38, offset: 7861},
 								name: "EOF",
 							},
 						},
@@ -2441,25 +4078,25 @@ var g = &grammar{
 		},
 		{
 			name: "EOF",
-			pos:  position{line: 225, col: 1, offset: 4526},
+			pos:  position{line: 357, col: 1, offset: 7867},
 			expr: &notExpr{
-				pos: position{line: 225, col: 8, offset: 4533},
+				pos: position{line: 357, col: 8, offset: 7874},
 				expr: &anyMatcher{
-					line: 225, col: 9, offset: 4534,
+					line: 357, col: 9, offset: 7875,
 				},
 			},
 		},
 	},
 }
 
-func (c *current) onQUERY1(us, firstBlock, otherBlocks interface{}) (interface{}, error) {
-	return newQuery(us, firstBlock, otherBlocks)
+func (c *current) onQUERY1(us, ps, firstBlock, otherBlocks interface{}) (interface{}, error) {
+	return newQuery(us, ps, firstBlock, otherBlocks)
 }
 
 func (p *parser) callonQUERY1() (interface{}, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onQUERY1(stack["us"], stack["firstBlock"], stack["otherBlocks"])
+	return p.cur.onQUERY1(stack["us"], stack["ps"], stack["firstBlock"], stack["otherBlocks"])
 }
 
 func (c *current) onUSE1(r, v interface{}) (interface{}, error) {
@@ -2492,14 +4129,84 @@ func (p *parser) callonUSE_VALUE1() (interface{}, error) {
 	return p.cur.onUSE_VALUE1(stack["v"])
 }
 
-func (c *current) onBLOCK1(action, m, w, f, fl interface{}) (interface{}, error) {
-	return newBlock(action, m, w, f, fl)
+func (c *current) onPARAMS1(first, others interface{}) (interface{}, error) {
+	return newParamDeclarationList(first, others)
+}
+
+func (p *parser) callonPARAMS1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onPARAMS1(stack["first"], stack["others"])
+}
+
+func (c *current) onPARAM_DECLARATION1(n, t, r, d interface{}) (interface{}, error) {
+	return newParamDeclaration(n, t, r, d)
+}
+
+func (p *parser) callonPARAM_DECLARATION1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onPARAM_DECLARATION1(stack["n"], stack["t"], stack["r"], stack["d"])
+}
+
+func (c *current) onPARAM_TYPE1(t interface{}) (interface{}, error) {
+	return newParamType(t)
+}
+
+func (p *parser) callonPARAM_TYPE1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onPARAM_TYPE1(stack["t"])
+}
+
+func (c *current) onPARAM_LIST_TYPE1(t interface{}) (interface{}, error) {
+	return newListParamType(t)
+}
+
+func (p *parser) callonPARAM_LIST_TYPE1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onPARAM_LIST_TYPE1(stack["t"])
+}
+
+func (c *current) onPARAM_TYPE_NAME1() (interface{}, error) {
+	return stringify(c.text)
+}
+
+func (p *parser) callonPARAM_TYPE_NAME1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onPARAM_TYPE_NAME1()
+}
+
+func (c *current) onPARAM_REQUIRED1() (interface{}, error) {
+	return newParamRequired()
+}
+
+func (p *parser) callonPARAM_REQUIRED1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onPARAM_REQUIRED1()
+}
+
+func (c *current) onPARAM_DEFAULT1(v interface{}) (interface{}, error) {
+	return newValue(v)
+}
+
+func (p *parser) callonPARAM_DEFAULT1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onPARAM_DEFAULT1(stack["v"])
+}
+
+func (c *current) onBLOCK1(action, m, w, wh, f, fl interface{}) (interface{}, error) {
+	return newBlock(action, m, w, wh, f, fl)
 }
 
 func (p *parser) callonBLOCK1() (interface{}, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onBLOCK1(stack["action"], stack["m"], stack["w"], stack["f"], stack["fl"])
+	return p.cur.onBLOCK1(stack["action"], stack["m"], stack["w"], stack["wh"], stack["f"], stack["fl"])
 }
 
 func (c *current) onACTION_RULE1(m, r, a, i interface{}) (interface{}, error) {
@@ -2532,14 +4239,34 @@ func (p *parser) callonALIAS1() (interface{}, error) {
 	return p.cur.onALIAS1(stack["a"])
 }
 
-func (c *current) onIN1(t interface{}) (interface{}, error) {
-	return newIn(t)
+func (c *current) onIN1(t, j interface{}) (interface{}, error) {
+	return newIn(t, j)
 }
 
 func (p *parser) callonIN1() (interface{}, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onIN1(stack["t"])
+	return p.cur.onIN1(stack["t"], stack["j"])
+}
+
+func (c *current) onJOIN1(m, l, r interface{}) (interface{}, error) {
+	return newJoin(m, l, r)
+}
+
+func (p *parser) callonJOIN1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onJOIN1(stack["m"], stack["l"], stack["r"])
+}
+
+func (c *current) onJOIN_MANY1() (interface{}, error) {
+	return true, nil
+}
+
+func (p *parser) callonJOIN_MANY1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onJOIN_MANY1()
 }
 
 func (c *current) onMODIFIER_RULE1(m interface{}) (interface{}, error) {
@@ -2582,14 +4309,14 @@ func (p *parser) callonKEY_VALUE_LIST1() (interface{}, error) {
 	return p.cur.onKEY_VALUE_LIST1(stack["first"], stack["others"])
 }
 
-func (c *current) onKEY_VALUE1(k, v, fn interface{}) (interface{}, error) {
-	return newKeyValue(k, v, fn)
+func (c *current) onKEY_VALUE1(k, v, fn, b interface{}) (interface{}, error) {
+	return newKeyValue(k, v, fn, b)
 }
 
 func (p *parser) callonKEY_VALUE1() (interface{}, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onKEY_VALUE1(stack["k"], stack["v"], stack["fn"])
+	return p.cur.onKEY_VALUE1(stack["k"], stack["v"], stack["fn"], stack["b"])
 }
 
 func (c *current) onAPPLY_FN1(fn interface{}) (interface{}, error) {
@@ -2602,14 +4329,34 @@ func (p *parser) callonAPPLY_FN1() (interface{}, error) {
 	return p.cur.onAPPLY_FN1(stack["fn"])
 }
 
-func (c *current) onFUNCTION1() (interface{}, error) {
-	return stringify(c.text)
+func (c *current) onBATCH_FN1(s, k interface{}) (interface{}, error) {
+	return newBatch(s, k)
+}
+
+func (p *parser) callonBATCH_FN1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onBATCH_FN1(stack["s"], stack["k"])
+}
+
+func (c *current) onBATCH_KEY1(k interface{}) (interface{}, error) {
+	return k, nil
+}
+
+func (p *parser) callonBATCH_KEY1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onBATCH_KEY1(stack["k"])
+}
+
+func (c *current) onFUNCTION1(fn interface{}) (interface{}, error) {
+	return fn, nil
 }
 
 func (p *parser) callonFUNCTION1() (interface{}, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onFUNCTION1()
+	return p.cur.onFUNCTION1(stack["fn"])
 }
 
 func (c *current) onVALUE1(v interface{}) (interface{}, error) {
@@ -2702,6 +4449,56 @@ func (p *parser) callonPRIMITIVE1() (interface{}, error) {
 	return p.cur.onPRIMITIVE1(stack["p"])
 }
 
+func (c *current) onWHEN_RULE1(n, l, cmp interface{}) (interface{}, error) {
+	return newCondition(n, l, cmp)
+}
+
+func (p *parser) callonWHEN_RULE1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onWHEN_RULE1(stack["n"], stack["l"], stack["cmp"])
+}
+
+func (c *current) onNEGATION1() (interface{}, error) {
+	return newNegation()
+}
+
+func (p *parser) callonNEGATION1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onNEGATION1()
+}
+
+func (c *current) onCONDITION_COMPARISON1(o, v interface{}) (interface{}, error) {
+	return newComparison(o, v)
+}
+
+func (p *parser) callonCONDITION_COMPARISON1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onCONDITION_COMPARISON1(stack["o"], stack["v"])
+}
+
+func (c *current) onCONDITION_OPERATOR1() (interface{}, error) {
+	return stringify(c.text)
+}
+
+func (p *parser) callonCONDITION_OPERATOR1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onCONDITION_OPERATOR1()
+}
+
+func (c *current) onCONDITION_OPERAND1(v interface{}) (interface{}, error) {
+	return newValue(v)
+}
+
+func (p *parser) callonCONDITION_OPERAND1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onCONDITION_OPERAND1(stack["v"])
+}
+
 func (c *current) onONLY_RULE1(f, fs interface{}) (interface{}, error) {
 	return newOnly(f, fs)
 }
@@ -2712,14 +4509,74 @@ func (p *parser) callonONLY_RULE1() (interface{}, error) {
 	return p.cur.onONLY_RULE1(stack["f"], stack["fs"])
 }
 
-func (c *current) onFILTER1(f, fn interface{}) (interface{}, error) {
-	return newFilter(f, fn)
+func (c *current) onFILTER1(f, a interface{}) (interface{}, error) {
+	return newAliasedFilter(f, a)
 }
 
 func (p *parser) callonFILTER1() (interface{}, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onFILTER1(stack["f"], stack["fn"])
+	return p.cur.onFILTER1(stack["f"], stack["a"])
+}
+
+func (c *current) onFIELD_FILTER1(f, fn interface{}) (interface{}, error) {
+	return newFilter(f, fn)
+}
+
+func (p *parser) callonFIELD_FILTER1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onFIELD_FILTER1(stack["f"], stack["fn"])
+}
+
+func (c *current) onFILTER_ALIAS1(a interface{}) (interface{}, error) {
+	return a, nil
+}
+
+func (p *parser) callonFILTER_ALIAS1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onFILTER_ALIAS1(stack["a"])
+}
+
+func (c *current) onEXPRESSION_FILTER1(fn, a, as interface{}) (interface{}, error) {
+	return newExpression(fn, a, as)
+}
+
+func (p *parser) callonEXPRESSION_FILTER1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onEXPRESSION_FILTER1(stack["fn"], stack["a"], stack["as"])
+}
+
+func (c *current) onEXPRESSION_FUNCTION1() (interface{}, error) {
+	return stringify(c.text)
+}
+
+func (p *parser) callonEXPRESSION_FUNCTION1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onEXPRESSION_FUNCTION1()
+}
+
+func (c *current) onEXPRESSION_ARG1(a interface{}) (interface{}, error) {
+	return newExpressionArg(a)
+}
+
+func (p *parser) callonEXPRESSION_ARG1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onEXPRESSION_ARG1(stack["a"])
+}
+
+func (c *current) onEXPRESSION_FIELD1(f interface{}) (interface{}, error) {
+	return newExpressionField(f)
+}
+
+func (p *parser) callonEXPRESSION_FIELD1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onEXPRESSION_FIELD1(stack["f"])
 }
 
 func (c *current) onFILTER_VALUE1(fv interface{}) (interface{}, error) {
@@ -2742,6 +4599,46 @@ func (p *parser) callonMATCHES_FN1() (interface{}, error) {
 	return p.cur.onMATCHES_FN1(stack["arg"])
 }
 
+func (c *current) onPREDICATE_FN1(op, arg interface{}) (interface{}, error) {
+	return newPredicate(op, arg)
+}
+
+func (p *parser) callonPREDICATE_FN1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onPREDICATE_FN1(stack["op"], stack["arg"])
+}
+
+func (c *current) onPREDICATE_OPERATOR1() (interface{}, error) {
+	return stringify(c.text)
+}
+
+func (p *parser) callonPREDICATE_OPERATOR1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onPREDICATE_OPERATOR1()
+}
+
+func (c *current) onPREDICATE_ARG1(v interface{}) (interface{}, error) {
+	return newValue(v)
+}
+
+func (p *parser) callonPREDICATE_ARG1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onPREDICATE_ARG1(stack["v"])
+}
+
+func (c *current) onPREDICATE_PRIMITIVE1(p interface{}) (interface{}, error) {
+	return newPrimitive(p)
+}
+
+func (p *parser) callonPREDICATE_PRIMITIVE1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onPREDICATE_PRIMITIVE1(stack["p"])
+}
+
 func (c *current) onHEADERS1(h, hs interface{}) (interface{}, error) {
 	return newHeaders(h, hs)
 }
@@ -2802,6 +4699,76 @@ func (p *parser) callonS_MAX_AGE1() (interface{}, error) {
 	return p.cur.onS_MAX_AGE1(stack["t"])
 }
 
+func (c *current) onRETRY1(o, os interface{}) (interface{}, error) {
+	return newRetry(o, os)
+}
+
+func (p *parser) callonRETRY1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onRETRY1(stack["o"], stack["os"])
+}
+
+func (c *current) onRETRY_OPTION1(k, v interface{}) (interface{}, error) {
+	return newRetryOption(k, v)
+}
+
+func (p *parser) callonRETRY_OPTION1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onRETRY_OPTION1(stack["k"], stack["v"])
+}
+
+func (c *current) onRETRY_KEY1() (interface{}, error) {
+	return stringify(c.text)
+}
+
+func (p *parser) callonRETRY_KEY1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onRETRY_KEY1()
+}
+
+func (c *current) onPAGINATE1(o, os interface{}) (interface{}, error) {
+	return newPaginate(o, os)
+}
+
+func (p *parser) callonPAGINATE1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onPAGINATE1(stack["o"], stack["os"])
+}
+
+func (c *current) onPAGINATE_OPTION1(k, v interface{}) (interface{}, error) {
+	return newPaginateOption(k, v)
+}
+
+func (p *parser) callonPAGINATE_OPTION1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onPAGINATE_OPTION1(stack["k"], stack["v"])
+}
+
+func (c *current) onPAGINATE_KEY1() (interface{}, error) {
+	return stringify(c.text)
+}
+
+func (p *parser) callonPAGINATE_KEY1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onPAGINATE_KEY1()
+}
+
+func (c *current) onINTEGER_LIST1(i, ii interface{}) (interface{}, error) {
+	return newIntegerList(i, ii)
+}
+
+func (p *parser) callonINTEGER_LIST1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onINTEGER_LIST1(stack["i"], stack["ii"])
+}
+
 func (c *current) onFLAGS_RULE1(i, is interface{}) (interface{}, error) {
 	return newFlags(i, is)
 }
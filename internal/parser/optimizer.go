@@ -3,6 +3,7 @@ package parser
 import (
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/b2wdigital/restQL-golang/v4/internal/domain"
 	"github.com/b2wdigital/restQL-golang/v4/internal/parser/ast"
@@ -19,23 +20,158 @@ func Optimize(queryAst *ast.Query) (domain.Query, error) {
 	query := domain.Query{Statements: statements}
 
 	if queryAst.Use != nil {
-		query.Use = makeUse(queryAst)
+		use, err := makeUse(queryAst)
+		if err != nil {
+			return domain.Query{}, err
+		}
+		query.Use = use
+	}
+
+	if queryAst.Params != nil {
+		params, err := makeParamDeclarations(queryAst)
+		if err != nil {
+			return domain.Query{}, err
+		}
+		query.Params = params
 	}
 
 	return query, nil
 }
 
-func makeUse(queryAst *ast.Query) map[string]interface{} {
+func makeUse(queryAst *ast.Query) (map[string]interface{}, error) {
 	result := map[string]interface{}{}
 	for _, use := range queryAst.Use {
 		key := strings.Trim(use.Key, " ")
-		if use.Value.String != nil {
+		switch {
+		case use.Value.String != nil:
 			result[key] = *use.Value.String
-		} else {
+		case use.Value.Boolean != nil:
+			result[key] = *use.Value.Boolean
+		default:
 			result[key] = *use.Value.Int
 		}
 	}
-	return result
+
+	if onTimeout, found := result[ast.OnTimeoutKeyword]; found {
+		if onTimeout != domain.OnTimeoutFail && onTimeout != domain.OnTimeoutPartial {
+			return nil, errors.Errorf("on-timeout modifier must be either %s or %s, got %v", domain.OnTimeoutFail, domain.OnTimeoutPartial, onTimeout)
+		}
+	}
+
+	return result, nil
+}
+
+func makeParamDeclarations(queryAst *ast.Query) ([]domain.ParamDeclaration, error) {
+	result := make([]domain.ParamDeclaration, len(queryAst.Params))
+	declared := make(map[string]bool, len(queryAst.Params))
+	for i, param := range queryAst.Params {
+		if declared[param.Name] {
+			return nil, errors.Errorf("param $%s is declared more than once", param.Name)
+		}
+		declared[param.Name] = true
+
+		pd := domain.ParamDeclaration{
+			Name:     param.Name,
+			Type:     param.Type.Name,
+			List:     param.Type.List,
+			Required: param.Required,
+		}
+
+		if param.Default != nil {
+			d, err := makeParamDefault(pd, getValue(*param.Default))
+			if err != nil {
+				return nil, errors.Wrapf(err, "param $%s has an invalid default value", param.Name)
+			}
+			pd.Default = d
+		}
+
+		result[i] = pd
+	}
+	return result, nil
+}
+
+// makeParamDefault checks the default value against the declared
+// type, so a query is not accepted only to fail on every request.
+func makeParamDefault(param domain.ParamDeclaration, value interface{}) (interface{}, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	if hasReference(value) {
+		return nil, errors.New("must be a literal, not a variable or chained value")
+	}
+
+	if !param.List {
+		return makeParamDefaultValue(param.Type, value)
+	}
+
+	list, ok := value.([]interface{})
+	if !ok {
+		return nil, errors.Errorf("must be a list of %s", param.Type)
+	}
+
+	result := make([]interface{}, len(list))
+	for i, item := range list {
+		v, err := makeParamDefaultValue(param.Type, item)
+		if err != nil {
+			return nil, errors.Errorf("must be a list of %s", param.Type)
+		}
+
+		result[i] = v
+	}
+
+	return result, nil
+}
+
+func makeParamDefaultValue(paramType string, value interface{}) (interface{}, error) {
+	ok := false
+
+	switch paramType {
+	case domain.StringParamType:
+		_, ok = value.(string)
+	case domain.IntParamType:
+		_, ok = value.(int)
+	case domain.FloatParamType:
+		switch v := value.(type) {
+		case float64:
+			ok = true
+		case int:
+			return float64(v), nil
+		}
+	case domain.BooleanParamType:
+		_, ok = value.(bool)
+	case domain.ObjectParamType:
+		_, ok = value.(map[string]interface{})
+	default:
+		return nil, errors.Errorf("has an unknown type %s", paramType)
+	}
+
+	if !ok {
+		return nil, errors.Errorf("must be of type %s", paramType)
+	}
+
+	return value, nil
+}
+
+func hasReference(value interface{}) bool {
+	switch value := value.(type) {
+	case domain.Variable, domain.Chain:
+		return true
+	case []interface{}:
+		for _, v := range value {
+			if hasReference(v) {
+				return true
+			}
+		}
+	case map[string]interface{}:
+		for _, v := range value {
+			if hasReference(v) {
+				return true
+			}
+		}
+	}
+
+	return false
 }
 
 func mapToStatements(fromBlocks []ast.Block) ([]domain.Statement, error) {
@@ -44,7 +180,7 @@ func mapToStatements(fromBlocks []ast.Block) ([]domain.Statement, error) {
 	for i, block := range fromBlocks {
 		statement, err := makeStatement(block)
 		if err != nil {
-			return nil, nil
+			return nil, err
 		}
 
 		result[i] = statement
@@ -60,9 +196,22 @@ func makeStatement(block ast.Block) (domain.Statement, error) {
 		Alias:    block.Alias,
 		In:       block.In,
 	}
+
+	if block.Join != nil {
+		if len(block.In) < 2 {
+			return domain.Statement{}, errors.Errorf("on clause requires a field of the %s statement as the in target", strings.Join(block.In, "."))
+		}
+		s.Join = &domain.Join{Target: block.Join.Target, Origin: block.Join.Origin, Many: block.Join.Many}
+	}
+
 	for _, qualifier := range block.Qualifiers {
 		if qualifier.With != nil {
-			s.With = makeParams(qualifier)
+			params, err := makeParams(qualifier)
+			if err != nil {
+				return domain.Statement{}, err
+			}
+
+			s.With = params
 		}
 
 		if qualifier.Only != nil {
@@ -92,6 +241,18 @@ func makeStatement(block ast.Block) (domain.Statement, error) {
 			s.CacheControl.SMaxAge = value
 		}
 
+		if qualifier.Retry != nil {
+			s.Retry = makeRetry(qualifier)
+		}
+
+		if qualifier.Paginate != nil {
+			s.Paginate = makePagination(qualifier)
+		}
+
+		if qualifier.When != nil {
+			s.When = makeCondition(qualifier)
+		}
+
 		s.Hidden = qualifier.Hidden || s.Hidden
 		s.IgnoreErrors = qualifier.IgnoreErrors || s.IgnoreErrors
 	}
@@ -99,13 +260,23 @@ func makeStatement(block ast.Block) (domain.Statement, error) {
 	return s, nil
 }
 
-func makeParams(wq ast.Qualifier) domain.Params {
+func makeParams(wq ast.Qualifier) (domain.Params, error) {
 	values := make(map[string]interface{})
+	batched := ""
 	for _, item := range wq.With.KeyValues {
 		v := getValue(item.Value)
 
 		v = applyFunctions(v, item.Functions)
 
+		if item.Batch != nil {
+			if batched != "" {
+				return domain.Params{}, errors.Errorf("only one parameter per statement can be batched, found %s and %s", batched, item.Key)
+			}
+			batched = item.Key
+
+			v = makeBatch(v, item.Batch)
+		}
+
 		values[item.Key] = v
 	}
 
@@ -115,7 +286,7 @@ func makeParams(wq ast.Qualifier) domain.Params {
 
 	parameterBody := wq.With.Body
 	if parameterBody == nil {
-		return p
+		return p, nil
 	}
 
 	var body interface{}
@@ -125,7 +296,7 @@ func makeParams(wq ast.Qualifier) domain.Params {
 
 	p.Body = body
 
-	return p
+	return p, nil
 }
 
 func applyFunctions(v interface{}, functions []string) interface{} {
@@ -141,31 +312,72 @@ func applyFunctions(v interface{}, functions []string) interface{} {
 			v = domain.JSON{Value: v}
 		case ast.Flatten:
 			v = domain.Flatten{Value: v}
+		default:
+			v = domain.CustomFunction{Name: fn, Value: v}
 		}
 	}
 
 	return v
 }
 
+func makeBatch(v interface{}, batch *ast.Batch) domain.Batch {
+	b := domain.Batch{Value: v, Size: batch.Size}
+	if batch.Key != nil {
+		b.Key = *batch.Key
+	}
+
+	return b
+}
+
 func makeOnlyFilter(onlyQualifier ast.Qualifier) ([]interface{}, error) {
 	filters := onlyQualifier.Only
 
 	result := make([]interface{}, len(filters))
 	for i, f := range filters {
-		if f.Match != nil {
-			match, err := makeMatchFunction(f)
-			if err != nil {
-				return nil, err
-			}
-			result[i] = match
-		} else {
-			result[i] = f.Field
+		filter, err := makeFilter(f)
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case f.Alias != nil:
+			result[i] = domain.Alias{Value: filter, Name: *f.Alias}
+		case f.Expression != nil:
+			result[i] = domain.Alias{Value: filter, Name: f.Expression.Function}
+		default:
+			result[i] = filter
 		}
 	}
 
 	return result, nil
 }
 
+func makeFilter(f ast.Filter) (interface{}, error) {
+	switch {
+	case f.Match != nil:
+		return makeMatchFunction(f)
+	case f.Predicate != nil:
+		return makePredicateFunction(f)
+	case f.Expression != nil:
+		return makeExpression(f), nil
+	default:
+		return f.Field, nil
+	}
+}
+
+func makeExpression(f ast.Filter) domain.Expression {
+	args := make([]interface{}, len(f.Expression.Arguments))
+	for i, arg := range f.Expression.Arguments {
+		if arg.Field != nil {
+			args[i] = arg.Field
+		} else {
+			args[i] = getPrimitive(arg.Value)
+		}
+	}
+
+	return domain.Expression{Function: f.Expression.Function, Args: args}
+}
+
 func makeMatchFunction(f ast.Filter) (domain.Match, error) {
 	if f.Match.String != nil {
 		arg := *f.Match.String
@@ -183,6 +395,20 @@ func makeMatchFunction(f ast.Filter) (domain.Match, error) {
 	return domain.Match{}, errors.New("no argument provided to matches functions")
 }
 
+func makePredicateFunction(f ast.Filter) (domain.Predicate, error) {
+	arg := getValue(f.Predicate.Value)
+
+	if pattern, ok := arg.(string); ok && f.Predicate.Operator == ast.NotMatchesPredicate {
+		regex, err := regexp.Compile(pattern)
+		if err != nil {
+			return domain.Predicate{}, errors.Wrap(err, "not-matches function regex argument is invalid")
+		}
+		arg = regex
+	}
+
+	return domain.Predicate{Value: f.Field, Operator: f.Predicate.Operator, Arg: arg}, nil
+}
+
 func makeHeaders(qualifier ast.Qualifier) map[string]interface{} {
 	result := map[string]interface{}{}
 
@@ -206,6 +432,72 @@ func makeHeaders(qualifier ast.Qualifier) map[string]interface{} {
 	return result
 }
 
+func makeRetry(qualifier ast.Qualifier) *domain.RetryPolicy {
+	retry := qualifier.Retry
+	policy := domain.RetryPolicy{Attempts: 1, StatusCodes: retry.Status}
+
+	if retry.Attempts != nil {
+		policy.Attempts = *retry.Attempts
+	}
+
+	if retry.Backoff != nil {
+		policy.Backoff = time.Millisecond * time.Duration(*retry.Backoff)
+	}
+
+	if retry.Jitter != nil {
+		policy.Jitter = time.Millisecond * time.Duration(*retry.Jitter)
+	}
+
+	return &policy
+}
+
+func makePagination(qualifier ast.Qualifier) *domain.Pagination {
+	paginate := qualifier.Paginate
+	pagination := domain.Pagination{MaxPages: domain.DefaultMaxPages}
+
+	if paginate.Next != nil {
+		pagination.Next = *paginate.Next
+	}
+
+	if paginate.Link != nil {
+		pagination.Link = *paginate.Link
+	}
+
+	if paginate.Page != nil {
+		pagination.Page = *paginate.Page
+	}
+
+	if paginate.Offset != nil {
+		pagination.Offset = *paginate.Offset
+	}
+
+	if paginate.Items != nil {
+		pagination.Items = *paginate.Items
+	}
+
+	if paginate.Max != nil {
+		pagination.MaxPages = *paginate.Max
+	}
+
+	return &pagination
+}
+
+func makeCondition(qualifier ast.Qualifier) *domain.Condition {
+	c := qualifier.When
+
+	condition := domain.Condition{
+		Negated:  c.Negated,
+		Left:     getValue(c.Left),
+		Operator: c.Operator,
+	}
+
+	if c.Right != nil {
+		condition.Right = getValue(*c.Right)
+	}
+
+	return &condition
+}
+
 func makeTimeout(qualifier ast.Qualifier) interface{} {
 	v := qualifier.Timeout
 	if v.Int != nil {
@@ -3,6 +3,7 @@ package parser
 import (
 	"github.com/b2wdigital/restQL-golang/v4/internal/domain"
 	"github.com/b2wdigital/restQL-golang/v4/internal/parser/ast"
+	"github.com/pkg/errors"
 )
 
 // Parser is the interface implemented by types that
@@ -13,16 +14,23 @@ type Parser interface {
 
 type parser struct {
 	astGenerator ast.Generator
+	functions    map[string]bool
 }
 
-// New returns an instance of a Parser.
-func New() (Parser, error) {
+// New returns an instance of a Parser. Besides the built-in
+// functions, queries can only use the given custom functions.
+func New(functions ...string) (Parser, error) {
 	generator, err := ast.New()
 	if err != nil {
 		return parser{}, err
 	}
 
-	return parser{astGenerator: generator}, nil
+	fns := make(map[string]bool, len(functions))
+	for _, fn := range functions {
+		fns[fn] = true
+	}
+
+	return parser{astGenerator: generator, functions: fns}, nil
 }
 
 func (p parser) Parse(queryStr string) (domain.Query, error) {
@@ -31,5 +39,50 @@ func (p parser) Parse(queryStr string) (domain.Query, error) {
 		return domain.Query{}, err
 	}
 
-	return Optimize(query)
+	optimized, err := Optimize(query)
+	if err != nil {
+		return domain.Query{}, err
+	}
+
+	for _, stmt := range optimized.Statements {
+		err := p.validateFunctions(stmt.With.Body)
+		if err != nil {
+			return domain.Query{}, err
+		}
+
+		for _, value := range stmt.With.Values {
+			err := p.validateFunctions(value)
+			if err != nil {
+				return domain.Query{}, err
+			}
+		}
+	}
+
+	return optimized, nil
+}
+
+func (p parser) validateFunctions(value interface{}) error {
+	switch value := value.(type) {
+	case domain.CustomFunction:
+		if !p.functions[value.Name] {
+			return errors.Errorf("unknown function : %s", value.Name)
+		}
+		return p.validateFunctions(value.Target())
+	case domain.Function:
+		return p.validateFunctions(value.Target())
+	case map[string]interface{}:
+		for _, v := range value {
+			if err := p.validateFunctions(v); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		for _, v := range value {
+			if err := p.validateFunctions(v); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
 }
@@ -3,6 +3,7 @@ package parser_test
 import (
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/b2wdigital/restQL-golang/v4/internal/domain"
 	"github.com/b2wdigital/restQL-golang/v4/internal/parser"
@@ -21,6 +22,18 @@ func TestQueryParser(t *testing.T) {
 			domain.Query{Statements: []domain.Statement{{Method: "from", Resource: "hero"}}},
 			"from hero",
 		},
+		{
+			"Query with execution modifiers",
+			domain.Query{
+				Use:        map[string]interface{}{"timeout": 500, "on-timeout": "partial", "fail-fast": true, "max-concurrency": 16},
+				Statements: []domain.Statement{{Method: "from", Resource: "hero"}},
+			},
+			`use timeout = 500
+			use on-timeout = "partial"
+			use fail-fast = true
+			use max-concurrency 16
+			from hero`,
+		},
 		{
 			"Multiple from statement",
 			domain.Query{Statements: []domain.Statement{{Method: "from", Resource: "hero"}, {Method: "from", Resource: "sidekick"}}},
@@ -126,6 +139,11 @@ func TestQueryParser(t *testing.T) {
 			domain.Query{Statements: []domain.Statement{{Method: "from", Resource: "hero", With: domain.Params{Values: map[string]interface{}{"id": domain.NoMultiplex{domain.JSON{[]interface{}{1, 2}}}}}}}},
 			"from hero with id = [1, 2] -> json -> no-multiplex",
 		},
+		{
+			"Unique from statement and batched list parameters",
+			domain.Query{Statements: []domain.Statement{{Method: "from", Resource: "hero", With: domain.Params{Values: map[string]interface{}{"id": domain.Batch{Value: []interface{}{1, 2, 3}, Size: 2, Key: "id"}}}}}},
+			`from hero with id = [1, 2, 3] -> batch(2, "id")`,
+		},
 		{
 			"Unique from statement and object parameter encoded as json",
 			domain.Query{Statements: []domain.Statement{{Method: "from", Resource: "hero", With: domain.Params{Values: map[string]interface{}{"id": domain.JSON{map[string]interface{}{"internal": 1}}}}}}},
@@ -166,6 +184,36 @@ func TestQueryParser(t *testing.T) {
 			domain.Query{Statements: []domain.Statement{{Method: "from", Resource: "hero", Only: []interface{}{domain.Match{Value: []string{"name"}, Arg: domain.Variable{Target: "heroName"}}, []string{"weapons"}}}}},
 			`from hero only name -> matches($heroName), weapons`,
 		},
+		{
+			"Unique from statement and only filters with predicate functions",
+			domain.Query{Statements: []domain.Statement{{Method: "from", Resource: "hero", Only: []interface{}{
+				domain.Predicate{Value: []string{"name"}, Operator: domain.NotMatchesOperator, Arg: regexp.MustCompile("^Super")},
+				domain.Predicate{Value: []string{"age"}, Operator: domain.GreaterThanOperator, Arg: 18},
+				domain.Predicate{Value: []string{"id"}, Operator: domain.InOperator, Arg: []interface{}{"a", domain.Variable{Target: "id"}}},
+				domain.Predicate{Value: []string{"weapons"}, Operator: domain.ContainsOperator, Arg: domain.Variable{Target: "weapon"}},
+			}}}},
+			`from hero only name -> not-matches("^Super"), age -> gt(18), id -> in(["a", $id]), weapons -> contains($weapon)`,
+		},
+		{
+			"Unique from statement and only filters with renamed and computed fields",
+			domain.Query{Statements: []domain.Statement{{Method: "from", Resource: "hero", Only: []interface{}{
+				domain.Alias{Value: []string{"name"}, Name: "heroName"},
+				domain.Alias{Value: domain.Expression{Function: domain.CountExpression, Args: []interface{}{[]string{"items"}}}, Name: "count"},
+				domain.Alias{Value: domain.Expression{Function: domain.ConcatExpression, Args: []interface{}{[]string{"first"}, " ", []string{"last"}}}, Name: "fullName"},
+			}}}},
+			`from hero only name as heroName, count(items), concat(first, " ", last) as fullName`,
+		},
+		{
+			"Unique from statement with aggregation joined by field",
+			domain.Query{Statements: []domain.Statement{
+				{Method: "from", Resource: "hero"},
+				{Method: "from", Resource: "weapon", In: []string{"hero", "weapons"}, Join: &domain.Join{Target: []string{"weaponId"}, Origin: []string{"id"}}},
+			}},
+			`
+					from hero
+					from weapon in hero.weapons on weaponId = id
+			`,
+		},
 		{
 			"Unique from statement with aggregation",
 			domain.Query{Statements: []domain.Statement{
@@ -177,6 +225,54 @@ func TestQueryParser(t *testing.T) {
 					from sidekick in hero.sidekick
 			`,
 		},
+		{
+			"Unique from statement with when clause",
+			domain.Query{Statements: []domain.Statement{{Method: "from", Resource: "hero", When: &domain.Condition{Left: domain.Variable{Target: "includeHero"}}}}},
+			"from hero when $includeHero",
+		},
+		{
+			"Unique from statement with when comparison clause",
+			domain.Query{Statements: []domain.Statement{{
+				Method:   "from",
+				Resource: "sidekick",
+				With:     domain.Params{Values: map[string]interface{}{"id": domain.Chain{"hero", "id"}}},
+				When:     &domain.Condition{Negated: true, Left: domain.Chain{"hero", "type"}, Operator: "!=", Right: 1},
+			}}},
+			"from sidekick with id = hero.id when !hero.type != 1",
+		},
+		{
+			"Unique from statement with params declaration",
+			domain.Query{
+				Params: []domain.ParamDeclaration{
+					{Name: "id", Type: domain.IntParamType, Required: true},
+					{Name: "tags", Type: domain.StringParamType, List: true, Default: []interface{}{"dc"}},
+				},
+				Statements: []domain.Statement{{Method: "from", Resource: "hero", With: domain.Params{Values: map[string]interface{}{"id": domain.Variable{Target: "id"}}}}},
+			},
+			`
+					params $id: int!, $tags: [string] = ["dc"]
+
+					from hero with id = $id
+			`,
+		},
+		{
+			"Unique from statement with retry",
+			domain.Query{Statements: []domain.Statement{{
+				Method:   "from",
+				Resource: "hero",
+				Retry:    &domain.RetryPolicy{Attempts: 3, Backoff: 100 * time.Millisecond, StatusCodes: []int{503}},
+			}}},
+			"from hero retry attempts = 3, backoff = 100, status = [503]",
+		},
+		{
+			"Unique from statement with paginate",
+			domain.Query{Statements: []domain.Statement{{
+				Method:   "from",
+				Resource: "hero",
+				Paginate: &domain.Pagination{Page: "page", Items: "results", MaxPages: domain.DefaultMaxPages},
+			}}},
+			`from hero paginate page = "page", items = "results"`,
+		},
 		{
 			"Full query",
 			domain.Query{
@@ -262,6 +358,97 @@ func TestQueryParser(t *testing.T) {
 	}
 }
 
+func TestQueryParserCustomFunctions(t *testing.T) {
+	queryParser, err := parser.New("sha256", "lowercase")
+	test.VerifyError(t, err)
+
+	got, err := queryParser.Parse(`from hero with name = $name -> lowercase -> sha256 -> base64, ids = [$id] -> lowercase`)
+	test.VerifyError(t, err)
+
+	expected := domain.Query{Statements: []domain.Statement{{
+		Method:   "from",
+		Resource: "hero",
+		With: domain.Params{Values: map[string]interface{}{
+			"name": domain.Base64{Value: domain.CustomFunction{Name: "sha256", Value: domain.CustomFunction{Name: "lowercase", Value: domain.Variable{Target: "name"}}}},
+			"ids":  domain.CustomFunction{Name: "lowercase", Value: []interface{}{domain.Variable{Target: "id"}}},
+		}},
+	}}}
+	test.Equal(t, got, expected)
+
+	_, err = queryParser.Parse(`from hero with name = "batman" -> url-encode`)
+	if err == nil {
+		t.Fatalf("expected error for unknown function, got nil")
+	}
+}
+
+func TestQueryParserJoinOnWholeResource(t *testing.T) {
+	queryParser, err := parser.New()
+	test.VerifyError(t, err)
+
+	_, err = queryParser.Parse(`from hero
+from weapon in hero on weaponId = id`)
+	if err == nil {
+		t.Fatalf("expected error for join on whole resource, got nil")
+	}
+}
+
+func TestQueryParserOnTimeoutModifier(t *testing.T) {
+	queryParser, err := parser.New()
+	test.VerifyError(t, err)
+
+	_, err = queryParser.Parse(`use on-timeout = "ignore"
+from hero`)
+	if err == nil {
+		t.Fatalf("expected error for invalid on-timeout behaviour, got nil")
+	}
+}
+
+func TestQueryParserManyBatchedParams(t *testing.T) {
+	queryParser, err := parser.New()
+	test.VerifyError(t, err)
+
+	_, err = queryParser.Parse(`from hero with id = [1, 2, 3] -> batch(2, "id"), name = ["a", "b"] -> batch(1)`)
+	if err == nil {
+		t.Fatalf("expected error for many batched params, got nil")
+	}
+}
+
+func TestQueryParserParamDeclarations(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+	}{
+		{"duplicated declaration", "params $id: int, $id: string\nfrom hero"},
+		{"default of another type", `params $page: int = "x"` + "\nfrom hero"},
+		{"default of another list type", `params $tags: [string] = [1]` + "\nfrom hero"},
+		{"list default on single param", `params $tags: string = ["dc"]` + "\nfrom hero"},
+		{"variable default", "params $page: int = $size\nfrom hero"},
+		{"chained default", "params $page: int = done.page\nfrom hero"},
+		{"variable inside default", "params $tags: [string] = [$tag]\nfrom hero"},
+	}
+
+	queryParser, err := parser.New()
+	test.VerifyError(t, err)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := queryParser.Parse(tt.query)
+			if err == nil {
+				t.Fatalf("expected error for invalid params declaration, got nil")
+			}
+		})
+	}
+
+	got, err := queryParser.Parse("params $ratio: float = 1, $filter: object = {active: true}\nfrom hero")
+	test.VerifyError(t, err)
+
+	expected := []domain.ParamDeclaration{
+		{Name: "ratio", Type: domain.FloatParamType, Default: float64(1)},
+		{Name: "filter", Type: domain.ObjectParamType, Default: map[string]interface{}{"active": true}},
+	}
+	test.Equal(t, got.Params, expected)
+}
+
 func BenchmarkParse(b *testing.B) {
 	query := `
 from hero as h
@@ -2,9 +2,11 @@ package cache
 
 import (
 	"context"
-	"github.com/b2wdigital/restQL-golang/v4/pkg/restql"
 	"time"
 
+	"github.com/b2wdigital/restQL-golang/v4/internal/platform/metrics"
+	"github.com/b2wdigital/restQL-golang/v4/pkg/restql"
+
 	"github.com/bluele/gcache"
 	"github.com/pkg/errors"
 )
@@ -42,6 +44,14 @@ func WithRefreshQueueLength(length int) Option {
 	}
 }
 
+// WithName sets the name used to report
+// the cache hits and misses in the metrics.
+func WithName(name string) Option {
+	return func(c *Cache) {
+		c.name = name
+	}
+}
+
 // WithExpiration sets the time to live of cache entries.
 func WithExpiration(expiration time.Duration) Option {
 	return func(c *Cache) {
@@ -55,6 +65,7 @@ func WithExpiration(expiration time.Duration) Option {
 // its due the entry is refresh with a background
 // routine, never deleting the old value, only replacing it.
 type Cache struct {
+	name               string
 	log                restql.Logger
 	gcache             gcache.Cache
 	loader             Loader
@@ -92,6 +103,8 @@ func (c *Cache) Get(ctx context.Context, key interface{}) (interface{}, error) {
 
 	switch {
 	case err == gcache.KeyNotFoundError:
+		c.countLookup(metrics.CacheMiss)
+
 		item, err := c.populate(ctx, key)
 		if err != nil {
 			return nil, err
@@ -110,6 +123,8 @@ func (c *Cache) Get(ctx context.Context, key interface{}) (interface{}, error) {
 		return nil, err
 	}
 
+	c.countLookup(metrics.CacheHit)
+
 	if item.Expired() {
 		go func() {
 			c.refreshWorkCh <- item.key
@@ -119,6 +134,47 @@ func (c *Cache) Get(ctx context.Context, key interface{}) (interface{}, error) {
 	return item.value, nil
 }
 
+// GetIfPresent retrieves the entry for the given key
+// without calling the loader when it is missing.
+func (c *Cache) GetIfPresent(key interface{}) (interface{}, bool) {
+	obj, err := c.gcache.Get(key)
+	if err != nil {
+		return nil, false
+	}
+
+	item, ok := obj.(cacheItem)
+	if !ok {
+		return nil, false
+	}
+
+	return item.value, true
+}
+
+// Set stores the value for the given key, replacing the previous one.
+func (c *Cache) Set(key interface{}, value interface{}) error {
+	item := cacheItem{
+		key:   key,
+		value: value,
+	}
+	if c.expiration > 0 {
+		item.expiration = time.Now().Add(c.expiration)
+	}
+
+	err := c.gcache.Set(key, item)
+	if err != nil {
+		c.log.Error("failed to set value on cache", err)
+		return err
+	}
+
+	return nil
+}
+
+func (c *Cache) countLookup(result string) {
+	if c.name != "" {
+		metrics.CacheRequests.Inc(c.name, result)
+	}
+}
+
 func (c *Cache) populate(ctx context.Context, key interface{}) (cacheItem, error) {
 	value, err := c.loader(ctx, key)
 	if err != nil {
@@ -1,6 +1,8 @@
 package conf
 
 import (
+	"fmt"
+	"github.com/b2wdigital/restQL-golang/v4/internal/domain"
 	"github.com/caarlos0/env/v6"
 	"gopkg.in/yaml.v2"
 	"io/ioutil"
@@ -29,12 +31,61 @@ type corsConf struct {
 	ExposeHeaders string `yaml:"exposeHeaders" env:"RESTQL_CORS_EXPOSE_HEADERS"`
 }
 
+// MiddlewarePluginConf represents the position of a middleware
+// plugin in the chain and the servers it is applied to.
+type MiddlewarePluginConf struct {
+	Position string   `yaml:"position"`
+	Apps     []string `yaml:"apps"`
+}
+
+type retryConf struct {
+	Attempts    int           `yaml:"attempts"`
+	Backoff     time.Duration `yaml:"backoff"`
+	Jitter      time.Duration `yaml:"jitter"`
+	StatusCodes []int         `yaml:"statusCodes"`
+}
+
+// CircuitBreakerConf represents the circuit breaker parameters,
+// used both as the global default and as a mapping policy.
+type CircuitBreakerConf struct {
+	Enabled              *bool         `yaml:"enabled"`
+	FailureRateThreshold float64       `yaml:"failureRateThreshold"`
+	MinimumRequests      int           `yaml:"minimumRequests"`
+	Window               time.Duration `yaml:"window"`
+	OpenInterval         time.Duration `yaml:"openInterval"`
+	HalfOpenProbes       int           `yaml:"halfOpenProbes"`
+}
+
+type coalesceConf struct {
+	Enabled bool     `yaml:"enabled"`
+	Headers []string `yaml:"headers"`
+}
+
+type responseCacheConf struct {
+	Enabled bool `yaml:"enabled"`
+	MaxSize int  `yaml:"maxSize"`
+}
+
+type mappingPolicyConf struct {
+	Retry          *retryConf          `yaml:"retry"`
+	CircuitBreaker *CircuitBreakerConf `yaml:"circuitBreaker"`
+	Coalesce       *coalesceConf       `yaml:"coalesce"`
+	ResponseCache  *responseCacheConf  `yaml:"responseCache"`
+}
+
 // Config represents all parameters allowed in restQL runtime.
 type Config struct {
 	HTTP struct {
 		ForwardPrefix        string        `yaml:"forwardPrefix" env:"RESTQL_FORWARD_PREFIX"`
 		GlobalQueryTimeout   time.Duration `env:"RESTQL_QUERY_GLOBAL_TIMEOUT" envDefault:"30s"`
 		QueryResourceTimeout time.Duration `env:"RESTQL_QUERY_RESOURCE_TIMEOUT" envDefault:"5s"`
+		OnQueryTimeout       string        `yaml:"onQueryTimeout" env:"RESTQL_QUERY_ON_TIMEOUT"`
+
+		Concurrency struct {
+			Workers   int `yaml:"workers" env:"RESTQL_CONCURRENCY_WORKERS"`
+			Query     int `yaml:"query" env:"RESTQL_CONCURRENCY_QUERY"`
+			Statement int `yaml:"statement" env:"RESTQL_CONCURRENCY_STATEMENT"`
+		} `yaml:"concurrency"`
 
 		Server struct {
 			APIAddr                 string        `env:"RESTQL_PORT,required"`
@@ -49,6 +100,8 @@ type Config struct {
 				RequestID *requestIDConf `yaml:"requestId"`
 				Timeout   *timeoutConf   `yaml:"timeout"`
 				Cors      *corsConf      `yaml:"cors"`
+
+				Plugins map[string]MiddlewarePluginConf `yaml:"plugins"`
 			} `yaml:"middlewares"`
 		} `yaml:"server"`
 
@@ -59,6 +112,8 @@ type Config struct {
 			MaxIdleConns        int           `yaml:"maxIdleConnections"`
 			MaxIdleConnsPerHost int           `yaml:"maxIdleConnectionsPerHost"`
 			MaxIdleConnDuration time.Duration `yaml:"maxIdleConnectionDuration"`
+
+			CircuitBreaker CircuitBreakerConf `yaml:"circuitBreaker"`
 		} `yaml:"client"`
 	} `yaml:"http"`
 
@@ -83,8 +138,23 @@ type Config struct {
 		Parser struct {
 			MaxSize int `yaml:"maxSize" env:"RESTQL_CACHE_PARSER_MAX_SIZE"`
 		} `yaml:"parser"`
+		Result struct {
+			MaxSize     int      `yaml:"maxSize" env:"RESTQL_CACHE_RESULT_MAX_SIZE"`
+			VaryHeaders []string `yaml:"varyHeaders"`
+		} `yaml:"result"`
 	} `yaml:"cache"`
 
+	Tracing struct {
+		Enable        bool          `yaml:"enable" env:"RESTQL_TRACING_ENABLE"`
+		ServiceName   string        `yaml:"serviceName" env:"RESTQL_TRACING_SERVICE_NAME"`
+		Exporter      string        `yaml:"exporter" env:"RESTQL_TRACING_EXPORTER"`
+		Endpoint      string        `yaml:"endpoint" env:"RESTQL_TRACING_ENDPOINT"`
+		File          string        `yaml:"file" env:"RESTQL_TRACING_FILE"`
+		ExportTimeout time.Duration `yaml:"exportTimeout"`
+		BatchSize     int           `yaml:"batchSize"`
+		FlushInterval time.Duration `yaml:"flushInterval"`
+	} `yaml:"tracing"`
+
 	Plugins struct {
 		Location string `yaml:"location" env:"RESTQL_PLUGINS_LOCATION"`
 	} `yaml:"plugins"`
@@ -93,6 +163,8 @@ type Config struct {
 
 	Mappings map[string]string `yaml:"mappings"`
 
+	MappingPolicies map[string]mappingPolicyConf `yaml:"mappingPolicies"`
+
 	Queries map[string]map[string][]string `yaml:"queries"`
 
 	Env EnvSource
@@ -117,6 +189,11 @@ func Load(build string) (*Config, error) {
 		return nil, err
 	}
 
+	onTimeout := cfg.HTTP.OnQueryTimeout
+	if onTimeout != "" && onTimeout != domain.OnTimeoutFail && onTimeout != domain.OnTimeoutPartial {
+		return nil, fmt.Errorf("invalid query on timeout behaviour %q: must be either %s or %s", onTimeout, domain.OnTimeoutFail, domain.OnTimeoutPartial)
+	}
+
 	cfg.Build = build
 	cfg.Env = EnvSource{}
 
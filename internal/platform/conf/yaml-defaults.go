@@ -31,6 +31,15 @@ cache:
   parser:
     maxSize: 100
 
+tracing:
+  enable: false
+  serviceName: restql
+  exporter: otlp
+  endpoint: http://localhost:4318/v1/traces
+  exportTimeout: 10s
+  batchSize: 512
+  flushInterval: 5s
+
 database:
   timeout: 1000
 `)
@@ -0,0 +1,22 @@
+// Package detached provides contexts that outlive their parents.
+package detached
+
+import (
+	"context"
+	"time"
+)
+
+// Context returns a context that keeps the values of the parent,
+// like the logger, but not its deadline and cancellation.
+func Context(parent context.Context) context.Context {
+	return detachedContext{parent: parent}
+}
+
+type detachedContext struct {
+	parent context.Context
+}
+
+func (dc detachedContext) Deadline() (time.Time, bool)       { return time.Time{}, false }
+func (dc detachedContext) Done() <-chan struct{}             { return nil }
+func (dc detachedContext) Err() error                        { return nil }
+func (dc detachedContext) Value(key interface{}) interface{} { return dc.parent.Value(key) }
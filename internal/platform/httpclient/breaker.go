@@ -0,0 +1,343 @@
+package httpclient
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/b2wdigital/restQL-golang/v4/internal/domain"
+	"github.com/b2wdigital/restQL-golang/v4/internal/platform/conf"
+	"github.com/b2wdigital/restQL-golang/v4/pkg/restql"
+	"github.com/pkg/errors"
+)
+
+// Circuit breaker states.
+const (
+	CircuitClosed   = "closed"
+	CircuitOpen     = "open"
+	CircuitHalfOpen = "half-open"
+)
+
+const (
+	defaultFailureRateThreshold = 50
+	defaultMinimumRequests      = 10
+	defaultBreakerWindow        = 10 * time.Second
+	defaultOpenInterval         = 5 * time.Second
+	defaultHalfOpenProbes       = 1
+)
+
+// CircuitBreakerStatus describes the current state of the
+// circuit breaker of a resource in a given host.
+type CircuitBreakerStatus struct {
+	Resource string
+	Host     string
+	State    string
+	Requests int
+	Failures int
+	OpenedAt time.Time
+}
+
+type breakerOptions struct {
+	enabled              bool
+	failureRateThreshold float64
+	minimumRequests      int
+	window               time.Duration
+	openInterval         time.Duration
+	halfOpenProbes       int
+}
+
+func makeBreakerOptions(cfg *conf.Config) (breakerOptions, map[string]breakerOptions) {
+	global := mergeBreakerOptions(breakerOptions{
+		failureRateThreshold: defaultFailureRateThreshold,
+		minimumRequests:      defaultMinimumRequests,
+		window:               defaultBreakerWindow,
+		openInterval:         defaultOpenInterval,
+		halfOpenProbes:       defaultHalfOpenProbes,
+	}, cfg.HTTP.Client.CircuitBreaker)
+
+	mappings := make(map[string]breakerOptions)
+	for mapping, policy := range cfg.MappingPolicies {
+		if policy.CircuitBreaker == nil {
+			continue
+		}
+
+		mappings[mapping] = mergeBreakerOptions(global, *policy.CircuitBreaker)
+	}
+
+	return global, mappings
+}
+
+func mergeBreakerOptions(base breakerOptions, c conf.CircuitBreakerConf) breakerOptions {
+	if c.Enabled != nil {
+		base.enabled = *c.Enabled
+	}
+
+	if c.FailureRateThreshold > 0 {
+		base.failureRateThreshold = c.FailureRateThreshold
+	}
+
+	if c.MinimumRequests > 0 {
+		base.minimumRequests = c.MinimumRequests
+	}
+
+	if c.Window > 0 {
+		base.window = c.Window
+	}
+
+	if c.OpenInterval > 0 {
+		base.openInterval = c.OpenInterval
+	}
+
+	if c.HalfOpenProbes > 0 {
+		base.halfOpenProbes = c.HalfOpenProbes
+	}
+
+	return base
+}
+
+// circuitBreakerClient wraps a HTTPClient failing fast
+// the calls to resources which circuit breaker is open.
+type circuitBreakerClient struct {
+	client   domain.HTTPClient
+	registry *CircuitBreakers
+	global   breakerOptions
+	mappings map[string]breakerOptions
+}
+
+func newCircuitBreakerClient(client domain.HTTPClient, registry *CircuitBreakers, cfg *conf.Config) domain.HTTPClient {
+	global, mappings := makeBreakerOptions(cfg)
+
+	return &circuitBreakerClient{
+		client:   client,
+		registry: registry,
+		global:   global,
+		mappings: mappings,
+	}
+}
+
+func (cc *circuitBreakerClient) Do(ctx context.Context, request domain.HTTPRequest) (domain.HTTPResponse, error) {
+	options, found := cc.mappings[request.Resource]
+	if !found {
+		options = cc.global
+	}
+
+	if !options.enabled {
+		return cc.client.Do(ctx, request)
+	}
+
+	cb := cc.registry.get(breakerKey{resource: request.Resource, host: request.Host}, options)
+	token, allowed := cb.allow()
+	if !allowed {
+		log := restql.GetLogger(ctx)
+		log.Debug("request not executed due to open circuit breaker", "resource", request.Resource, "target", request.Host)
+
+		response := domain.HTTPResponse{URL: makeURL(request).String(), StatusCode: http.StatusServiceUnavailable}
+		return response, domain.ErrCircuitOpen
+	}
+
+	response, err := cc.client.Do(ctx, request)
+	switch {
+	case errors.Is(err, context.Canceled):
+		cb.release(token)
+	case err != nil || response.StatusCode >= http.StatusInternalServerError:
+		cb.record(token, false)
+	default:
+		cb.record(token, true)
+	}
+
+	return response, err
+}
+
+type breakerKey struct {
+	resource string
+	host     string
+}
+
+// CircuitBreakers holds the circuit breakers created
+// by a HTTP client, one for each resource and host.
+type CircuitBreakers struct {
+	mu       sync.Mutex
+	breakers map[breakerKey]*circuitBreaker
+}
+
+func newCircuitBreakers() *CircuitBreakers {
+	return &CircuitBreakers{breakers: make(map[breakerKey]*circuitBreaker)}
+}
+
+func (br *CircuitBreakers) get(key breakerKey, options breakerOptions) *circuitBreaker {
+	br.mu.Lock()
+	defer br.mu.Unlock()
+
+	cb, found := br.breakers[key]
+	if !found {
+		cb = &circuitBreaker{options: options, state: CircuitClosed, windowStart: time.Now()}
+		br.breakers[key] = cb
+	}
+
+	return cb
+}
+
+// Statuses returns the status of every circuit
+// breaker, sorted by resource and host.
+func (br *CircuitBreakers) Statuses() []CircuitBreakerStatus {
+	br.mu.Lock()
+	result := make([]CircuitBreakerStatus, 0, len(br.breakers))
+	for key, cb := range br.breakers {
+		status := cb.status()
+		status.Resource = key.resource
+		status.Host = key.host
+
+		result = append(result, status)
+	}
+	br.mu.Unlock()
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Resource == result[j].Resource {
+			return result[i].Host < result[j].Host
+		}
+		return result[i].Resource < result[j].Resource
+	})
+
+	return result
+}
+
+// circuitBreaker tracks the failure rate of the calls to
+// a resource in a rolling window. When the rate reaches the
+// threshold the circuit opens and all calls are rejected until
+// the open interval elapses, after which a limited number of
+// probes is allowed. If all probes succeed the circuit closes,
+// otherwise it opens again.
+//
+// Every state transition starts a new generation, and the results
+// of calls admitted in a previous one are ignored, so a slow call
+// cannot be taken as a probe or change the state it was not part of.
+type circuitBreaker struct {
+	mu      sync.Mutex
+	options breakerOptions
+
+	state       string
+	generation  uint64
+	windowStart time.Time
+	requests    int
+	failures    int
+	openedAt    time.Time
+
+	probesInFlight int
+	probeSuccesses int
+}
+
+// breakerToken identifies the generation
+// in which a call was admitted by the breaker.
+type breakerToken struct {
+	generation uint64
+	probe      bool
+}
+
+func (cb *circuitBreaker) allow() (breakerToken, bool) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	now := time.Now()
+
+	switch cb.state {
+	case CircuitOpen:
+		if now.Sub(cb.openedAt) < cb.options.openInterval {
+			return breakerToken{}, false
+		}
+
+		cb.state = CircuitHalfOpen
+		cb.generation++
+		cb.probesInFlight = 0
+		cb.probeSuccesses = 0
+		fallthrough
+	case CircuitHalfOpen:
+		if cb.probesInFlight+cb.probeSuccesses >= cb.options.halfOpenProbes {
+			return breakerToken{}, false
+		}
+
+		cb.probesInFlight++
+		return breakerToken{generation: cb.generation, probe: true}, true
+	default:
+		if now.Sub(cb.windowStart) >= cb.options.window {
+			cb.windowStart = now
+			cb.requests = 0
+			cb.failures = 0
+		}
+
+		return breakerToken{generation: cb.generation}, true
+	}
+}
+
+func (cb *circuitBreaker) record(token breakerToken, success bool) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	if token.generation != cb.generation {
+		return
+	}
+
+	switch cb.state {
+	case CircuitHalfOpen:
+		cb.probesInFlight--
+		if !success {
+			cb.open()
+			return
+		}
+
+		cb.probeSuccesses++
+		if cb.probeSuccesses >= cb.options.halfOpenProbes {
+			cb.close()
+		}
+	case CircuitClosed:
+		cb.requests++
+		if !success {
+			cb.failures++
+		}
+
+		if cb.requests < cb.options.minimumRequests {
+			return
+		}
+
+		failureRate := float64(cb.failures) / float64(cb.requests) * 100
+		if failureRate >= cb.options.failureRateThreshold {
+			cb.open()
+		}
+	}
+}
+
+func (cb *circuitBreaker) release(token breakerToken) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	if token.probe && token.generation == cb.generation {
+		cb.probesInFlight--
+	}
+}
+
+func (cb *circuitBreaker) open() {
+	cb.state = CircuitOpen
+	cb.generation++
+	cb.openedAt = time.Now()
+}
+
+func (cb *circuitBreaker) close() {
+	cb.state = CircuitClosed
+	cb.generation++
+	cb.windowStart = time.Now()
+	cb.requests = 0
+	cb.failures = 0
+}
+
+func (cb *circuitBreaker) status() CircuitBreakerStatus {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	return CircuitBreakerStatus{
+		State:    cb.state,
+		Requests: cb.requests,
+		Failures: cb.failures,
+		OpenedAt: cb.openedAt,
+	}
+}
@@ -0,0 +1,174 @@
+package httpclient
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/b2wdigital/restQL-golang/v4/internal/domain"
+	"github.com/b2wdigital/restQL-golang/v4/pkg/restql"
+	"github.com/b2wdigital/restQL-golang/v4/test"
+	"github.com/pkg/errors"
+)
+
+type statusClient struct {
+	status int
+	calls  int
+}
+
+func (sc *statusClient) Do(ctx context.Context, request domain.HTTPRequest) (domain.HTTPResponse, error) {
+	sc.calls++
+	return domain.HTTPResponse{StatusCode: sc.status}, nil
+}
+
+func TestCircuitBreakerClient(t *testing.T) {
+	ctx := restql.WithLogger(context.Background(), test.NoOpLogger{})
+	request := domain.HTTPRequest{Resource: "hero", Schema: "http", Host: "hero.api", Path: "/hero"}
+
+	options := breakerOptions{
+		enabled:              true,
+		failureRateThreshold: 50,
+		minimumRequests:      2,
+		window:               time.Minute,
+		openInterval:         20 * time.Millisecond,
+		halfOpenProbes:       1,
+	}
+
+	newClient := func(client domain.HTTPClient, options breakerOptions) *circuitBreakerClient {
+		return &circuitBreakerClient{
+			client:   client,
+			registry: newCircuitBreakers(),
+			global:   options,
+		}
+	}
+
+	t.Run("should open circuit when failure rate reaches threshold", func(t *testing.T) {
+		upstream := &statusClient{status: http.StatusInternalServerError}
+		client := newClient(upstream, options)
+
+		client.Do(ctx, request)
+		client.Do(ctx, request)
+
+		response, err := client.Do(ctx, request)
+
+		test.Equal(t, errors.Is(err, domain.ErrCircuitOpen), true)
+		test.Equal(t, response.StatusCode, http.StatusServiceUnavailable)
+		test.Equal(t, upstream.calls, 2)
+		test.Equal(t, client.registry.Statuses()[0].State, CircuitOpen)
+	})
+
+	t.Run("should close circuit after successful probe", func(t *testing.T) {
+		upstream := &statusClient{status: http.StatusInternalServerError}
+		client := newClient(upstream, options)
+
+		client.Do(ctx, request)
+		client.Do(ctx, request)
+
+		time.Sleep(options.openInterval)
+		upstream.status = http.StatusOK
+
+		_, err := client.Do(ctx, request)
+
+		test.VerifyError(t, err)
+		test.Equal(t, upstream.calls, 3)
+		test.Equal(t, client.registry.Statuses()[0].State, CircuitClosed)
+	})
+
+	t.Run("should reopen circuit after failed probe", func(t *testing.T) {
+		upstream := &statusClient{status: http.StatusInternalServerError}
+		client := newClient(upstream, options)
+
+		client.Do(ctx, request)
+		client.Do(ctx, request)
+
+		time.Sleep(options.openInterval)
+
+		client.Do(ctx, request)
+		_, err := client.Do(ctx, request)
+
+		test.Equal(t, errors.Is(err, domain.ErrCircuitOpen), true)
+		test.Equal(t, upstream.calls, 3)
+		test.Equal(t, client.registry.Statuses()[0].State, CircuitOpen)
+	})
+
+	t.Run("should not track calls when disabled", func(t *testing.T) {
+		upstream := &statusClient{status: http.StatusInternalServerError}
+		disabled := options
+		disabled.enabled = false
+		client := newClient(upstream, disabled)
+
+		for i := 0; i < 5; i++ {
+			client.Do(ctx, request)
+		}
+
+		test.Equal(t, upstream.calls, 5)
+		test.Equal(t, len(client.registry.Statuses()), 0)
+	})
+}
+
+func TestCircuitBreakerStaleCalls(t *testing.T) {
+	options := breakerOptions{
+		enabled:              true,
+		failureRateThreshold: 50,
+		minimumRequests:      2,
+		window:               time.Minute,
+		openInterval:         20 * time.Millisecond,
+		halfOpenProbes:       1,
+	}
+
+	newBreaker := func() *circuitBreaker {
+		return &circuitBreaker{options: options, state: CircuitClosed, windowStart: time.Now()}
+	}
+
+	openCircuit := func(cb *circuitBreaker) {
+		for i := 0; i < 2; i++ {
+			token, _ := cb.allow()
+			cb.record(token, false)
+		}
+	}
+
+	t.Run("should ignore results of calls admitted before the circuit opened", func(t *testing.T) {
+		cb := newBreaker()
+
+		slow, allowed := cb.allow()
+		test.Equal(t, allowed, true)
+
+		openCircuit(cb)
+		time.Sleep(options.openInterval)
+
+		probe, allowed := cb.allow()
+		test.Equal(t, allowed, true)
+		test.Equal(t, probe.probe, true)
+
+		cb.record(slow, true)
+		test.Equal(t, cb.status().State, CircuitHalfOpen)
+
+		_, allowed = cb.allow()
+		test.Equal(t, allowed, false)
+
+		cb.record(probe, false)
+		test.Equal(t, cb.status().State, CircuitOpen)
+	})
+
+	t.Run("should not release probes for calls admitted before the circuit opened", func(t *testing.T) {
+		cb := newBreaker()
+
+		slow, _ := cb.allow()
+
+		openCircuit(cb)
+		time.Sleep(options.openInterval)
+
+		probe, _ := cb.allow()
+
+		cb.release(slow)
+		cb.release(slow)
+		test.Equal(t, cb.probesInFlight, 1)
+
+		_, allowed := cb.allow()
+		test.Equal(t, allowed, false)
+
+		cb.record(probe, true)
+		test.Equal(t, cb.status().State, CircuitClosed)
+	})
+}
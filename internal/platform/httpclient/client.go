@@ -7,7 +7,10 @@ import (
 	"github.com/b2wdigital/restQL-golang/v4/pkg/restql"
 )
 
-// New constructs an HTTPClient instances.
-func New(log restql.Logger, pm plugins.Lifecycle, cfg *conf.Config) domain.HTTPClient {
-	return newNativeHTTPClient(log, pm, cfg)
+// New constructs an HTTPClient instances, returning
+// with it the circuit breakers used by its calls.
+func New(log restql.Logger, pm plugins.Lifecycle, cfg *conf.Config) (domain.HTTPClient, *CircuitBreakers) {
+	breakers := newCircuitBreakers()
+	client := newCircuitBreakerClient(newNativeHTTPClient(log, pm, cfg), breakers, cfg)
+	return newResponseCacheClient(log, client, cfg), breakers
 }
@@ -0,0 +1,152 @@
+package httpclient
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/b2wdigital/restQL-golang/v4/internal/domain"
+	"github.com/b2wdigital/restQL-golang/v4/internal/platform/conf"
+	"github.com/b2wdigital/restQL-golang/v4/internal/platform/detached"
+	"golang.org/x/sync/singleflight"
+)
+
+type executeFn func(ctx context.Context, request domain.HTTPRequest) (domain.HTTPResponse, error)
+
+// credentialHeaders are always part of the coalescing key, so
+// callers with different credentials never share a response.
+var credentialHeaders = []string{"Authorization", "Cookie"}
+
+// coalescer merges identical GET requests made concurrently
+// to the same resource into a single upstream call, sharing
+// its response with all callers.
+type coalescer struct {
+	group    singleflight.Group
+	mappings map[string][]string
+}
+
+func newCoalescer(cfg *conf.Config) *coalescer {
+	mappings := make(map[string][]string)
+	for mapping, policy := range cfg.MappingPolicies {
+		if policy.Coalesce == nil || !policy.Coalesce.Enabled {
+			continue
+		}
+
+		seen := make(map[string]bool)
+		var headers []string
+		for _, h := range append(credentialHeaders, policy.Coalesce.Headers...) {
+			h = http.CanonicalHeaderKey(h)
+			if !seen[h] {
+				seen[h] = true
+				headers = append(headers, h)
+			}
+		}
+		sort.Strings(headers)
+
+		mappings[mapping] = headers
+	}
+
+	return &coalescer{mappings: mappings}
+}
+
+// do executes the request with the given function, waiting for
+// an identical request in flight instead when there is one.
+// Requests are identical when they have the same URL, timeout,
+// credential headers and values for the headers configured for
+// the mapping, as sent upstream after the request interceptors.
+func (c *coalescer) do(ctx context.Context, request domain.HTTPRequest, fn executeFn) (domain.HTTPResponse, error) {
+	headers, found := c.mappings[request.Resource]
+	if !found || request.Method != http.MethodGet {
+		return fn(ctx, request)
+	}
+
+	requestURL := makeURL(request).String()
+	key := coalesceKey(requestURL, request, headers)
+
+	// The upstream call is shared, hence it must not be
+	// interrupted when the caller that started it gives up.
+	ch := c.group.DoChan(key, func() (interface{}, error) {
+		return fn(detached.Context(ctx), request)
+	})
+
+	select {
+	case result := <-ch:
+		response := result.Val.(domain.HTTPResponse)
+		if result.Shared {
+			response = copyResponse(response)
+		}
+
+		return response, result.Err
+	case <-ctx.Done():
+		if ctx.Err() == context.DeadlineExceeded {
+			return makeErrorResponse(requestURL, 0, http.StatusRequestTimeout), domain.ErrRequestTimeout
+		}
+
+		return makeErrorResponse(requestURL, 0, defaultStatusCode), ctx.Err()
+	}
+}
+
+func coalesceKey(requestURL string, request domain.HTTPRequest, headers []string) string {
+	var sb strings.Builder
+	sb.WriteString(request.Resource)
+	sb.WriteString(" ")
+	sb.WriteString(requestURL)
+	sb.WriteString(" ")
+	sb.WriteString(request.Timeout.String())
+
+	writeHeaderValues(&sb, canonicalHeaders(request.Headers), headers)
+
+	return sb.String()
+}
+
+// writeHeaderValues appends the names and values of the
+// given headers to a cache key, one header per line.
+func writeHeaderValues(sb *strings.Builder, headers map[string]string, names []string) {
+	for _, h := range names {
+		sb.WriteString("\n")
+		sb.WriteString(h)
+		sb.WriteString(": ")
+		sb.WriteString(headers[h])
+	}
+}
+
+func canonicalHeaders(headers domain.Headers) map[string]string {
+	canonical := make(map[string]string, len(headers))
+	for k, v := range headers {
+		canonical[http.CanonicalHeaderKey(k)] = v
+	}
+
+	return canonical
+}
+
+func copyResponse(response domain.HTTPResponse) domain.HTTPResponse {
+	headers := make(map[string]string, len(response.Headers))
+	for k, v := range response.Headers {
+		headers[k] = v
+	}
+
+	response.Headers = headers
+	response.Body = copyBody(response.Body)
+
+	return response
+}
+
+func copyBody(body interface{}) interface{} {
+	switch body := body.(type) {
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(body))
+		for k, v := range body {
+			m[k] = copyBody(v)
+		}
+		return m
+	case []interface{}:
+		l := make([]interface{}, len(body))
+		for i, v := range body {
+			l[i] = copyBody(v)
+		}
+		return l
+	default:
+		return body
+	}
+}
@@ -0,0 +1,121 @@
+package httpclient
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/b2wdigital/restQL-golang/v4/internal/domain"
+	"github.com/b2wdigital/restQL-golang/v4/internal/platform/conf"
+	"github.com/b2wdigital/restQL-golang/v4/test"
+	"gopkg.in/yaml.v2"
+)
+
+func TestCoalescer(t *testing.T) {
+	cfg := &conf.Config{}
+	err := yaml.Unmarshal([]byte(`
+mappingPolicies:
+  hero:
+    coalesce:
+      enabled: true
+      headers: [x-tenant]
+  villain:
+    coalesce:
+      enabled: true
+`), cfg)
+	test.VerifyError(t, err)
+
+	tests := []struct {
+		name          string
+		requests      []domain.HTTPRequest
+		expectedCalls int32
+	}{
+		{
+			"should coalesce identical get requests",
+			[]domain.HTTPRequest{
+				{Resource: "hero", Method: http.MethodGet, Schema: "http", Host: "hero.api", Path: "/hero", Headers: domain.Headers{"Authorization": "a", "X-Tid": "1"}},
+				{Resource: "hero", Method: http.MethodGet, Schema: "http", Host: "hero.api", Path: "/hero", Headers: domain.Headers{"Authorization": "a", "X-Tid": "2"}},
+				{Resource: "hero", Method: http.MethodGet, Schema: "http", Host: "hero.api", Path: "/hero", Headers: domain.Headers{"Authorization": "a", "X-Tid": "3"}},
+			},
+			1,
+		},
+		{
+			"should not coalesce requests with different relevant headers",
+			[]domain.HTTPRequest{
+				{Resource: "hero", Method: http.MethodGet, Schema: "http", Host: "hero.api", Path: "/hero", Headers: domain.Headers{"X-Tenant": "a"}},
+				{Resource: "hero", Method: http.MethodGet, Schema: "http", Host: "hero.api", Path: "/hero", Headers: domain.Headers{"X-Tenant": "b"}},
+			},
+			2,
+		},
+		{
+			"should not coalesce requests with different credentials",
+			[]domain.HTTPRequest{
+				{Resource: "villain", Method: http.MethodGet, Schema: "http", Host: "villain.api", Path: "/villain", Headers: domain.Headers{"Authorization": "a"}},
+				{Resource: "villain", Method: http.MethodGet, Schema: "http", Host: "villain.api", Path: "/villain", Headers: domain.Headers{"authorization": "b"}},
+				{Resource: "villain", Method: http.MethodGet, Schema: "http", Host: "villain.api", Path: "/villain", Headers: domain.Headers{"Cookie": "session=a"}},
+				{Resource: "villain", Method: http.MethodGet, Schema: "http", Host: "villain.api", Path: "/villain", Headers: domain.Headers{"Cookie": "session=b"}},
+			},
+			4,
+		},
+		{
+			"should not coalesce requests with different query",
+			[]domain.HTTPRequest{
+				{Resource: "hero", Method: http.MethodGet, Schema: "http", Host: "hero.api", Path: "/hero", Query: map[string]interface{}{"id": "1"}},
+				{Resource: "hero", Method: http.MethodGet, Schema: "http", Host: "hero.api", Path: "/hero", Query: map[string]interface{}{"id": "2"}},
+			},
+			2,
+		},
+		{
+			"should not coalesce non get requests",
+			[]domain.HTTPRequest{
+				{Resource: "hero", Method: http.MethodPost, Schema: "http", Host: "hero.api", Path: "/hero"},
+				{Resource: "hero", Method: http.MethodPost, Schema: "http", Host: "hero.api", Path: "/hero"},
+			},
+			2,
+		},
+		{
+			"should not coalesce requests to mappings without policy",
+			[]domain.HTTPRequest{
+				{Resource: "sidekick", Method: http.MethodGet, Schema: "http", Host: "sidekick.api", Path: "/sidekick"},
+				{Resource: "sidekick", Method: http.MethodGet, Schema: "http", Host: "sidekick.api", Path: "/sidekick"},
+			},
+			2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newCoalescer(cfg)
+
+			var calls int32
+			release := make(chan struct{})
+			fn := func(ctx context.Context, request domain.HTTPRequest) (domain.HTTPResponse, error) {
+				atomic.AddInt32(&calls, 1)
+				<-release
+				return domain.HTTPResponse{StatusCode: http.StatusOK, Body: map[string]interface{}{"name": "batman"}}, nil
+			}
+
+			responses := make([]domain.HTTPResponse, len(tt.requests))
+			var wg sync.WaitGroup
+			for i, r := range tt.requests {
+				wg.Add(1)
+				go func(i int, r domain.HTTPRequest) {
+					defer wg.Done()
+					responses[i], _ = c.do(context.Background(), r, fn)
+				}(i, r)
+			}
+
+			time.Sleep(20 * time.Millisecond)
+			close(release)
+			wg.Wait()
+
+			test.Equal(t, atomic.LoadInt32(&calls), tt.expectedCalls)
+			for _, r := range responses {
+				test.Equal(t, r.Body, map[string]interface{}{"name": "batman"})
+			}
+		})
+	}
+}
@@ -17,7 +17,9 @@ import (
 
 	"github.com/b2wdigital/restQL-golang/v4/internal/domain"
 	"github.com/b2wdigital/restQL-golang/v4/internal/platform/conf"
+	"github.com/b2wdigital/restQL-golang/v4/internal/platform/metrics"
 	"github.com/b2wdigital/restQL-golang/v4/internal/platform/plugins"
+	"github.com/b2wdigital/restQL-golang/v4/internal/platform/tracing"
 	"github.com/pkg/errors"
 	"github.com/rs/dnscache"
 )
@@ -28,6 +30,7 @@ type nativeHTTPClient struct {
 	client    *http.Client
 	log       restql.Logger
 	lifecycle plugins.Lifecycle
+	coalescer *coalescer
 }
 
 func newNativeHTTPClient(log restql.Logger, l plugins.Lifecycle, cfg *conf.Config) *nativeHTTPClient {
@@ -79,11 +82,50 @@ func newNativeHTTPClient(log restql.Logger, l plugins.Lifecycle, cfg *conf.Confi
 		client:    c,
 		log:       log,
 		lifecycle: l,
+		coalescer: newCoalescer(cfg),
 	}
 }
 
 func (nc *nativeHTTPClient) Do(ctx context.Context, request domain.HTTPRequest) (domain.HTTPResponse, error) {
 	ctx = nc.lifecycle.BeforeRequest(ctx, request)
+
+	request, err := nc.lifecycle.InterceptRequest(ctx, request)
+	if err != nil {
+		nc.lifecycle.AfterRequest(ctx, request, domain.HTTPResponse{}, err)
+		return domain.HTTPResponse{}, err
+	}
+
+	ctx, span := tracing.Start(ctx, "restql.request "+request.Resource, tracing.SpanKindClient)
+	span.SetAttribute("restql.resource", request.Resource)
+	span.SetAttribute("http.method", request.Method)
+	span.SetAttribute("http.host", request.Host)
+	span.SetAttribute("http.target", request.Path)
+
+	request.Headers = tracing.Inject(ctx, request.Headers)
+
+	response, err := nc.coalescer.do(ctx, request, nc.execute)
+	if err == nil {
+		response = nc.lifecycle.InterceptResponse(ctx, request, response)
+	}
+
+	span.SetAttribute("http.status_code", response.StatusCode)
+	span.SetError(err)
+	span.Finish()
+
+	nc.lifecycle.AfterRequest(ctx, request, response, err)
+
+	return response, err
+}
+
+// execute makes the upstream call, observing its latency and status code.
+func (nc *nativeHTTPClient) execute(ctx context.Context, request domain.HTTPRequest) (domain.HTTPResponse, error) {
+	response, err := nc.call(ctx, request)
+	metrics.UpstreamRequestDuration.Observe(response.Duration.Seconds(), request.Resource, strconv.Itoa(response.StatusCode))
+
+	return response, err
+}
+
+func (nc *nativeHTTPClient) call(ctx context.Context, request domain.HTTPRequest) (domain.HTTPResponse, error) {
 	log := restql.GetLogger(ctx)
 
 	req, err := nc.makeRequest(request)
@@ -105,19 +147,14 @@ func (nc *nativeHTTPClient) Do(ctx context.Context, request domain.HTTPRequest)
 	duration := time.Since(start)
 	if err != nil {
 		if err, ok := err.(net.Error); ok && err.Timeout() {
-			errorResponse := makeErrorResponse(requestURL, duration, http.StatusRequestTimeout)
 			log.Warn("request timed out", "url", requestURL, "target", target, "method", request.Method, "duration-ms", duration.Milliseconds())
 
-			nc.lifecycle.AfterRequest(ctx, request, errorResponse, domain.ErrRequestTimeout)
-
-			return errorResponse, domain.ErrRequestTimeout
+			return makeErrorResponse(requestURL, duration, http.StatusRequestTimeout), domain.ErrRequestTimeout
 		}
 
-		errorResponse := makeErrorResponse(requestURL, duration, defaultStatusCode)
 		log.Error("request finished with error", err, "url", requestURL, "target", target, "method", request.Method, "duration-ms", duration.Milliseconds())
 
-		nc.lifecycle.AfterRequest(ctx, request, errorResponse, err)
-		return errorResponse, err
+		return makeErrorResponse(requestURL, duration, defaultStatusCode), err
 	}
 
 	defer func() {
@@ -129,10 +166,7 @@ func (nc *nativeHTTPClient) Do(ctx context.Context, request domain.HTTPRequest)
 
 	body, err := nc.unmarshalBody(log, response)
 	if err != nil {
-		errorResponse := makeErrorResponse(requestURL, duration, defaultStatusCode)
-		nc.lifecycle.AfterRequest(ctx, request, errorResponse, err)
-
-		return errorResponse, err
+		return makeErrorResponse(requestURL, duration, defaultStatusCode), err
 	}
 
 	hr := make(map[string]string)
@@ -148,8 +182,6 @@ func (nc *nativeHTTPClient) Do(ctx context.Context, request domain.HTTPRequest)
 		Duration:   duration,
 	}
 
-	nc.lifecycle.AfterRequest(ctx, request, httpResponse, err)
-
 	return httpResponse, nil
 }
 
@@ -0,0 +1,239 @@
+package httpclient
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/b2wdigital/restQL-golang/v4/internal/domain"
+	"github.com/b2wdigital/restQL-golang/v4/internal/platform/cache"
+	"github.com/b2wdigital/restQL-golang/v4/internal/platform/conf"
+	"github.com/b2wdigital/restQL-golang/v4/internal/platform/detached"
+	"github.com/b2wdigital/restQL-golang/v4/pkg/restql"
+)
+
+const defaultResponseCacheSize = 1000
+
+var maxAgeRegex = regexp.MustCompile("max-age=(\\d+)")
+var sMaxAgeRegex = regexp.MustCompile("s-maxage=(\\d+)")
+var staleWhileRevalidateRegex = regexp.MustCompile("stale-while-revalidate=(\\d+)")
+var notStorableRegex = regexp.MustCompile("no-cache|no-store|private")
+
+// varyKey and responseKey distinguish the entries holding the
+// Vary header of a URL from the ones holding its responses.
+type varyKey string
+type responseKey string
+
+type cachedResponse struct {
+	response             domain.HTTPResponse
+	storedAt             time.Time
+	maxAge               time.Duration
+	staleWhileRevalidate time.Duration
+}
+
+// responseCacheClient wraps a HTTPClient storing the upstream
+// responses of GET requests according to their Cache-Control
+// header. Each mapping with the cache enabled has its own LRU
+// cache, where the responses are keyed by the request URL, its
+// credential headers and the values of the headers listed in the
+// response Vary header.
+type responseCacheClient struct {
+	client     domain.HTTPClient
+	caches     map[string]*cache.Cache
+	refreshing sync.Map
+}
+
+func newResponseCacheClient(log restql.Logger, client domain.HTTPClient, cfg *conf.Config) domain.HTTPClient {
+	caches := make(map[string]*cache.Cache)
+	for mapping, policy := range cfg.MappingPolicies {
+		if policy.ResponseCache == nil || !policy.ResponseCache.Enabled {
+			continue
+		}
+
+		size := policy.ResponseCache.MaxSize
+		if size <= 0 {
+			size = defaultResponseCacheSize
+		}
+
+		caches[mapping] = cache.New(log, size, nil)
+	}
+
+	if len(caches) == 0 {
+		return client
+	}
+
+	return &responseCacheClient{client: client, caches: caches}
+}
+
+func (rc *responseCacheClient) Do(ctx context.Context, request domain.HTTPRequest) (domain.HTTPResponse, error) {
+	c, found := rc.caches[request.Resource]
+	if !found || request.Method != http.MethodGet {
+		return rc.client.Do(ctx, request)
+	}
+
+	requestURL := makeURL(request).String()
+
+	if !notStorableRegex.MatchString(canonicalHeaders(request.Headers)["Cache-Control"]) {
+		key, entry, found := lookupResponse(c, requestURL, request)
+		if found {
+			age := time.Since(entry.storedAt)
+			switch {
+			case age < entry.maxAge:
+				return makeCachedResponse(entry, age), nil
+			case age < entry.maxAge+entry.staleWhileRevalidate:
+				rc.revalidate(ctx, c, key, requestURL, request)
+				return makeCachedResponse(entry, age), nil
+			}
+		}
+	}
+
+	response, err := rc.client.Do(ctx, request)
+	if err == nil {
+		storeResponse(c, requestURL, request, response)
+	}
+
+	response.CacheStatus = domain.CacheMiss
+	return response, err
+}
+
+// revalidate refreshes a stale entry in background,
+// making sure only one refresh per entry is running.
+func (rc *responseCacheClient) revalidate(ctx context.Context, c *cache.Cache, key responseKey, requestURL string, request domain.HTTPRequest) {
+	if _, running := rc.refreshing.LoadOrStore(key, struct{}{}); running {
+		return
+	}
+
+	go func() {
+		defer rc.refreshing.Delete(key)
+
+		response, err := rc.client.Do(detached.Context(ctx), request)
+		if err != nil {
+			log := restql.GetLogger(ctx)
+			log.Debug("failed to revalidate cached response", "url", requestURL, "error", err)
+			return
+		}
+
+		storeResponse(c, requestURL, request, response)
+	}()
+}
+
+func lookupResponse(c *cache.Cache, requestURL string, request domain.HTTPRequest) (responseKey, cachedResponse, bool) {
+	v, found := c.GetIfPresent(varyKey(requestURL))
+	if !found {
+		return "", cachedResponse{}, false
+	}
+
+	vary, ok := v.([]string)
+	if !ok {
+		return "", cachedResponse{}, false
+	}
+
+	key := variantKey(requestURL, vary, request)
+	v, found = c.GetIfPresent(key)
+	if !found {
+		return key, cachedResponse{}, false
+	}
+
+	entry, ok := v.(cachedResponse)
+	return key, entry, ok
+}
+
+func storeResponse(c *cache.Cache, requestURL string, request domain.HTTPRequest, response domain.HTTPResponse) {
+	if response.StatusCode != http.StatusOK {
+		return
+	}
+
+	responseHeaders := canonicalHeaders(response.Headers)
+
+	cacheControl := responseHeaders["Cache-Control"]
+	if notStorableRegex.MatchString(cacheControl) {
+		return
+	}
+
+	maxAge, ok := parseCacheControlSeconds(sMaxAgeRegex, cacheControl)
+	if !ok {
+		maxAge, ok = parseCacheControlSeconds(maxAgeRegex, cacheControl)
+	}
+	if !ok || maxAge <= 0 {
+		return
+	}
+
+	staleWhileRevalidate, _ := parseCacheControlSeconds(staleWhileRevalidateRegex, cacheControl)
+
+	vary, ok := parseVary(responseHeaders["Vary"])
+	if !ok {
+		return
+	}
+
+	entry := cachedResponse{
+		response:             copyResponse(response),
+		storedAt:             time.Now(),
+		maxAge:               maxAge,
+		staleWhileRevalidate: staleWhileRevalidate,
+	}
+
+	if err := c.Set(varyKey(requestURL), vary); err != nil {
+		return
+	}
+	_ = c.Set(variantKey(requestURL, vary, request), entry)
+}
+
+func makeCachedResponse(entry cachedResponse, age time.Duration) domain.HTTPResponse {
+	response := copyResponse(entry.response)
+	response.Headers["Age"] = strconv.Itoa(int(age.Seconds()))
+	response.Duration = 0
+	response.CacheStatus = domain.CacheHit
+
+	return response
+}
+
+// variantKey always includes the credential headers, as the cache is
+// shared by all clients and a response to an authenticated request
+// must not be served to other callers, whatever the Vary header says.
+func variantKey(requestURL string, vary []string, request domain.HTTPRequest) responseKey {
+	var sb strings.Builder
+	sb.WriteString(requestURL)
+
+	headers := canonicalHeaders(request.Headers)
+	writeHeaderValues(&sb, headers, credentialHeaders)
+	writeHeaderValues(&sb, headers, vary)
+
+	return responseKey(sb.String())
+}
+
+func parseVary(value string) ([]string, bool) {
+	vary := []string{}
+	for _, h := range strings.Split(value, ",") {
+		h = strings.TrimSpace(h)
+		switch h {
+		case "":
+			continue
+		case "*":
+			return nil, false
+		default:
+			vary = append(vary, http.CanonicalHeaderKey(h))
+		}
+	}
+	sort.Strings(vary)
+
+	return vary, true
+}
+
+func parseCacheControlSeconds(directive *regexp.Regexp, cacheControl string) (time.Duration, bool) {
+	matches := directive.FindStringSubmatch(cacheControl)
+	if len(matches) < 2 {
+		return 0, false
+	}
+
+	seconds, err := strconv.Atoi(matches[1])
+	if err != nil {
+		return 0, false
+	}
+
+	return time.Duration(seconds) * time.Second, true
+}
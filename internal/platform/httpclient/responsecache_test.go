@@ -0,0 +1,181 @@
+package httpclient
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/b2wdigital/restQL-golang/v4/internal/domain"
+	"github.com/b2wdigital/restQL-golang/v4/internal/platform/conf"
+	"github.com/b2wdigital/restQL-golang/v4/pkg/restql"
+	"github.com/b2wdigital/restQL-golang/v4/test"
+	"gopkg.in/yaml.v2"
+)
+
+type headersClient struct {
+	headers domain.Headers
+	calls   int32
+}
+
+func (hc *headersClient) Do(ctx context.Context, request domain.HTTPRequest) (domain.HTTPResponse, error) {
+	atomic.AddInt32(&hc.calls, 1)
+	return domain.HTTPResponse{StatusCode: http.StatusOK, Headers: hc.headers, Body: map[string]interface{}{"name": "batman"}}, nil
+}
+
+func TestResponseCacheClient(t *testing.T) {
+	cfg := &conf.Config{}
+	err := yaml.Unmarshal([]byte(`
+mappingPolicies:
+  hero:
+    responseCache:
+      enabled: true
+`), cfg)
+	test.VerifyError(t, err)
+
+	ctx := restql.WithLogger(context.Background(), test.NoOpLogger{})
+	get := domain.HTTPRequest{Resource: "hero", Method: http.MethodGet, Schema: "http", Host: "hero.api", Path: "/hero"}
+
+	tests := []struct {
+		name             string
+		headers          domain.Headers
+		requests         []domain.HTTPRequest
+		expectedStatuses []string
+		expectedCalls    int32
+	}{
+		{
+			"should serve fresh response from cache",
+			domain.Headers{"Cache-Control": "max-age=60"},
+			[]domain.HTTPRequest{get, get},
+			[]string{domain.CacheMiss, domain.CacheHit},
+			1,
+		},
+		{
+			"should prefer s-maxage over max-age",
+			domain.Headers{"Cache-Control": "max-age=0, s-maxage=60"},
+			[]domain.HTTPRequest{get, get},
+			[]string{domain.CacheMiss, domain.CacheHit},
+			1,
+		},
+		{
+			"should not store response without max-age",
+			domain.Headers{"Content-Type": "application/json"},
+			[]domain.HTTPRequest{get, get},
+			[]string{domain.CacheMiss, domain.CacheMiss},
+			2,
+		},
+		{
+			"should not store response with no-cache",
+			domain.Headers{"Cache-Control": "no-cache, max-age=60"},
+			[]domain.HTTPRequest{get, get},
+			[]string{domain.CacheMiss, domain.CacheMiss},
+			2,
+		},
+		{
+			"should not store response varying on all headers",
+			domain.Headers{"Cache-Control": "max-age=60", "Vary": "*"},
+			[]domain.HTTPRequest{get, get},
+			[]string{domain.CacheMiss, domain.CacheMiss},
+			2,
+		},
+		{
+			"should key response by headers listed in vary",
+			domain.Headers{"Cache-Control": "max-age=60", "Vary": "Accept-Language"},
+			[]domain.HTTPRequest{
+				{Resource: "hero", Method: http.MethodGet, Schema: "http", Host: "hero.api", Path: "/hero", Headers: domain.Headers{"Accept-Language": "en"}},
+				{Resource: "hero", Method: http.MethodGet, Schema: "http", Host: "hero.api", Path: "/hero", Headers: domain.Headers{"accept-language": "pt"}},
+				{Resource: "hero", Method: http.MethodGet, Schema: "http", Host: "hero.api", Path: "/hero", Headers: domain.Headers{"accept-language": "en"}},
+			},
+			[]string{domain.CacheMiss, domain.CacheMiss, domain.CacheHit},
+			2,
+		},
+		{
+			"should key response by credential headers",
+			domain.Headers{"Cache-Control": "max-age=60"},
+			[]domain.HTTPRequest{
+				{Resource: "hero", Method: http.MethodGet, Schema: "http", Host: "hero.api", Path: "/hero", Headers: domain.Headers{"Authorization": "Bearer a"}},
+				{Resource: "hero", Method: http.MethodGet, Schema: "http", Host: "hero.api", Path: "/hero", Headers: domain.Headers{"authorization": "Bearer b"}},
+				get,
+				{Resource: "hero", Method: http.MethodGet, Schema: "http", Host: "hero.api", Path: "/hero", Headers: domain.Headers{"Cookie": "session=a"}},
+				{Resource: "hero", Method: http.MethodGet, Schema: "http", Host: "hero.api", Path: "/hero", Headers: domain.Headers{"Authorization": "Bearer a"}},
+			},
+			[]string{domain.CacheMiss, domain.CacheMiss, domain.CacheMiss, domain.CacheMiss, domain.CacheHit},
+			4,
+		},
+		{
+			"should bypass cache when request has no-cache",
+			domain.Headers{"Cache-Control": "max-age=60"},
+			[]domain.HTTPRequest{
+				get,
+				{Resource: "hero", Method: http.MethodGet, Schema: "http", Host: "hero.api", Path: "/hero", Headers: domain.Headers{"Cache-Control": "no-cache"}},
+			},
+			[]string{domain.CacheMiss, domain.CacheMiss},
+			2,
+		},
+		{
+			"should not cache non get requests",
+			domain.Headers{"Cache-Control": "max-age=60"},
+			[]domain.HTTPRequest{
+				{Resource: "hero", Method: http.MethodPost, Schema: "http", Host: "hero.api", Path: "/hero"},
+				{Resource: "hero", Method: http.MethodPost, Schema: "http", Host: "hero.api", Path: "/hero"},
+			},
+			[]string{"", ""},
+			2,
+		},
+		{
+			"should not cache mappings without policy",
+			domain.Headers{"Cache-Control": "max-age=60"},
+			[]domain.HTTPRequest{
+				{Resource: "sidekick", Method: http.MethodGet, Schema: "http", Host: "sidekick.api", Path: "/sidekick"},
+				{Resource: "sidekick", Method: http.MethodGet, Schema: "http", Host: "sidekick.api", Path: "/sidekick"},
+			},
+			[]string{"", ""},
+			2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			upstream := &headersClient{headers: tt.headers}
+			client := newResponseCacheClient(test.NoOpLogger{}, upstream, cfg)
+
+			statuses := make([]string, len(tt.requests))
+			for i, r := range tt.requests {
+				response, err := client.Do(ctx, r)
+				test.VerifyError(t, err)
+				test.Equal(t, response.Body, map[string]interface{}{"name": "batman"})
+
+				statuses[i] = response.CacheStatus
+			}
+
+			test.Equal(t, statuses, tt.expectedStatuses)
+			test.Equal(t, atomic.LoadInt32(&upstream.calls), tt.expectedCalls)
+		})
+	}
+
+	t.Run("should serve stale response while revalidating", func(t *testing.T) {
+		upstream := &headersClient{headers: domain.Headers{"Cache-Control": "max-age=60, stale-while-revalidate=60"}}
+		client := newResponseCacheClient(test.NoOpLogger{}, upstream, cfg).(*responseCacheClient)
+
+		_, err := client.Do(ctx, get)
+		test.VerifyError(t, err)
+
+		requestURL := makeURL(get).String()
+		c := client.caches["hero"]
+		key, entry, _ := lookupResponse(c, requestURL, get)
+		entry.storedAt = time.Now().Add(-90 * time.Second)
+		test.VerifyError(t, c.Set(key, entry))
+
+		response, err := client.Do(ctx, get)
+		test.VerifyError(t, err)
+		test.Equal(t, response.CacheStatus, domain.CacheHit)
+		test.Equal(t, response.Headers["Age"], "90")
+
+		time.Sleep(20 * time.Millisecond)
+
+		_, refreshed, _ := lookupResponse(c, requestURL, get)
+		test.Equal(t, atomic.LoadInt32(&upstream.calls), int32(2))
+		test.Equal(t, time.Since(refreshed.storedAt) < time.Second, true)
+	})
+}
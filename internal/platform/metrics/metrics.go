@@ -0,0 +1,318 @@
+// Package metrics provides a minimal implementation of
+// counters, gauges and histograms that can be exposed
+// in the Prometheus text exposition format.
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DefaultBuckets are the histogram upper bounds, in seconds,
+// suited for the latency of queries and upstream requests.
+var DefaultBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+var labelValueEscaper = strings.NewReplacer("\\", "\\\\", "\"", "\\\"", "\n", "\\n")
+
+type metric interface {
+	write(w *bufio.Writer)
+}
+
+// Registry holds a collection of metrics, writing
+// them in the order they were registered.
+type Registry struct {
+	mu      sync.Mutex
+	metrics []metric
+}
+
+// NewRegistry constructs an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+func (r *Registry) register(m metric) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.metrics = append(r.metrics, m)
+}
+
+// WriteText writes all metrics in the Prometheus text format.
+func (r *Registry) WriteText(w io.Writer) error {
+	r.mu.Lock()
+	metrics := make([]metric, len(r.metrics))
+	copy(metrics, r.metrics)
+	r.mu.Unlock()
+
+	bw := bufio.NewWriter(w)
+	for _, m := range metrics {
+		m.write(bw)
+	}
+
+	return bw.Flush()
+}
+
+type desc struct {
+	name   string
+	help   string
+	labels []string
+}
+
+func (d desc) header(w *bufio.Writer, kind string) {
+	fmt.Fprintf(w, "# HELP %s %s\n", d.name, d.help)
+	fmt.Fprintf(w, "# TYPE %s %s\n", d.name, kind)
+}
+
+func (d desc) key(values []string) string {
+	if len(values) != len(d.labels) {
+		panic(fmt.Sprintf("metric %s expects %d label values, got %d", d.name, len(d.labels), len(values)))
+	}
+
+	return strings.Join(values, "\xff")
+}
+
+func (d desc) labelPairs(values []string, extra ...string) string {
+	if len(d.labels) == 0 && len(extra) == 0 {
+		return ""
+	}
+
+	pairs := make([]string, 0, len(d.labels)+len(extra)/2)
+	for i, l := range d.labels {
+		pairs = append(pairs, l+"=\""+labelValueEscaper.Replace(values[i])+"\"")
+	}
+	for i := 0; i+1 < len(extra); i += 2 {
+		pairs = append(pairs, extra[i]+"=\""+labelValueEscaper.Replace(extra[i+1])+"\"")
+	}
+
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+func sortedKeys(m map[string][]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// Counter is a cumulative metric partitioned by labels.
+type Counter struct {
+	desc
+	mu     sync.Mutex
+	labels map[string][]string
+	counts map[string]float64
+}
+
+// NewCounter creates and registers a Counter.
+func NewCounter(r *Registry, name, help string, labels ...string) *Counter {
+	c := &Counter{
+		desc:   desc{name: name, help: help, labels: labels},
+		labels: make(map[string][]string),
+		counts: make(map[string]float64),
+	}
+	r.register(c)
+
+	return c
+}
+
+// Inc increments by one the counter for the given label values.
+func (c *Counter) Inc(values ...string) {
+	c.Add(1, values...)
+}
+
+// Add increments by v the counter for the given label values.
+func (c *Counter) Add(v float64, values ...string) {
+	key := c.key(values)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, found := c.labels[key]; !found {
+		c.labels[key] = append([]string(nil), values...)
+	}
+	c.counts[key] += v
+}
+
+func (c *Counter) write(w *bufio.Writer) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.header(w, "counter")
+	if len(c.desc.labels) == 0 && len(c.labels) == 0 {
+		fmt.Fprintf(w, "%s 0\n", c.name)
+		return
+	}
+
+	for _, key := range sortedKeys(c.labels) {
+		fmt.Fprintf(w, "%s%s %s\n", c.name, c.labelPairs(c.labels[key]), formatFloat(c.counts[key]))
+	}
+}
+
+// Gauge is a metric that can go up and down, partitioned by labels.
+type Gauge struct {
+	desc
+	mu     sync.Mutex
+	labels map[string][]string
+	values map[string]float64
+}
+
+// NewGauge creates and registers a Gauge.
+func NewGauge(r *Registry, name, help string, labels ...string) *Gauge {
+	g := &Gauge{
+		desc:   desc{name: name, help: help, labels: labels},
+		labels: make(map[string][]string),
+		values: make(map[string]float64),
+	}
+	r.register(g)
+
+	return g
+}
+
+// Inc increments the gauge by one.
+func (g *Gauge) Inc(values ...string) {
+	g.Add(1, values...)
+}
+
+// Dec decrements the gauge by one.
+func (g *Gauge) Dec(values ...string) {
+	g.Add(-1, values...)
+}
+
+// Add adds v, which can be negative, to the gauge.
+func (g *Gauge) Add(v float64, values ...string) {
+	key := g.key(values)
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if _, found := g.labels[key]; !found {
+		g.labels[key] = append([]string(nil), values...)
+	}
+	g.values[key] += v
+}
+
+func (g *Gauge) write(w *bufio.Writer) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.header(w, "gauge")
+	if len(g.desc.labels) == 0 && len(g.labels) == 0 {
+		fmt.Fprintf(w, "%s 0\n", g.name)
+		return
+	}
+
+	for _, key := range sortedKeys(g.labels) {
+		fmt.Fprintf(w, "%s%s %s\n", g.name, g.labelPairs(g.labels[key]), formatFloat(g.values[key]))
+	}
+}
+
+// Histogram samples observations in cumulative buckets, partitioned by labels.
+type Histogram struct {
+	desc
+	buckets []float64
+	mu      sync.Mutex
+	labels  map[string][]string
+	data    map[string]*histogramData
+}
+
+type histogramData struct {
+	counts []uint64
+	count  uint64
+	sum    float64
+}
+
+// NewHistogram creates and registers a Histogram with the given bucket upper bounds.
+func NewHistogram(r *Registry, name, help string, buckets []float64, labels ...string) *Histogram {
+	b := append([]float64(nil), buckets...)
+	sort.Float64s(b)
+
+	h := &Histogram{
+		desc:    desc{name: name, help: help, labels: labels},
+		buckets: b,
+		labels:  make(map[string][]string),
+		data:    make(map[string]*histogramData),
+	}
+	r.register(h)
+
+	return h
+}
+
+// Observe adds a single observation to the histogram.
+func (h *Histogram) Observe(v float64, values ...string) {
+	key := h.key(values)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	d, found := h.data[key]
+	if !found {
+		h.labels[key] = append([]string(nil), values...)
+		d = &histogramData{counts: make([]uint64, len(h.buckets))}
+		h.data[key] = d
+	}
+
+	for i, upper := range h.buckets {
+		if v <= upper {
+			d.counts[i]++
+		}
+	}
+	d.count++
+	d.sum += v
+}
+
+func (h *Histogram) write(w *bufio.Writer) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.header(w, "histogram")
+	for _, key := range sortedKeys(h.labels) {
+		values := h.labels[key]
+		d := h.data[key]
+
+		for i, upper := range h.buckets {
+			fmt.Fprintf(w, "%s_bucket%s %d\n", h.name, h.labelPairs(values, "le", formatFloat(upper)), d.counts[i])
+		}
+		fmt.Fprintf(w, "%s_bucket%s %d\n", h.name, h.labelPairs(values, "le", "+Inf"), d.count)
+		fmt.Fprintf(w, "%s_sum%s %s\n", h.name, h.labelPairs(values), formatFloat(d.sum))
+		fmt.Fprintf(w, "%s_count%s %d\n", h.name, h.labelPairs(values), d.count)
+	}
+}
+
+// GaugeFunc is a gauge without labels which value
+// is computed by a function when metrics are written.
+type GaugeFunc struct {
+	desc
+	fn func() float64
+}
+
+// NewGaugeFunc creates and registers a GaugeFunc.
+func NewGaugeFunc(r *Registry, name, help string, fn func() float64) *GaugeFunc {
+	g := &GaugeFunc{desc: desc{name: name, help: help}, fn: fn}
+	r.register(g)
+
+	return g
+}
+
+func (g *GaugeFunc) write(w *bufio.Writer) {
+	g.header(w, "gauge")
+	fmt.Fprintf(w, "%s %s\n", g.name, formatFloat(g.fn()))
+}
+
+func formatFloat(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	default:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	}
+}
@@ -0,0 +1,104 @@
+package metrics_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/b2wdigital/restQL-golang/v4/internal/platform/metrics"
+	"github.com/b2wdigital/restQL-golang/v4/test"
+)
+
+func TestRegistryWriteText(t *testing.T) {
+	tests := []struct {
+		name     string
+		setup    func(r *metrics.Registry)
+		expected string
+	}{
+		{
+			"should write counter sorted by label values",
+			func(r *metrics.Registry) {
+				c := metrics.NewCounter(r, "requests_total", "Total requests.", "mapping", "status")
+				c.Inc("sidekick", "200")
+				c.Inc("hero", "200")
+				c.Add(2, "hero", "200")
+			},
+			"# HELP requests_total Total requests.\n" +
+				"# TYPE requests_total counter\n" +
+				"requests_total{mapping=\"hero\",status=\"200\"} 3\n" +
+				"requests_total{mapping=\"sidekick\",status=\"200\"} 1\n",
+		},
+		{
+			"should write zero for unlabeled metrics never updated",
+			func(r *metrics.Registry) {
+				metrics.NewCounter(r, "spawned_total", "Spawned.")
+				metrics.NewGauge(r, "in_flight", "In flight.")
+			},
+			"# HELP spawned_total Spawned.\n" +
+				"# TYPE spawned_total counter\n" +
+				"spawned_total 0\n" +
+				"# HELP in_flight In flight.\n" +
+				"# TYPE in_flight gauge\n" +
+				"in_flight 0\n",
+		},
+		{
+			"should write gauge",
+			func(r *metrics.Registry) {
+				g := metrics.NewGauge(r, "in_flight", "In flight.")
+				g.Inc()
+				g.Inc()
+				g.Dec()
+			},
+			"# HELP in_flight In flight.\n" +
+				"# TYPE in_flight gauge\n" +
+				"in_flight 1\n",
+		},
+		{
+			"should write histogram with cumulative buckets",
+			func(r *metrics.Registry) {
+				h := metrics.NewHistogram(r, "latency_seconds", "Latency.", []float64{1, 0.1}, "mapping")
+				h.Observe(0.05, "hero")
+				h.Observe(0.5, "hero")
+				h.Observe(2, "hero")
+			},
+			"# HELP latency_seconds Latency.\n" +
+				"# TYPE latency_seconds histogram\n" +
+				"latency_seconds_bucket{mapping=\"hero\",le=\"0.1\"} 1\n" +
+				"latency_seconds_bucket{mapping=\"hero\",le=\"1\"} 2\n" +
+				"latency_seconds_bucket{mapping=\"hero\",le=\"+Inf\"} 3\n" +
+				"latency_seconds_sum{mapping=\"hero\"} 2.55\n" +
+				"latency_seconds_count{mapping=\"hero\"} 3\n",
+		},
+		{
+			"should escape label values",
+			func(r *metrics.Registry) {
+				c := metrics.NewCounter(r, "queries_total", "Queries.", "query")
+				c.Inc("a\"b\\c\nd")
+			},
+			"# HELP queries_total Queries.\n" +
+				"# TYPE queries_total counter\n" +
+				"queries_total{query=\"a\\\"b\\\\c\\nd\"} 1\n",
+		},
+		{
+			"should write gauge func",
+			func(r *metrics.Registry) {
+				metrics.NewGaugeFunc(r, "workers", "Workers.", func() float64 { return 4 })
+			},
+			"# HELP workers Workers.\n" +
+				"# TYPE workers gauge\n" +
+				"workers 4\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := metrics.NewRegistry()
+			tt.setup(r)
+
+			var buf bytes.Buffer
+			err := r.WriteText(&buf)
+
+			test.VerifyError(t, err)
+			test.Equal(t, buf.String(), tt.expected)
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package metrics
+
+import "runtime"
+
+// Default is the registry with the restQL metrics,
+// exposed by the health server.
+var Default = NewRegistry()
+
+// Cache lookup results.
+const (
+	CacheHit  = "hit"
+	CacheMiss = "miss"
+)
+
+var (
+	// Queries counts the finished queries by namespace, query, revision and status code.
+	Queries = NewCounter(Default, "restql_queries_total", "Total number of queries run.", "namespace", "query", "revision", "status")
+
+	// QueryDuration observes the query latency by namespace, query, revision and status code.
+	QueryDuration = NewHistogram(Default, "restql_query_duration_seconds", "Query latency in seconds.", DefaultBuckets, "namespace", "query", "revision", "status")
+
+	// QueriesInFlight tracks the number of queries being run.
+	QueriesInFlight = NewGauge(Default, "restql_queries_in_flight", "Number of queries being run.")
+
+	// UpstreamRequestDuration observes the upstream calls latency by mapping and status code.
+	UpstreamRequestDuration = NewHistogram(Default, "restql_upstream_request_duration_seconds", "Upstream request latency in seconds.", DefaultBuckets, "mapping", "status")
+
+	// CacheRequests counts the cache lookups by cache name and result.
+	CacheRequests = NewCounter(Default, "restql_cache_requests_total", "Total number of cache lookups.", "cache", "result")
+
+	// RunnerGoroutines counts the goroutines spawned by the query runner workers.
+	RunnerGoroutines = NewCounter(Default, "restql_runner_goroutines_total", "Total number of goroutines spawned by the runner workers.")
+
+	// Goroutines reports the number of goroutines currently running.
+	Goroutines = NewGaugeFunc(Default, "restql_goroutines", "Number of goroutines that currently exist.", func() float64 {
+		return float64(runtime.NumGoroutine())
+	})
+)
@@ -71,11 +71,27 @@ func parseDetails(resource domain.DoneResource) map[string]interface{} {
 		"responseTime":    resource.ResponseTime,
 	}
 
+	if resource.Attempts > 0 {
+		debug["attempts"] = resource.Attempts
+	}
+
+	if resource.CacheStatus != "" {
+		debug["cache"] = resource.CacheStatus
+	}
+
 	metadata := make(map[string]interface{})
 	if resource.IgnoreErrors {
 		metadata["ignore-errors"] = true
 	}
 
+	if resource.Skipped {
+		metadata["skipped"] = true
+	}
+
+	if resource.ErrorKind != "" {
+		metadata["error-kind"] = string(resource.ErrorKind)
+	}
+
 	return map[string]interface{}{
 		"status":    resource.Status,
 		"success":   resource.Success,
@@ -0,0 +1,75 @@
+package plugins
+
+import (
+	"regexp"
+
+	"github.com/b2wdigital/restQL-golang/v4/pkg/restql"
+	"github.com/pkg/errors"
+)
+
+var functionName = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// builtinFunctions are the names reserved by
+// the functions provided by restQL itself.
+var builtinFunctions = map[string]bool{
+	"no-multiplex": true,
+	"base64":       true,
+	"json":         true,
+	"as-body":      true,
+	"flatten":      true,
+	"batch":        true,
+}
+
+// Functions indexes the function plugins by name.
+type Functions map[string]restql.FunctionPlugin
+
+// NewFunctions constructs a Functions instance with the registered
+// function plugins. Plugins with an invalid name, or with the name
+// of a built-in function or of another plugin, are discarded.
+func NewFunctions(log restql.Logger) Functions {
+	functions := make(Functions)
+
+	for _, p := range loadFunctionPlugins(log) {
+		name := p.Name()
+
+		switch {
+		case !functionName.MatchString(name):
+			log.Warn("function plugin discarded due to invalid name", "name", name)
+		case builtinFunctions[name]:
+			log.Warn("function plugin discarded due to built-in function with same name", "name", name)
+		case functions[name] != nil:
+			log.Warn("function plugin discarded due to another plugin with same name", "name", name)
+		default:
+			functions[name] = p
+		}
+	}
+
+	return functions
+}
+
+// Names returns the names of the available functions.
+func (f Functions) Names() []string {
+	names := make([]string, 0, len(f))
+	for name := range f {
+		names = append(names, name)
+	}
+
+	return names
+}
+
+// Apply encodes the value with the named function, returning
+// an error if the function is not found, fails or panics.
+func (f Functions) Apply(log restql.Logger, name string, value interface{}) (interface{}, error) {
+	fn, found := f[name]
+	if !found {
+		return nil, errors.Errorf("function %s not found", name)
+	}
+
+	var result interface{}
+	var err error
+	if panicErr := Protect(func() { result, err = fn.Apply(log, value) }); panicErr != nil {
+		return nil, errors.Wrapf(panicErr, "function %s produced a panic", name)
+	}
+
+	return result, err
+}
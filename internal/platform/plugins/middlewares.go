@@ -0,0 +1,27 @@
+package plugins
+
+import (
+	"github.com/b2wdigital/restQL-golang/v4/pkg/restql"
+)
+
+// NewMiddlewares returns the registered middleware plugins in
+// registration order. As they are configured by name, plugins
+// with the name of another plugin are discarded.
+func NewMiddlewares(log restql.Logger) []restql.MiddlewarePlugin {
+	var middlewares []restql.MiddlewarePlugin
+	names := make(map[string]bool)
+
+	for _, p := range loadMiddlewarePlugins(log) {
+		name := p.Name()
+
+		if names[name] {
+			log.Warn("middleware plugin discarded due to another plugin with same name", "name", name)
+			continue
+		}
+
+		names[name] = true
+		middlewares = append(middlewares, p)
+	}
+
+	return middlewares
+}
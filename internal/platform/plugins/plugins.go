@@ -20,6 +20,11 @@ type Lifecycle interface {
 	AfterQuery(ctx context.Context, query string, result domain.Resources) context.Context
 	BeforeRequest(ctx context.Context, request domain.HTTPRequest) context.Context
 	AfterRequest(ctx context.Context, request domain.HTTPRequest, response domain.HTTPResponse, err error) context.Context
+	InterceptRequest(ctx context.Context, request domain.HTTPRequest) (domain.HTTPRequest, error)
+	InterceptResponse(ctx context.Context, request domain.HTTPRequest, response domain.HTTPResponse) domain.HTTPResponse
+	BeforeStatement(ctx context.Context, statement restql.StatementInfo) context.Context
+	AfterStatement(ctx context.Context, statement restql.StatementInfo, result domain.DoneResource) context.Context
+	OnQueryError(ctx context.Context, query string, err error) context.Context
 }
 
 type pluginExecutor func(ctx context.Context, p restql.LifecyclePlugin) context.Context
@@ -27,6 +32,7 @@ type pluginExecutor func(ctx context.Context, p restql.LifecyclePlugin) context.
 type manager struct {
 	log              restql.Logger
 	availablePlugins []restql.LifecyclePlugin
+	interceptors     []restql.LifecyclePlugin
 }
 
 // NewLifecycle constructs a Lifecycle instance.
@@ -37,7 +43,18 @@ func NewLifecycle(log restql.Logger) (Lifecycle, error) {
 		return NoOpLifecycle, nil
 	}
 
-	return manager{log: log, availablePlugins: ps}, nil
+	return newManager(log, ps), nil
+}
+
+func newManager(log restql.Logger, ps []restql.LifecyclePlugin) manager {
+	var interceptors []restql.LifecyclePlugin
+	for _, p := range ps {
+		if _, ok := p.(restql.RequestInterceptor); ok {
+			interceptors = append(interceptors, p)
+		}
+	}
+
+	return manager{log: log, availablePlugins: ps, interceptors: interceptors}
 }
 
 func (m manager) BeforeTransaction(ctx context.Context, requestCtx *fasthttp.RequestCtx) context.Context {
@@ -79,6 +96,101 @@ func (m manager) AfterRequest(ctx context.Context, request domain.HTTPRequest, r
 		return p.AfterRequest(currentCtx, request, response, err)
 	})
 }
+
+func (m manager) BeforeStatement(ctx context.Context, statement restql.StatementInfo) context.Context {
+	return m.executeAllPluginsWithContext(ctx, "BeforeStatement", func(currentCtx context.Context, p restql.LifecyclePlugin) context.Context {
+		sl, ok := p.(restql.StatementLifecycle)
+		if !ok {
+			return currentCtx
+		}
+		return sl.BeforeStatement(currentCtx, statement)
+	})
+}
+
+func (m manager) AfterStatement(ctx context.Context, statement restql.StatementInfo, result domain.DoneResource) context.Context {
+	return m.executeAllPluginsWithContext(ctx, "AfterStatement", func(currentCtx context.Context, p restql.LifecyclePlugin) context.Context {
+		sl, ok := p.(restql.StatementLifecycle)
+		if !ok {
+			return currentCtx
+		}
+		return sl.AfterStatement(currentCtx, statement, result)
+	})
+}
+
+func (m manager) OnQueryError(ctx context.Context, query string, err error) context.Context {
+	return m.executeAllPluginsWithContext(ctx, "OnQueryError", func(currentCtx context.Context, p restql.LifecyclePlugin) context.Context {
+		qe, ok := p.(restql.QueryErrorHandler)
+		if !ok {
+			return currentCtx
+		}
+		return qe.OnQueryError(currentCtx, query, err)
+	})
+}
+
+func (m manager) InterceptRequest(ctx context.Context, request domain.HTTPRequest) (domain.HTTPRequest, error) {
+	if len(m.interceptors) == 0 {
+		return request, nil
+	}
+
+	log := restql.GetLogger(ctx)
+
+	// Headers and query are shared with other requests
+	// of the statement, so plugins receive a copy of them.
+	request = copyRequest(request)
+	for _, p := range m.interceptors {
+		interceptor := p.(restql.RequestInterceptor)
+
+		var err error
+		m.safeExecute(log, p.Name(), "InterceptRequest", func() {
+			var intercepted domain.HTTPRequest
+			intercepted, err = interceptor.InterceptRequest(ctx, request)
+			if err == nil {
+				request = intercepted
+			}
+		})
+
+		if err != nil {
+			return request, errors.Wrapf(err, "plugin %s failed to intercept request", p.Name())
+		}
+	}
+
+	return request, nil
+}
+
+func (m manager) InterceptResponse(ctx context.Context, request domain.HTTPRequest, response domain.HTTPResponse) domain.HTTPResponse {
+	log := restql.GetLogger(ctx)
+
+	for _, p := range m.interceptors {
+		interceptor := p.(restql.RequestInterceptor)
+
+		m.safeExecute(log, p.Name(), "InterceptResponse", func() {
+			response = interceptor.InterceptResponse(ctx, request, response)
+		})
+	}
+
+	return response
+}
+
+func copyRequest(request domain.HTTPRequest) domain.HTTPRequest {
+	if request.Headers != nil {
+		headers := make(domain.Headers, len(request.Headers))
+		for k, v := range request.Headers {
+			headers[k] = v
+		}
+		request.Headers = headers
+	}
+
+	if request.Query != nil {
+		query := make(map[string]interface{}, len(request.Query))
+		for k, v := range request.Query {
+			query[k] = v
+		}
+		request.Query = query
+	}
+
+	return request
+}
+
 func (m manager) executeAllPluginsWithContext(ctx context.Context, hook string, fn pluginExecutor) context.Context {
 	log := restql.GetLogger(ctx)
 
@@ -95,14 +207,23 @@ func (m manager) executeAllPluginsWithContext(ctx context.Context, hook string,
 }
 
 func (m manager) safeExecute(log restql.Logger, pluginName string, hook string, fn func()) {
+	if err := Protect(fn); err != nil {
+		log.Error("plugin produced a panic", err, "name", pluginName, "hook", hook)
+	}
+}
+
+// Protect executes the function recovering from panics, as the
+// plugin code is not under restQL control. The panic is returned
+// as an error with its reason and stack.
+func Protect(fn func()) (err error) {
 	defer func() {
 		if reason := recover(); reason != nil {
-			err := errors.Errorf("reason : %v\n\t stack : %v", reason, string(debug.Stack()))
-			log.Error("plugin produced a panic", err, "name", pluginName, "hook", hook)
+			err = errors.Errorf("reason : %v\n\t stack : %v", reason, string(debug.Stack()))
 		}
 	}()
 
 	fn()
+	return nil
 }
 
 func (m manager) newTransactionRequest(log restql.Logger, ctx *fasthttp.RequestCtx) restql.TransactionRequest {
@@ -161,3 +282,18 @@ func (n noOpLifecycle) BeforeRequest(ctx context.Context, request domain.HTTPReq
 func (n noOpLifecycle) AfterRequest(ctx context.Context, request domain.HTTPRequest, response domain.HTTPResponse, err error) context.Context {
 	return ctx
 }
+func (n noOpLifecycle) BeforeStatement(ctx context.Context, statement restql.StatementInfo) context.Context {
+	return ctx
+}
+func (n noOpLifecycle) AfterStatement(ctx context.Context, statement restql.StatementInfo, result domain.DoneResource) context.Context {
+	return ctx
+}
+func (n noOpLifecycle) OnQueryError(ctx context.Context, query string, err error) context.Context {
+	return ctx
+}
+func (n noOpLifecycle) InterceptRequest(ctx context.Context, request domain.HTTPRequest) (domain.HTTPRequest, error) {
+	return request, nil
+}
+func (n noOpLifecycle) InterceptResponse(ctx context.Context, request domain.HTTPRequest, response domain.HTTPResponse) domain.HTTPResponse {
+	return response
+}
@@ -0,0 +1,138 @@
+package plugins
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/b2wdigital/restQL-golang/v4/internal/domain"
+	"github.com/b2wdigital/restQL-golang/v4/pkg/restql"
+	"github.com/b2wdigital/restQL-golang/v4/test"
+)
+
+var errSigning = errors.New("signing failed")
+
+type lifecyclePlugin struct {
+	name string
+}
+
+func (lp lifecyclePlugin) Name() string { return lp.name }
+func (lp lifecyclePlugin) BeforeTransaction(ctx context.Context, tr restql.TransactionRequest) context.Context {
+	return ctx
+}
+func (lp lifecyclePlugin) AfterTransaction(ctx context.Context, tr restql.TransactionResponse) context.Context {
+	return ctx
+}
+func (lp lifecyclePlugin) BeforeQuery(ctx context.Context, query string, queryCtx restql.QueryContext) context.Context {
+	return ctx
+}
+func (lp lifecyclePlugin) AfterQuery(ctx context.Context, query string, result map[string]interface{}) context.Context {
+	return ctx
+}
+func (lp lifecyclePlugin) BeforeRequest(ctx context.Context, request restql.HttpRequest) context.Context {
+	return ctx
+}
+func (lp lifecyclePlugin) AfterRequest(ctx context.Context, request restql.HttpRequest, response restql.HttpResponse, err error) context.Context {
+	return ctx
+}
+
+// interceptorPlugin sets a header in the request and
+// the response, failing or panicking when asked to.
+type interceptorPlugin struct {
+	lifecyclePlugin
+	header string
+	value  string
+	err    error
+	panics bool
+}
+
+func (ip interceptorPlugin) InterceptRequest(ctx context.Context, request restql.HttpRequest) (restql.HttpRequest, error) {
+	if ip.panics {
+		panic("interceptor panic")
+	}
+	if ip.err != nil {
+		return restql.HttpRequest{}, ip.err
+	}
+
+	request.Headers[ip.header] = ip.value
+	request.Host = ip.value + "." + request.Host
+	return request, nil
+}
+
+func (ip interceptorPlugin) InterceptResponse(ctx context.Context, request restql.HttpRequest, response restql.HttpResponse) restql.HttpResponse {
+	if ip.panics {
+		panic("interceptor panic")
+	}
+
+	response.Headers = domain.Headers{ip.header: request.Headers[ip.header]}
+	return response
+}
+
+func TestRequestInterceptor(t *testing.T) {
+	tests := []struct {
+		name             string
+		plugins          []restql.LifecyclePlugin
+		expectedRequest  domain.HTTPRequest
+		expectedResponse domain.HTTPResponse
+		expectedErr      error
+	}{
+		{
+			"should keep request and response without interceptors",
+			[]restql.LifecyclePlugin{lifecyclePlugin{name: "lifecycle"}},
+			domain.HTTPRequest{Host: "hero.api", Headers: domain.Headers{"X-Tid": "1"}},
+			domain.HTTPResponse{StatusCode: 200},
+			nil,
+		},
+		{
+			"should chain interceptors in registration order",
+			[]restql.LifecyclePlugin{
+				interceptorPlugin{lifecyclePlugin: lifecyclePlugin{name: "auth"}, header: "Authorization", value: "token"},
+				lifecyclePlugin{name: "lifecycle"},
+				interceptorPlugin{lifecyclePlugin: lifecyclePlugin{name: "region"}, header: "X-Region", value: "us"},
+			},
+			domain.HTTPRequest{Host: "us.token.hero.api", Headers: domain.Headers{"X-Tid": "1", "Authorization": "token", "X-Region": "us"}},
+			domain.HTTPResponse{StatusCode: 200, Headers: domain.Headers{"X-Region": "us"}},
+			nil,
+		},
+		{
+			"should ignore interceptor that panics",
+			[]restql.LifecyclePlugin{
+				interceptorPlugin{lifecyclePlugin: lifecyclePlugin{name: "broken"}, panics: true},
+				interceptorPlugin{lifecyclePlugin: lifecyclePlugin{name: "auth"}, header: "Authorization", value: "token"},
+			},
+			domain.HTTPRequest{Host: "token.hero.api", Headers: domain.Headers{"X-Tid": "1", "Authorization": "token"}},
+			domain.HTTPResponse{StatusCode: 200, Headers: domain.Headers{"Authorization": "token"}},
+			nil,
+		},
+		{
+			"should fail when interceptor returns an error",
+			[]restql.LifecyclePlugin{
+				interceptorPlugin{lifecyclePlugin: lifecyclePlugin{name: "signer"}, err: errSigning},
+			},
+			domain.HTTPRequest{Host: "hero.api", Headers: domain.Headers{"X-Tid": "1"}},
+			domain.HTTPResponse{},
+			errSigning,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lifecycle := newManager(test.NoOpLogger{}, tt.plugins)
+			ctx := restql.WithLogger(context.Background(), test.NoOpLogger{})
+
+			headers := domain.Headers{"X-Tid": "1"}
+			request, err := lifecycle.InterceptRequest(ctx, domain.HTTPRequest{Host: "hero.api", Headers: headers})
+
+			test.Equal(t, errors.Is(err, tt.expectedErr), true)
+			test.Equal(t, request, tt.expectedRequest)
+			test.Equal(t, headers, domain.Headers{"X-Tid": "1"})
+
+			if err != nil {
+				return
+			}
+
+			response := lifecycle.InterceptResponse(ctx, request, domain.HTTPResponse{StatusCode: 200})
+			test.Equal(t, response, tt.expectedResponse)
+		})
+	}
+}
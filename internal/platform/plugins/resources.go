@@ -0,0 +1,47 @@
+package plugins
+
+import (
+	"context"
+
+	"github.com/b2wdigital/restQL-golang/v4/pkg/restql"
+	"github.com/pkg/errors"
+)
+
+// Resources indexes the resource plugins by name.
+type Resources map[string]restql.ResourcePlugin
+
+// NewResources constructs a Resources instance with the registered
+// resource plugins. Plugins with the name of another plugin are discarded.
+func NewResources(log restql.Logger) Resources {
+	resources := make(Resources)
+
+	for _, p := range loadResourcePlugins(log) {
+		name := p.Name()
+
+		if resources[name] != nil {
+			log.Warn("resource plugin discarded due to another plugin with same name", "name", name)
+			continue
+		}
+
+		resources[name] = p
+	}
+
+	return resources
+}
+
+// Resolve executes the request with the named resource plugin, returning
+// an error if the plugin is not found, fails or panics.
+func (r Resources) Resolve(ctx context.Context, name string, request restql.ResourceRequest) (restql.ResourceResponse, error) {
+	resource, found := r[name]
+	if !found {
+		return restql.ResourceResponse{}, errors.Errorf("resource plugin %s not found", name)
+	}
+
+	var response restql.ResourceResponse
+	var err error
+	if panicErr := Protect(func() { response, err = resource.Resolve(ctx, request) }); panicErr != nil {
+		return restql.ResourceResponse{}, errors.Wrapf(panicErr, "resource plugin %s produced a panic", name)
+	}
+
+	return response, err
+}
@@ -25,3 +25,66 @@ func loadLifecyclePlugins(logger restql.Logger) []restql.LifecyclePlugin {
 	}
 	return ps
 }
+
+func loadFunctionPlugins(logger restql.Logger) []restql.FunctionPlugin {
+	var ps []restql.FunctionPlugin
+	for _, pluginInfo := range restql.GetFunctionPlugins() {
+		p, err := pluginInfo.New(logger)
+		if err != nil {
+			logger.Error("failed to load plugin", err)
+			continue
+		}
+
+		pluginInstance, ok := p.(restql.FunctionPlugin)
+		if !ok {
+			logger.Error("failed to load plugin", errors.Errorf("plugin of incorrect type: %T", p))
+			continue
+		}
+
+		logger.Debug("plugin loaded", "name", pluginInstance.Name())
+		ps = append(ps, pluginInstance)
+	}
+	return ps
+}
+
+func loadResourcePlugins(logger restql.Logger) []restql.ResourcePlugin {
+	var ps []restql.ResourcePlugin
+	for _, pluginInfo := range restql.GetResourcePlugins() {
+		p, err := pluginInfo.New(logger)
+		if err != nil {
+			logger.Error("failed to load plugin", err)
+			continue
+		}
+
+		pluginInstance, ok := p.(restql.ResourcePlugin)
+		if !ok {
+			logger.Error("failed to load plugin", errors.Errorf("plugin of incorrect type: %T", p))
+			continue
+		}
+
+		logger.Debug("plugin loaded", "name", pluginInstance.Name())
+		ps = append(ps, pluginInstance)
+	}
+	return ps
+}
+
+func loadMiddlewarePlugins(logger restql.Logger) []restql.MiddlewarePlugin {
+	var ps []restql.MiddlewarePlugin
+	for _, pluginInfo := range restql.GetMiddlewarePlugins() {
+		p, err := pluginInfo.New(logger)
+		if err != nil {
+			logger.Error("failed to load plugin", err)
+			continue
+		}
+
+		pluginInstance, ok := p.(restql.MiddlewarePlugin)
+		if !ok {
+			logger.Error("failed to load plugin", errors.Errorf("plugin of incorrect type: %T", p))
+			continue
+		}
+
+		logger.Debug("plugin loaded", "name", pluginInstance.Name())
+		ps = append(ps, pluginInstance)
+	}
+	return ps
+}
@@ -0,0 +1,168 @@
+package tracing
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const (
+	defaultBatchSize     = 512
+	defaultFlushInterval = 5 * time.Second
+	maxQueueSize         = 8192
+)
+
+// Exporter sends finished spans to a tracing backend.
+type Exporter interface {
+	Export(ctx context.Context, serviceName string, spans []*Span) error
+	Shutdown(ctx context.Context) error
+}
+
+// ErrorHandler receives the errors that happened
+// while exporting spans in background.
+type ErrorHandler func(err error)
+
+// batchProcessor buffers finished spans and exports them when
+// the batch is full or the flush interval elapses. Spans are
+// dropped when the queue is full, so the tracing never blocks
+// the query execution.
+type batchProcessor struct {
+	exporter      Exporter
+	serviceName   string
+	batchSize     int
+	flushInterval time.Duration
+	onError       ErrorHandler
+
+	queue chan *Span
+	flush chan chan struct{}
+	stop  chan struct{}
+	done  chan struct{}
+	once  sync.Once
+}
+
+func newBatchProcessor(exporter Exporter, options Options) *batchProcessor {
+	batchSize := options.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultBatchSize
+	}
+
+	flushInterval := options.FlushInterval
+	if flushInterval <= 0 {
+		flushInterval = defaultFlushInterval
+	}
+
+	onError := options.OnError
+	if onError == nil {
+		onError = func(err error) {}
+	}
+
+	bp := &batchProcessor{
+		exporter:      exporter,
+		serviceName:   options.ServiceName,
+		batchSize:     batchSize,
+		flushInterval: flushInterval,
+		onError:       onError,
+		queue:         make(chan *Span, maxQueueSize),
+		flush:         make(chan chan struct{}),
+		stop:          make(chan struct{}),
+		done:          make(chan struct{}),
+	}
+
+	go bp.run()
+
+	return bp
+}
+
+func (bp *batchProcessor) enqueue(span *Span) {
+	select {
+	case bp.queue <- span:
+	default:
+	}
+}
+
+func (bp *batchProcessor) run() {
+	defer close(bp.done)
+
+	ticker := time.NewTicker(bp.flushInterval)
+	defer ticker.Stop()
+
+	batch := make([]*Span, 0, bp.batchSize)
+	export := func() {
+		if len(batch) == 0 {
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), bp.flushInterval)
+		if err := bp.exporter.Export(ctx, bp.serviceName, batch); err != nil {
+			bp.onError(err)
+		}
+		cancel()
+
+		batch = make([]*Span, 0, bp.batchSize)
+	}
+
+	drain := func() {
+		for {
+			select {
+			case span := <-bp.queue:
+				batch = append(batch, span)
+				if len(batch) >= bp.batchSize {
+					export()
+				}
+			default:
+				export()
+				return
+			}
+		}
+	}
+
+	for {
+		select {
+		case span := <-bp.queue:
+			batch = append(batch, span)
+			if len(batch) >= bp.batchSize {
+				export()
+			}
+		case <-ticker.C:
+			export()
+		case ack := <-bp.flush:
+			drain()
+			close(ack)
+		case <-bp.stop:
+			drain()
+			return
+		}
+	}
+}
+
+// forceFlush exports all queued spans, waiting for it to finish.
+func (bp *batchProcessor) forceFlush(ctx context.Context) error {
+	ack := make(chan struct{})
+
+	select {
+	case bp.flush <- ack:
+	case <-bp.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	select {
+	case <-ack:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (bp *batchProcessor) shutdown(ctx context.Context) error {
+	bp.once.Do(func() { close(bp.stop) })
+
+	select {
+	case <-bp.done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	return bp.exporter.Shutdown(ctx)
+}
@@ -0,0 +1,86 @@
+package tracing_test
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/b2wdigital/restQL-golang/v4/internal/platform/tracing"
+	"github.com/b2wdigital/restQL-golang/v4/test"
+	"github.com/pkg/errors"
+)
+
+func TestOTLPExporter(t *testing.T) {
+	t.Run("should post spans to the collector", func(t *testing.T) {
+		received := make(chan map[string]interface{}, 1)
+		collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			test.Equal(t, r.Method, http.MethodPost)
+			test.Equal(t, r.URL.Path, "/v1/traces")
+			test.Equal(t, r.Header.Get("Content-Type"), "application/json")
+
+			body, _ := ioutil.ReadAll(r.Body)
+			var payload map[string]interface{}
+			_ = json.Unmarshal(body, &payload)
+			received <- payload
+
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer collector.Close()
+
+		exporter := tracing.NewOTLPExporter(collector.URL+"/v1/traces", time.Second)
+		tracer := tracing.NewTracer(exporter, tracing.Options{ServiceName: "restql"})
+
+		remote, _ := tracing.ParseTraceparent("00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01", "")
+		ctx := tracing.WithRemoteSpanContext(context.Background(), remote)
+		_, span := tracer.Start(ctx, "restql.request hero", tracing.SpanKindClient)
+		span.SetAttribute("restql.resource", "hero")
+		span.SetAttribute("http.status_code", 500)
+		span.SetError(errors.New("upstream failed"))
+		span.Finish()
+
+		err := tracer.Shutdown(context.Background())
+		test.VerifyError(t, err)
+
+		payload := <-received
+		resourceSpans := payload["resourceSpans"].([]interface{})[0].(map[string]interface{})
+
+		resource := resourceSpans["resource"].(map[string]interface{})
+		test.Equal(t, resource["attributes"], []interface{}{
+			map[string]interface{}{"key": "service.name", "value": map[string]interface{}{"stringValue": "restql"}},
+		})
+
+		scopeSpans := resourceSpans["scopeSpans"].([]interface{})[0].(map[string]interface{})
+		exported := scopeSpans["spans"].([]interface{})[0].(map[string]interface{})
+
+		test.Equal(t, exported["traceId"], "0af7651916cd43dd8448eb211c80319c")
+		test.Equal(t, exported["spanId"], span.Context.SpanID.String())
+		test.Equal(t, exported["parentSpanId"], "b7ad6b7169203331")
+		test.Equal(t, exported["name"], "restql.request hero")
+		test.Equal(t, exported["kind"], float64(3))
+		test.Equal(t, exported["attributes"], []interface{}{
+			map[string]interface{}{"key": "http.status_code", "value": map[string]interface{}{"intValue": "500"}},
+			map[string]interface{}{"key": "restql.resource", "value": map[string]interface{}{"stringValue": "hero"}},
+		})
+		test.Equal(t, exported["status"], map[string]interface{}{"code": float64(2), "message": "upstream failed"})
+	})
+
+	t.Run("should return error when collector fails", func(t *testing.T) {
+		collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer collector.Close()
+
+		exporter := tracing.NewOTLPExporter(collector.URL, time.Second)
+		tracer := tracing.NewTracer(&spanRecorder{}, tracing.Options{})
+		_, span := tracer.Start(context.Background(), "span", tracing.SpanKindInternal)
+		span.Finish()
+
+		err := exporter.Export(context.Background(), "restql", []*tracing.Span{span})
+
+		test.Equal(t, err != nil, true)
+	})
+}
@@ -0,0 +1,341 @@
+// Package tracing implements the W3C Trace Context propagation
+// and a lightweight span recorder with pluggable exporters.
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Trace context HTTP headers, as defined by W3C.
+const (
+	TraceparentHeader = "traceparent"
+	TracestateHeader  = "tracestate"
+)
+
+const sampledFlag = 0x01
+
+// TraceID identifies a trace.
+type TraceID [16]byte
+
+// SpanID identifies a span inside a trace.
+type SpanID [8]byte
+
+func (t TraceID) String() string { return hex.EncodeToString(t[:]) }
+func (s SpanID) String() string  { return hex.EncodeToString(s[:]) }
+
+// IsValid tells if the trace id is not all zeros.
+func (t TraceID) IsValid() bool { return t != TraceID{} }
+
+// IsValid tells if the span id is not all zeros.
+func (s SpanID) IsValid() bool { return s != SpanID{} }
+
+// SpanContext is the part of a span propagated to other services.
+type SpanContext struct {
+	TraceID    TraceID
+	SpanID     SpanID
+	Flags      byte
+	TraceState string
+}
+
+// IsValid tells if both trace and span ids are set.
+func (sc SpanContext) IsValid() bool {
+	return sc.TraceID.IsValid() && sc.SpanID.IsValid()
+}
+
+// IsSampled tells if the trace should be recorded.
+func (sc SpanContext) IsSampled() bool {
+	return sc.Flags&sampledFlag == sampledFlag
+}
+
+// Traceparent formats the span context as a traceparent header value.
+func (sc SpanContext) Traceparent() string {
+	return fmt.Sprintf("00-%s-%s-%02x", sc.TraceID, sc.SpanID, sc.Flags)
+}
+
+// ParseTraceparent reads a span context from the traceparent
+// and tracestate header values, returning false when the
+// traceparent is malformed.
+func ParseTraceparent(traceparent, tracestate string) (SpanContext, bool) {
+	parts := strings.Split(strings.TrimSpace(traceparent), "-")
+	if len(parts) < 4 {
+		return SpanContext{}, false
+	}
+
+	version, traceID, spanID, flags := parts[0], parts[1], parts[2], parts[3]
+	if len(version) != 2 || version == "ff" || (version == "00" && len(parts) != 4) {
+		return SpanContext{}, false
+	}
+	if len(traceID) != 32 || len(spanID) != 16 || len(flags) != 2 {
+		return SpanContext{}, false
+	}
+	if _, err := hex.DecodeString(version); err != nil {
+		return SpanContext{}, false
+	}
+
+	var sc SpanContext
+	if _, err := hex.Decode(sc.TraceID[:], []byte(traceID)); err != nil || strings.ToLower(traceID) != traceID {
+		return SpanContext{}, false
+	}
+	if _, err := hex.Decode(sc.SpanID[:], []byte(spanID)); err != nil || strings.ToLower(spanID) != spanID {
+		return SpanContext{}, false
+	}
+
+	var f [1]byte
+	if _, err := hex.Decode(f[:], []byte(flags)); err != nil {
+		return SpanContext{}, false
+	}
+	sc.Flags = f[0]
+
+	if !sc.IsValid() {
+		return SpanContext{}, false
+	}
+
+	sc.TraceState = strings.TrimSpace(tracestate)
+
+	return sc, true
+}
+
+// SpanKind describes the relationship of the span with other services.
+type SpanKind int
+
+// Span kinds, following the OpenTelemetry values.
+const (
+	SpanKindInternal SpanKind = 1
+	SpanKindServer   SpanKind = 2
+	SpanKindClient   SpanKind = 3
+)
+
+// Span records a unit of work of a trace.
+// All methods are safe to call on a nil Span,
+// which is returned when tracing is disabled.
+type Span struct {
+	tracer *Tracer
+
+	Name       string
+	Kind       SpanKind
+	Context    SpanContext
+	ParentID   SpanID
+	Start      time.Time
+	End        time.Time
+	Attributes map[string]interface{}
+	Err        string
+
+	mu    sync.Mutex
+	ended bool
+}
+
+// SetAttribute adds a key-value pair to the span.
+func (s *Span) SetAttribute(key string, value interface{}) {
+	if s == nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.Attributes[key] = value
+}
+
+// SetError marks the span as failed.
+func (s *Span) SetError(err error) {
+	if s == nil || err == nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.Err = err.Error()
+}
+
+// Finish ends the span, sending it to the exporter when sampled.
+func (s *Span) Finish() {
+	if s == nil {
+		return
+	}
+
+	s.mu.Lock()
+	if s.ended {
+		s.mu.Unlock()
+		return
+	}
+	s.ended = true
+	s.End = time.Now()
+	s.mu.Unlock()
+
+	if s.Context.IsSampled() {
+		s.tracer.processor.enqueue(s)
+	}
+}
+
+// Options represents the parameters of a Tracer.
+// ServiceName identifies restQL in the exported spans,
+// while BatchSize and FlushInterval control how often
+// spans are exported. OnError receives export failures.
+type Options struct {
+	ServiceName   string
+	BatchSize     int
+	FlushInterval time.Duration
+	OnError       ErrorHandler
+}
+
+// Tracer creates spans and delivers the finished ones to an exporter.
+type Tracer struct {
+	processor *batchProcessor
+}
+
+// NewTracer constructs a Tracer exporting the spans in batches.
+func NewTracer(exporter Exporter, options Options) *Tracer {
+	return &Tracer{processor: newBatchProcessor(exporter, options)}
+}
+
+// ForceFlush exports all finished spans, waiting for it to complete.
+func (t *Tracer) ForceFlush(ctx context.Context) error {
+	if t == nil {
+		return nil
+	}
+
+	return t.processor.forceFlush(ctx)
+}
+
+// Shutdown exports the pending spans and stops the tracer.
+func (t *Tracer) Shutdown(ctx context.Context) error {
+	if t == nil {
+		return nil
+	}
+
+	return t.processor.shutdown(ctx)
+}
+
+// Start creates a span as a child of the span or the remote
+// span context present in the given context. Without parent
+// a new sampled trace is started.
+func (t *Tracer) Start(ctx context.Context, name string, kind SpanKind) (context.Context, *Span) {
+	if t == nil {
+		return ctx, nil
+	}
+
+	parent, hasParent := SpanContextFromContext(ctx)
+
+	sc := SpanContext{SpanID: newSpanID(), Flags: sampledFlag}
+	if hasParent {
+		sc.TraceID = parent.TraceID
+		sc.Flags = parent.Flags
+		sc.TraceState = parent.TraceState
+	} else {
+		sc.TraceID = newTraceID()
+	}
+
+	span := &Span{
+		tracer:     t,
+		Name:       name,
+		Kind:       kind,
+		Context:    sc,
+		Start:      time.Now(),
+		Attributes: make(map[string]interface{}),
+	}
+	if hasParent {
+		span.ParentID = parent.SpanID
+	}
+
+	return context.WithValue(ctx, spanCtxKey{}, span), span
+}
+
+type spanCtxKey struct{}
+type remoteCtxKey struct{}
+
+// WithRemoteSpanContext stores a span context received
+// from another service, to be used as parent of new spans.
+func WithRemoteSpanContext(ctx context.Context, sc SpanContext) context.Context {
+	return context.WithValue(ctx, remoteCtxKey{}, sc)
+}
+
+// SpanFromContext returns the current span, if any.
+func SpanFromContext(ctx context.Context) *Span {
+	span, _ := ctx.Value(spanCtxKey{}).(*Span)
+	return span
+}
+
+// SpanContextFromContext returns the span context of the current
+// span or, when there is none, the remote span context.
+func SpanContextFromContext(ctx context.Context) (SpanContext, bool) {
+	if span := SpanFromContext(ctx); span != nil {
+		return span.Context, true
+	}
+
+	sc, ok := ctx.Value(remoteCtxKey{}).(SpanContext)
+	return sc, ok && sc.IsValid()
+}
+
+// Inject returns a copy of the headers with the trace context
+// of the current span, or the given headers if there is none.
+func Inject(ctx context.Context, headers map[string]string) map[string]string {
+	sc, ok := SpanContextFromContext(ctx)
+	if !ok {
+		return headers
+	}
+
+	result := make(map[string]string, len(headers)+2)
+	for k, v := range headers {
+		if strings.EqualFold(k, TraceparentHeader) || strings.EqualFold(k, TracestateHeader) {
+			continue
+		}
+		result[k] = v
+	}
+
+	result[TraceparentHeader] = sc.Traceparent()
+	if sc.TraceState != "" {
+		result[TracestateHeader] = sc.TraceState
+	}
+
+	return result
+}
+
+var (
+	defaultMu     sync.RWMutex
+	defaultTracer *Tracer
+)
+
+// SetDefault sets the tracer used by the package level functions.
+// A nil tracer disables tracing.
+func SetDefault(t *Tracer) {
+	defaultMu.Lock()
+	defer defaultMu.Unlock()
+
+	defaultTracer = t
+}
+
+// Default returns the tracer used by the package level functions.
+func Default() *Tracer {
+	defaultMu.RLock()
+	defer defaultMu.RUnlock()
+
+	return defaultTracer
+}
+
+// Start creates a span with the default tracer.
+func Start(ctx context.Context, name string, kind SpanKind) (context.Context, *Span) {
+	return Default().Start(ctx, name, kind)
+}
+
+func newTraceID() TraceID {
+	var id TraceID
+	for !id.IsValid() {
+		_, _ = rand.Read(id[:])
+	}
+	return id
+}
+
+func newSpanID() SpanID {
+	var id SpanID
+	for !id.IsValid() {
+		_, _ = rand.Read(id[:])
+	}
+	return id
+}
@@ -0,0 +1,172 @@
+package tracing_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/b2wdigital/restQL-golang/v4/internal/platform/tracing"
+	"github.com/b2wdigital/restQL-golang/v4/test"
+)
+
+type spanRecorder struct {
+	services []string
+	spans    []*tracing.Span
+}
+
+func (r *spanRecorder) Export(ctx context.Context, serviceName string, spans []*tracing.Span) error {
+	r.services = append(r.services, serviceName)
+	r.spans = append(r.spans, spans...)
+	return nil
+}
+
+func (r *spanRecorder) Shutdown(ctx context.Context) error { return nil }
+
+func TestParseTraceparent(t *testing.T) {
+	tests := []struct {
+		name        string
+		traceparent string
+		tracestate  string
+		expected    string
+		ok          bool
+	}{
+		{
+			"should parse valid sampled traceparent",
+			"00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01",
+			"vendor=value",
+			"00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01",
+			true,
+		},
+		{
+			"should parse valid not sampled traceparent",
+			"00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-00",
+			"",
+			"00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-00",
+			true,
+		},
+		{
+			"should accept future versions with extra fields",
+			"01-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01-extra",
+			"",
+			"00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01",
+			true,
+		},
+		{"should reject empty value", "", "", "", false},
+		{"should reject invalid version", "ff-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01", "", "", false},
+		{"should reject extra fields on version 00", "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01-extra", "", "", false},
+		{"should reject all zeros trace id", "00-00000000000000000000000000000000-b7ad6b7169203331-01", "", "", false},
+		{"should reject all zeros span id", "00-0af7651916cd43dd8448eb211c80319c-0000000000000000-01", "", "", false},
+		{"should reject upper case hex", "00-0AF7651916CD43DD8448EB211C80319C-b7ad6b7169203331-01", "", "", false},
+		{"should reject short trace id", "00-0af7651916cd43dd-b7ad6b7169203331-01", "", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sc, ok := tracing.ParseTraceparent(tt.traceparent, tt.tracestate)
+
+			test.Equal(t, ok, tt.ok)
+			if ok {
+				test.Equal(t, sc.Traceparent(), tt.expected)
+				test.Equal(t, sc.TraceState, tt.tracestate)
+			}
+		})
+	}
+}
+
+func TestTracerStart(t *testing.T) {
+	t.Run("should start a new sampled trace without parent", func(t *testing.T) {
+		recorder := &spanRecorder{}
+		tracer := tracing.NewTracer(recorder, tracing.Options{ServiceName: "restql"})
+
+		_, span := tracer.Start(context.Background(), "root", tracing.SpanKindServer)
+		span.Finish()
+
+		err := tracer.Shutdown(context.Background())
+		test.VerifyError(t, err)
+
+		test.Equal(t, len(recorder.spans), 1)
+		test.Equal(t, recorder.services, []string{"restql"})
+		test.Equal(t, recorder.spans[0].Context.IsValid(), true)
+		test.Equal(t, recorder.spans[0].Context.IsSampled(), true)
+		test.Equal(t, recorder.spans[0].ParentID.IsValid(), false)
+	})
+
+	t.Run("should continue remote trace and nest child spans", func(t *testing.T) {
+		recorder := &spanRecorder{}
+		tracer := tracing.NewTracer(recorder, tracing.Options{ServiceName: "restql"})
+
+		remote, _ := tracing.ParseTraceparent("00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01", "vendor=value")
+		ctx := tracing.WithRemoteSpanContext(context.Background(), remote)
+
+		ctx, parent := tracer.Start(ctx, "parent", tracing.SpanKindServer)
+		_, child := tracer.Start(ctx, "child", tracing.SpanKindClient)
+		child.SetAttribute("http.status_code", 200)
+		child.Finish()
+		parent.Finish()
+
+		err := tracer.Shutdown(context.Background())
+		test.VerifyError(t, err)
+
+		test.Equal(t, len(recorder.spans), 2)
+		test.Equal(t, child.Context.TraceID, remote.TraceID)
+		test.Equal(t, child.Context.TraceState, "vendor=value")
+		test.Equal(t, child.ParentID, parent.Context.SpanID)
+		test.Equal(t, parent.ParentID, remote.SpanID)
+		test.Equal(t, child.Attributes["http.status_code"], 200)
+	})
+
+	t.Run("should not export spans of not sampled traces", func(t *testing.T) {
+		recorder := &spanRecorder{}
+		tracer := tracing.NewTracer(recorder, tracing.Options{})
+
+		remote, _ := tracing.ParseTraceparent("00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-00", "")
+		ctx := tracing.WithRemoteSpanContext(context.Background(), remote)
+
+		_, span := tracer.Start(ctx, "span", tracing.SpanKindServer)
+		span.Finish()
+
+		err := tracer.Shutdown(context.Background())
+		test.VerifyError(t, err)
+
+		test.Equal(t, len(recorder.spans), 0)
+	})
+
+	t.Run("should do nothing when tracer is nil", func(t *testing.T) {
+		var tracer *tracing.Tracer
+
+		ctx, span := tracer.Start(context.Background(), "span", tracing.SpanKindServer)
+		span.SetAttribute("key", "value")
+		span.Finish()
+
+		test.Equal(t, tracing.SpanFromContext(ctx) == nil, true)
+	})
+}
+
+func TestInject(t *testing.T) {
+	t.Run("should return headers untouched without trace context", func(t *testing.T) {
+		headers := map[string]string{"X-Custom": "value"}
+
+		got := tracing.Inject(context.Background(), headers)
+
+		test.Equal(t, got, map[string]string{"X-Custom": "value"})
+	})
+
+	t.Run("should add the current span as parent on a copy of the headers", func(t *testing.T) {
+		tracer := tracing.NewTracer(&spanRecorder{}, tracing.Options{})
+		defer tracer.Shutdown(context.Background())
+
+		remote, _ := tracing.ParseTraceparent("00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01", "vendor=value")
+		ctx := tracing.WithRemoteSpanContext(context.Background(), remote)
+		ctx, span := tracer.Start(ctx, "request", tracing.SpanKindClient)
+
+		headers := map[string]string{"X-Custom": "value", "Traceparent": "stale"}
+		got := tracing.Inject(ctx, headers)
+
+		expected := map[string]string{
+			"X-Custom":    "value",
+			"traceparent": "00-0af7651916cd43dd8448eb211c80319c-" + span.Context.SpanID.String() + "-01",
+			"tracestate":  "vendor=value",
+		}
+		test.Equal(t, got, expected)
+		test.Equal(t, headers, map[string]string{"X-Custom": "value", "Traceparent": "stale"})
+	})
+}
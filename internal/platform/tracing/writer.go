@@ -0,0 +1,128 @@
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// WriterExporter writes each span as a JSON line to an io.Writer,
+// such as the standard output or a file.
+type WriterExporter struct {
+	mu     sync.Mutex
+	w      io.Writer
+	closer io.Closer
+}
+
+// NewWriterExporter constructs a WriterExporter for the given writer.
+func NewWriterExporter(w io.Writer) *WriterExporter {
+	return &WriterExporter{w: w}
+}
+
+// NewFileExporter constructs a WriterExporter appending to the given file.
+func NewFileExporter(path string) (*WriterExporter, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open spans file")
+	}
+
+	return &WriterExporter{w: f, closer: f}, nil
+}
+
+type writerSpan struct {
+	Service    string                 `json:"service"`
+	TraceID    string                 `json:"traceId"`
+	SpanID     string                 `json:"spanId"`
+	ParentID   string                 `json:"parentSpanId,omitempty"`
+	TraceState string                 `json:"traceState,omitempty"`
+	Name       string                 `json:"name"`
+	Kind       SpanKind               `json:"kind"`
+	Start      time.Time              `json:"start"`
+	End        time.Time              `json:"end"`
+	Duration   int64                  `json:"durationMs"`
+	Attributes map[string]interface{} `json:"attributes,omitempty"`
+	Error      string                 `json:"error,omitempty"`
+}
+
+// Export writes one line per span.
+func (e *WriterExporter) Export(ctx context.Context, serviceName string, spans []*Span) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	encoder := json.NewEncoder(e.w)
+	for _, s := range spans {
+		if err := encoder.Encode(makeWriterSpan(serviceName, s)); err != nil {
+			return errors.Wrap(err, "failed to write span")
+		}
+	}
+
+	return nil
+}
+
+// Shutdown closes the underlying file, if any.
+func (e *WriterExporter) Shutdown(ctx context.Context) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.closer == nil {
+		return nil
+	}
+
+	return e.closer.Close()
+}
+
+func makeWriterSpan(serviceName string, s *Span) writerSpan {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ws := writerSpan{
+		Service:    serviceName,
+		TraceID:    s.Context.TraceID.String(),
+		SpanID:     s.Context.SpanID.String(),
+		TraceState: s.Context.TraceState,
+		Name:       s.Name,
+		Kind:       s.Kind,
+		Start:      s.Start,
+		End:        s.End,
+		Duration:   s.End.Sub(s.Start).Milliseconds(),
+		Error:      s.Err,
+	}
+
+	if s.ParentID.IsValid() {
+		ws.ParentID = s.ParentID.String()
+	}
+
+	if len(s.Attributes) > 0 {
+		ws.Attributes = make(map[string]interface{}, len(s.Attributes))
+		for k, v := range s.Attributes {
+			ws.Attributes[k] = v
+		}
+	}
+
+	return ws
+}
+
+func sortedAttributeKeys(attributes map[string]interface{}) []string {
+	keys := make([]string, 0, len(attributes))
+	for k := range attributes {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+func toString(value interface{}) string {
+	if s, ok := value.(fmt.Stringer); ok {
+		return s.String()
+	}
+
+	return fmt.Sprintf("%v", value)
+}
@@ -0,0 +1,47 @@
+package tracing_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/b2wdigital/restQL-golang/v4/internal/platform/tracing"
+	"github.com/b2wdigital/restQL-golang/v4/test"
+)
+
+func TestWriterExporter(t *testing.T) {
+	var buf bytes.Buffer
+	tracer := tracing.NewTracer(tracing.NewWriterExporter(&buf), tracing.Options{ServiceName: "restql"})
+
+	ctx, parent := tracer.Start(context.Background(), "restql.transaction", tracing.SpanKindServer)
+	_, child := tracer.Start(ctx, "restql.query", tracing.SpanKindInternal)
+	child.SetAttribute("restql.tenant", "default")
+	child.Finish()
+	parent.Finish()
+
+	err := tracer.Shutdown(context.Background())
+	test.VerifyError(t, err)
+
+	decoder := json.NewDecoder(&buf)
+	var lines []map[string]interface{}
+	for decoder.More() {
+		var line map[string]interface{}
+		err := decoder.Decode(&line)
+		test.VerifyError(t, err)
+		lines = append(lines, line)
+	}
+
+	test.Equal(t, len(lines), 2)
+
+	test.Equal(t, lines[0]["service"], "restql")
+	test.Equal(t, lines[0]["name"], "restql.query")
+	test.Equal(t, lines[0]["traceId"], parent.Context.TraceID.String())
+	test.Equal(t, lines[0]["parentSpanId"], parent.Context.SpanID.String())
+	test.Equal(t, lines[0]["attributes"], map[string]interface{}{"restql.tenant": "default"})
+
+	test.Equal(t, lines[1]["name"], "restql.transaction")
+	test.Equal(t, lines[1]["kind"], float64(2))
+	_, hasParent := lines[1]["parentSpanId"]
+	test.Equal(t, hasParent, false)
+}
@@ -0,0 +1,91 @@
+package web
+
+import (
+	"fmt"
+
+	"github.com/b2wdigital/restQL-golang/v4/internal/domain"
+	"github.com/b2wdigital/restQL-golang/v4/internal/runner"
+)
+
+// ExplainResponse represents the client format of a query execution plan
+type ExplainResponse struct {
+	Timeout int64          `json:"timeout"`
+	Stages  []ExplainStage `json:"stages"`
+}
+
+// ExplainStage represents the client format of a group of statements executed in parallel
+type ExplainStage struct {
+	Statements []ExplainStatement `json:"statements"`
+}
+
+// ExplainStatement represents the client format of a planned statement
+type ExplainStatement struct {
+	ID          string           `json:"id"`
+	Method      string           `json:"method"`
+	Resource    string           `json:"resource"`
+	DependsOn   []string         `json:"dependsOn"`
+	Multiplexed bool             `json:"multiplexed"`
+	Chained     bool             `json:"chained"`
+	Requests    []ExplainRequest `json:"requests"`
+}
+
+// ExplainRequest represents the client format of a planned upstream request
+type ExplainRequest struct {
+	Method  string                 `json:"method"`
+	URL     string                 `json:"url"`
+	Query   map[string]interface{} `json:"query,omitempty"`
+	Headers map[string]string      `json:"headers,omitempty"`
+	Body    interface{}            `json:"body,omitempty"`
+	Timeout int64                  `json:"timeout"`
+}
+
+// MakeExplainResponse builds the client representation of the query execution plan.
+func MakeExplainResponse(plan runner.Plan) ExplainResponse {
+	stages := make([]ExplainStage, len(plan.Stages))
+	for i, stage := range plan.Stages {
+		statements := make([]ExplainStatement, len(stage.Statements))
+		for j, stmt := range stage.Statements {
+			statements[j] = makeExplainStatement(stmt)
+		}
+
+		stages[i] = ExplainStage{Statements: statements}
+	}
+
+	return ExplainResponse{
+		Timeout: plan.Timeout.Milliseconds(),
+		Stages:  stages,
+	}
+}
+
+func makeExplainStatement(stmt runner.PlannedStatement) ExplainStatement {
+	dependsOn := make([]string, len(stmt.DependsOn))
+	for i, d := range stmt.DependsOn {
+		dependsOn[i] = string(d)
+	}
+
+	requests := make([]ExplainRequest, len(stmt.Requests))
+	for i, r := range stmt.Requests {
+		requests[i] = makeExplainRequest(r)
+	}
+
+	return ExplainStatement{
+		ID:          string(stmt.ID),
+		Method:      stmt.Method,
+		Resource:    stmt.Resource,
+		DependsOn:   dependsOn,
+		Multiplexed: stmt.Multiplexed,
+		Chained:     stmt.Chained,
+		Requests:    requests,
+	}
+}
+
+func makeExplainRequest(request domain.HTTPRequest) ExplainRequest {
+	return ExplainRequest{
+		Method:  request.Method,
+		URL:     fmt.Sprintf("%s://%s%s", request.Schema, request.Host, request.Path),
+		Query:   request.Query,
+		Headers: request.Headers,
+		Body:    request.Body,
+		Timeout: request.Timeout.Milliseconds(),
+	}
+}
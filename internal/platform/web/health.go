@@ -2,15 +2,20 @@ package web
 
 import (
 	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/b2wdigital/restQL-golang/v4/internal/platform/httpclient"
 	"github.com/valyala/fasthttp"
 )
 
 type check struct {
-	build string
+	build    string
+	breakers *httpclient.CircuitBreakers
 }
 
-func newCheck(build string) check {
-	return check{build: build}
+func newCheck(build string, breakers *httpclient.CircuitBreakers) check {
+	return check{build: build, breakers: breakers}
 }
 
 func (c check) Health(ctx *fasthttp.RequestCtx) error {
@@ -22,3 +27,36 @@ func (c check) ResourceStatus(ctx *fasthttp.RequestCtx) error {
 	ctx.Response.SetBodyString(fmt.Sprintf("RestQL is running with build %s", c.build))
 	return nil
 }
+
+// CircuitBreakerResponse represents the client format of a circuit breaker state
+type CircuitBreakerResponse struct {
+	Resource string     `json:"resource"`
+	Host     string     `json:"host"`
+	State    string     `json:"state"`
+	Requests int        `json:"requests"`
+	Failures int        `json:"failures"`
+	OpenedAt *time.Time `json:"openedAt,omitempty"`
+}
+
+func (c check) CircuitBreakers(ctx *fasthttp.RequestCtx) error {
+	statuses := c.breakers.Statuses()
+
+	response := make([]CircuitBreakerResponse, len(statuses))
+	for i, s := range statuses {
+		cb := CircuitBreakerResponse{
+			Resource: s.Resource,
+			Host:     s.Host,
+			State:    s.State,
+			Requests: s.Requests,
+			Failures: s.Failures,
+		}
+		if !s.OpenedAt.IsZero() {
+			openedAt := s.OpenedAt
+			cb.OpenedAt = &openedAt
+		}
+
+		response[i] = cb
+	}
+
+	return Respond(ctx, response, http.StatusOK, nil)
+}
@@ -0,0 +1,125 @@
+package web
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/b2wdigital/restQL-golang/v4/internal/platform/metrics"
+	"github.com/b2wdigital/restQL-golang/v4/pkg/restql"
+	"github.com/valyala/fasthttp"
+)
+
+const (
+	adHocQueryLabel     = "ad-hoc"
+	unknownQueryLabel   = "unknown"
+	queryObservationKey = "queryObservation"
+)
+
+// queryObservation holds the metric labels of a query and
+// when it started, until the query is finished. Saved queries
+// are only labeled by their identification once resolved, so
+// clients cannot create an unbounded number of series.
+type queryObservation struct {
+	start    time.Time
+	saved    bool
+	resolved *restql.QueryOptions
+	streamed bool
+}
+
+// observeQuery returns the observation of the query run by the
+// request, which is nil, and records nothing, when not instrumented.
+func observeQuery(ctx *fasthttp.RequestCtx) *queryObservation {
+	qo, _ := ctx.UserValue(queryObservationKey).(*queryObservation)
+	return qo
+}
+
+// resolve marks the request as running an existing saved query,
+// allowing its identification to be used as metric labels.
+func (qo *queryObservation) resolve(options restql.QueryOptions) {
+	if qo != nil {
+		qo.resolved = &options
+	}
+}
+
+// stream tells that the query is still running when the handler
+// returns, as its result is streamed, and that finish will be
+// called once it is done.
+func (qo *queryObservation) stream() {
+	if qo != nil {
+		qo.streamed = true
+	}
+}
+
+func (qo *queryObservation) finish(status int) {
+	if qo == nil {
+		return
+	}
+
+	namespace, queryID, revision := qo.labels()
+	statusLabel := strconv.Itoa(status)
+
+	metrics.Queries.Inc(namespace, queryID, revision, statusLabel)
+	metrics.QueryDuration.Observe(time.Since(qo.start).Seconds(), namespace, queryID, revision, statusLabel)
+	metrics.QueriesInFlight.Dec()
+}
+
+func (qo *queryObservation) labels() (namespace, queryID, revision string) {
+	switch {
+	case qo.resolved != nil:
+		return qo.resolved.Namespace, qo.resolved.Id, strconv.Itoa(qo.resolved.Revision)
+	case qo.saved:
+		return unknownQueryLabel, unknownQueryLabel, unknownQueryLabel
+	default:
+		return "", adHocQueryLabel, ""
+	}
+}
+
+// instrumentQuery wraps a query handler recording the number
+// of queries in flight, and the latency and status code of
+// each one by namespace, query and revision. Streamed queries
+// are recorded when the stream ends, with the summary status.
+func instrumentQuery(h handler) handler {
+	return func(ctx *fasthttp.RequestCtx) error {
+		metrics.QueriesInFlight.Inc()
+
+		_, saved := ctx.UserValue("queryId").(string)
+		qo := &queryObservation{start: time.Now(), saved: saved}
+		ctx.SetUserValue(queryObservationKey, qo)
+
+		err := h(ctx)
+		if qo.streamed && err == nil {
+			return nil
+		}
+
+		qo.finish(responseStatus(ctx, err))
+		return err
+	}
+}
+
+func responseStatus(ctx *fasthttp.RequestCtx, err error) int {
+	if err == nil {
+		return ctx.Response.StatusCode()
+	}
+
+	if webErr, ok := err.(*Error); ok {
+		return webErr.Status
+	}
+
+	return http.StatusInternalServerError
+}
+
+type metricsHandler struct {
+	registry *metrics.Registry
+}
+
+func newMetricsHandler(registry *metrics.Registry) metricsHandler {
+	return metricsHandler{registry: registry}
+}
+
+func (m metricsHandler) Metrics(ctx *fasthttp.RequestCtx) error {
+	ctx.Response.Header.SetContentType("text/plain; version=0.0.4; charset=utf-8")
+	ctx.Response.SetStatusCode(http.StatusOK)
+
+	return m.registry.WriteText(ctx.Response.BodyWriter())
+}
@@ -0,0 +1,112 @@
+package web
+
+import (
+	"bytes"
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/b2wdigital/restQL-golang/v4/internal/domain"
+	"github.com/b2wdigital/restQL-golang/v4/internal/eval"
+	"github.com/b2wdigital/restQL-golang/v4/internal/platform/metrics"
+	"github.com/b2wdigital/restQL-golang/v4/pkg/restql"
+	"github.com/b2wdigital/restQL-golang/v4/test"
+	"github.com/valyala/fasthttp"
+)
+
+func TestQueryLabels(t *testing.T) {
+	tests := []struct {
+		name        string
+		observation queryObservation
+		expected    []string
+	}{
+		{
+			"should label ad-hoc queries",
+			queryObservation{},
+			[]string{"", "ad-hoc", ""},
+		},
+		{
+			"should label resolved saved queries by their identification",
+			queryObservation{saved: true, resolved: &restql.QueryOptions{Namespace: "heroes", Id: "batman", Revision: 1}},
+			[]string{"heroes", "batman", "1"},
+		},
+		{
+			"should label unresolved saved queries as unknown",
+			queryObservation{saved: true},
+			[]string{"unknown", "unknown", "unknown"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			namespace, queryID, revision := tt.observation.labels()
+
+			test.Equal(t, []string{namespace, queryID, revision}, tt.expected)
+		})
+	}
+}
+
+func TestInstrumentStreamedQuery(t *testing.T) {
+	options := restql.QueryOptions{Namespace: "streamed", Id: "batman", Revision: 1}
+	hero := domain.DoneResource{Status: 200, Success: true, ResponseBody: map[string]interface{}{"name": "batman"}}
+	villain := domain.DoneResource{Status: 404, ResponseBody: map[string]interface{}{}}
+
+	release := make(chan struct{})
+	h := instrumentQuery(func(reqCtx *fasthttp.RequestCtx) error {
+		qo := observeQuery(reqCtx)
+
+		return streamQuery(reqCtx, context.Background(), test.NoOpLogger{}, streamNDJSON, false, savedQueryError, qo,
+			func(ctx context.Context, listener eval.StatementListener) (domain.Resources, error) {
+				listener("hero", hero)
+				<-release
+				listener("villain", villain)
+
+				qo.resolve(options)
+				return domain.Resources{"hero": hero, "villain": villain}, nil
+			})
+	})
+
+	inFlight := "restql_queries_in_flight"
+	total := `restql_queries_total{namespace="streamed",query="batman",revision="1",status="404"}`
+	before := writeMetrics(t)
+
+	reqCtx := &fasthttp.RequestCtx{}
+	reqCtx.SetUserValue("queryId", "batman")
+
+	err := h(reqCtx)
+	test.VerifyError(t, err)
+
+	running := writeMetrics(t)
+	test.Equal(t, running[inFlight], before[inFlight]+1)
+	test.Equal(t, running[total], before[total])
+
+	close(release)
+	reqCtx.Response.Body()
+
+	finished := writeMetrics(t)
+	test.Equal(t, finished[inFlight], before[inFlight])
+	test.Equal(t, finished[total], before[total]+1)
+}
+
+// writeMetrics returns the value of each series
+// of the default registry, keyed by its name and labels.
+func writeMetrics(t *testing.T) map[string]float64 {
+	var buf bytes.Buffer
+	err := metrics.Default.WriteText(&buf)
+	test.VerifyError(t, err)
+
+	values := make(map[string]float64)
+	for _, line := range strings.Split(buf.String(), "\n") {
+		i := strings.LastIndex(line, " ")
+		if line == "" || strings.HasPrefix(line, "#") || i < 0 {
+			continue
+		}
+
+		v, err := strconv.ParseFloat(line[i+1:], 64)
+		test.VerifyError(t, err)
+		values[line[:i]] = v
+	}
+
+	return values
+}
@@ -22,30 +22,48 @@ func (nm noopMiddleware) Apply(h fasthttp.RequestHandler) fasthttp.RequestHandle
 }
 
 // Apply takes a base handler and a slice of middlewares,
-// applying each one in the order they are given.
-func Apply(log restql.Logger, h fasthttp.RequestHandler, mws []Middleware) fasthttp.RequestHandler {
+// applying each one in the order they are given. It fails
+// when a middleware plugin cannot wrap the handler.
+func Apply(log restql.Logger, h fasthttp.RequestHandler, mws []Middleware) (fasthttp.RequestHandler, error) {
 	handler := h
 
 	for i := len(mws) - 1; i >= 0; i-- {
 		m := mws[i]
 		log.Debug(fmt.Sprintf("applying middleware %T", m))
-		handler = m.Apply(handler)
+
+		pm, ok := m.(pluginMiddleware)
+		if !ok {
+			handler = m.Apply(handler)
+			continue
+		}
+
+		wrapped, err := pm.apply(handler)
+		if err != nil {
+			return nil, err
+		}
+		handler = wrapped
 	}
 
-	return handler
+	return handler, nil
 }
 
 // FetchEnabled returns all middlewares enabled in configuration
-func FetchEnabled(log restql.Logger, cfg *conf.Config, pm plugins.Lifecycle) []Middleware {
-	mws := []Middleware{newRecoverer(log), newNativeContext(), newTransaction(pm)}
+// for the API, with the middleware plugins in their positions.
+// The recover middleware is always the first one, so it can
+// handle panics from any other.
+func FetchEnabled(log restql.Logger, cfg *conf.Config, pm plugins.Lifecycle, mps []restql.MiddlewarePlugin) []Middleware {
+	builtins := []namedMiddleware{
+		{name: nativeContextName, Middleware: newNativeContext()},
+		{name: transactionName, Middleware: newTransaction(pm)},
+	}
 
 	mwCfg := cfg.HTTP.Server.Middlewares
 	if mwCfg.Timeout != nil {
-		mws = append(mws, newTimeout(mwCfg.Timeout.Duration, log))
+		builtins = append(builtins, namedMiddleware{name: timeoutName, Middleware: newTimeout(mwCfg.Timeout.Duration, log)})
 	}
 
 	if mwCfg.RequestID != nil {
-		mws = append(mws, newRequestID(mwCfg.RequestID.Header, mwCfg.RequestID.Strategy, log))
+		builtins = append(builtins, namedMiddleware{name: requestIDName, Middleware: newRequestID(mwCfg.RequestID.Header, mwCfg.RequestID.Strategy, log)})
 	}
 
 	if mwCfg.Cors != nil {
@@ -55,8 +73,20 @@ func FetchEnabled(log restql.Logger, cfg *conf.Config, pm plugins.Lifecycle) []M
 			withAllowMethods(mwCfg.Cors.AllowMethods),
 			withExposedHeaders(mwCfg.Cors.ExposeHeaders),
 		)
-		mws = append(mws, cors)
+		builtins = append(builtins, namedMiddleware{name: corsName, Middleware: cors})
+	}
+
+	mws := []Middleware{newRecoverer(log)}
+	return append(mws, withPlugins(log, cfg, apiApp, builtins, mps)...)
+}
+
+// FetchHealth returns the middlewares of the health server,
+// which are only the middleware plugins enabled for it.
+func FetchHealth(log restql.Logger, cfg *conf.Config, mps []restql.MiddlewarePlugin) []Middleware {
+	mws := withPlugins(log, cfg, healthApp, nil, mps)
+	if len(mws) == 0 {
+		return nil
 	}
 
-	return mws
+	return append([]Middleware{newRecoverer(log)}, mws...)
 }
@@ -0,0 +1,149 @@
+package middleware
+
+import (
+	"strings"
+
+	"github.com/b2wdigital/restQL-golang/v4/internal/platform/conf"
+	"github.com/b2wdigital/restQL-golang/v4/internal/platform/plugins"
+	"github.com/b2wdigital/restQL-golang/v4/pkg/restql"
+	"github.com/pkg/errors"
+	"github.com/valyala/fasthttp"
+)
+
+// Servers where the middleware plugins can be applied.
+const (
+	apiApp    = "api"
+	healthApp = "health"
+)
+
+// Positions of the middleware plugins in the chain, either
+// at the ends or relative to a built-in middleware.
+const (
+	firstPosition  = "first"
+	lastPosition   = "last"
+	beforePosition = "before:"
+	afterPosition  = "after:"
+)
+
+// Names of the built-in middlewares, used as
+// reference to position the middleware plugins.
+const (
+	nativeContextName = "nativeContext"
+	transactionName   = "transaction"
+	timeoutName       = "timeout"
+	requestIDName     = "requestId"
+	corsName          = "cors"
+)
+
+var builtinNames = map[string]bool{
+	nativeContextName: true,
+	transactionName:   true,
+	timeoutName:       true,
+	requestIDName:     true,
+	corsName:          true,
+}
+
+type namedMiddleware struct {
+	name string
+	Middleware
+}
+
+type pluginMiddleware struct {
+	plugin restql.MiddlewarePlugin
+}
+
+func newPluginMiddleware(plugin restql.MiddlewarePlugin) pluginMiddleware {
+	return pluginMiddleware{plugin: plugin}
+}
+
+func (pm pluginMiddleware) Apply(h fasthttp.RequestHandler) fasthttp.RequestHandler {
+	return pm.plugin.Apply(h)
+}
+
+// apply wraps the handler with the plugin, failing
+// if the plugin panics or returns no handler.
+func (pm pluginMiddleware) apply(h fasthttp.RequestHandler) (fasthttp.RequestHandler, error) {
+	var handler fasthttp.RequestHandler
+	if err := plugins.Protect(func() { handler = pm.plugin.Apply(h) }); err != nil {
+		return nil, errors.Wrapf(err, "middleware plugin %s produced a panic", pm.plugin.Name())
+	}
+
+	if handler == nil {
+		return nil, errors.Errorf("middleware plugin %s returned a nil handler", pm.plugin.Name())
+	}
+
+	return handler, nil
+}
+
+// withPlugins inserts the middleware plugins enabled for the app in the
+// built-in chain according to their configured position. Plugins on the
+// same position keep their registration order, and the ones positioned
+// relative to a built-in middleware that is not enabled are placed last.
+func withPlugins(log restql.Logger, cfg *conf.Config, app string, builtins []namedMiddleware, mps []restql.MiddlewarePlugin) []Middleware {
+	enabled := make(map[string]bool, len(builtins))
+	for _, b := range builtins {
+		enabled[b.name] = true
+	}
+
+	var first, last []Middleware
+	before := make(map[string][]Middleware)
+	after := make(map[string][]Middleware)
+
+	for _, mp := range mps {
+		pluginCfg := cfg.HTTP.Server.Middlewares.Plugins[mp.Name()]
+		if !isAppliedTo(pluginCfg.Apps, app) {
+			continue
+		}
+
+		m := newPluginMiddleware(mp)
+		position := pluginCfg.Position
+
+		switch {
+		case position == "" || position == lastPosition:
+			last = append(last, m)
+		case position == firstPosition:
+			first = append(first, m)
+		case strings.HasPrefix(position, beforePosition) || strings.HasPrefix(position, afterPosition):
+			anchor := position[strings.Index(position, ":")+1:]
+
+			switch {
+			case !builtinNames[anchor]:
+				log.Warn("middleware plugin placed last due to unknown position", "name", mp.Name(), "position", position)
+				last = append(last, m)
+			case !enabled[anchor]:
+				log.Debug("middleware plugin placed last due to disabled middleware", "name", mp.Name(), "position", position)
+				last = append(last, m)
+			case strings.HasPrefix(position, beforePosition):
+				before[anchor] = append(before[anchor], m)
+			default:
+				after[anchor] = append(after[anchor], m)
+			}
+		default:
+			log.Warn("middleware plugin placed last due to unknown position", "name", mp.Name(), "position", position)
+			last = append(last, m)
+		}
+	}
+
+	mws := first
+	for _, b := range builtins {
+		mws = append(mws, before[b.name]...)
+		mws = append(mws, b.Middleware)
+		mws = append(mws, after[b.name]...)
+	}
+
+	return append(mws, last...)
+}
+
+func isAppliedTo(apps []string, app string) bool {
+	if len(apps) == 0 {
+		return app == apiApp
+	}
+
+	for _, a := range apps {
+		if a == app {
+			return true
+		}
+	}
+
+	return false
+}
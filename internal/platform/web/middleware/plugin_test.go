@@ -0,0 +1,208 @@
+package middleware
+
+import (
+	"testing"
+
+	"github.com/b2wdigital/restQL-golang/v4/internal/platform/conf"
+	"github.com/b2wdigital/restQL-golang/v4/pkg/restql"
+	"github.com/b2wdigital/restQL-golang/v4/test"
+	"github.com/valyala/fasthttp"
+)
+
+type namedPlugin struct {
+	name      string
+	panics    bool
+	noHandler bool
+}
+
+func (np namedPlugin) Name() string { return np.name }
+
+func (np namedPlugin) Apply(h fasthttp.RequestHandler) fasthttp.RequestHandler {
+	if np.panics {
+		panic("middleware panic")
+	}
+
+	if np.noHandler {
+		return nil
+	}
+
+	return func(ctx *fasthttp.RequestCtx) {
+		ctx.Response.Header.Add("X-Chain", np.name)
+		h(ctx)
+	}
+}
+
+type builtinStub struct {
+	name string
+}
+
+func (bs builtinStub) Apply(h fasthttp.RequestHandler) fasthttp.RequestHandler {
+	return h
+}
+
+func newBuiltinStub(name string) namedMiddleware {
+	return namedMiddleware{name: name, Middleware: builtinStub{name: name}}
+}
+
+func TestWithPlugins(t *testing.T) {
+	builtins := []namedMiddleware{
+		newBuiltinStub(nativeContextName),
+		newBuiltinStub(transactionName),
+		newBuiltinStub(corsName),
+	}
+
+	mps := []restql.MiddlewarePlugin{
+		namedPlugin{name: "auth"},
+		namedPlugin{name: "tenant"},
+		namedPlugin{name: "shaper"},
+	}
+
+	tests := []struct {
+		name     string
+		app      string
+		builtins []namedMiddleware
+		plugins  map[string]conf.MiddlewarePluginConf
+		expected []string
+	}{
+		{
+			"should place plugins last by default",
+			apiApp,
+			builtins,
+			nil,
+			[]string{nativeContextName, transactionName, corsName, "auth", "tenant", "shaper"},
+		},
+		{
+			"should place plugins in configured positions",
+			apiApp,
+			builtins,
+			map[string]conf.MiddlewarePluginConf{
+				"auth":   {Position: "first"},
+				"tenant": {Position: "after:transaction"},
+				"shaper": {Position: "before:transaction"},
+			},
+			[]string{"auth", nativeContextName, "shaper", transactionName, "tenant", corsName},
+		},
+		{
+			"should keep registration order on same position",
+			apiApp,
+			builtins,
+			map[string]conf.MiddlewarePluginConf{
+				"auth":   {Position: "before:cors"},
+				"tenant": {Position: "last"},
+				"shaper": {Position: "before:cors"},
+			},
+			[]string{nativeContextName, transactionName, "auth", "shaper", corsName, "tenant"},
+		},
+		{
+			"should place plugins last on unknown or disabled middleware",
+			apiApp,
+			builtins,
+			map[string]conf.MiddlewarePluginConf{
+				"auth":   {Position: "after:requestId"},
+				"tenant": {Position: "after:unknown"},
+				"shaper": {Position: "middle"},
+			},
+			[]string{nativeContextName, transactionName, corsName, "auth", "tenant", "shaper"},
+		},
+		{
+			"should only apply plugins enabled for the app",
+			healthApp,
+			nil,
+			map[string]conf.MiddlewarePluginConf{
+				"auth":   {Apps: []string{"api"}},
+				"tenant": {Apps: []string{"api", "health"}, Position: "after:transaction"},
+				"shaper": {Apps: []string{"health"}},
+			},
+			[]string{"tenant", "shaper"},
+		},
+		{
+			"should not apply plugins to health by default",
+			healthApp,
+			nil,
+			map[string]conf.MiddlewarePluginConf{
+				"tenant": {Apps: []string{"health"}},
+			},
+			[]string{"tenant"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &conf.Config{}
+			cfg.HTTP.Server.Middlewares.Plugins = tt.plugins
+
+			mws := withPlugins(test.NoOpLogger{}, cfg, tt.app, tt.builtins, mps)
+
+			var got []string
+			for _, m := range mws {
+				switch m := m.(type) {
+				case pluginMiddleware:
+					got = append(got, m.plugin.Name())
+				case builtinStub:
+					got = append(got, m.name)
+				}
+			}
+
+			test.Equal(t, got, tt.expected)
+		})
+	}
+}
+
+func TestFetchHealth(t *testing.T) {
+	health := conf.MiddlewarePluginConf{Apps: []string{"health"}}
+
+	cfg := &conf.Config{}
+	cfg.HTTP.Server.Middlewares.Plugins = map[string]conf.MiddlewarePluginConf{
+		"auth":   {Apps: []string{"api"}},
+		"tenant": health,
+		"shaper": health,
+		"broken": health,
+	}
+
+	t.Run("should not apply middlewares without plugins enabled", func(t *testing.T) {
+		mws := FetchHealth(test.NoOpLogger{}, cfg, []restql.MiddlewarePlugin{namedPlugin{name: "auth"}, namedPlugin{name: "unknown"}})
+		test.Equal(t, len(mws), 0)
+	})
+
+	t.Run("should apply plugins in order", func(t *testing.T) {
+		mps := []restql.MiddlewarePlugin{
+			namedPlugin{name: "auth"},
+			namedPlugin{name: "tenant"},
+			namedPlugin{name: "shaper"},
+		}
+
+		var called bool
+		h, err := Apply(test.NoOpLogger{}, func(ctx *fasthttp.RequestCtx) { called = true }, FetchHealth(test.NoOpLogger{}, cfg, mps))
+		test.VerifyError(t, err)
+
+		ctx := &fasthttp.RequestCtx{}
+		h(ctx)
+
+		var chain []string
+		ctx.Response.Header.VisitAll(func(key, value []byte) {
+			if string(key) == "X-Chain" {
+				chain = append(chain, string(value))
+			}
+		})
+
+		test.Equal(t, called, true)
+		test.Equal(t, chain, []string{"tenant", "shaper"})
+	})
+
+	t.Run("should fail when a plugin cannot wrap the handler", func(t *testing.T) {
+		broken := []namedPlugin{
+			{name: "broken", panics: true},
+			{name: "broken", noHandler: true},
+		}
+
+		for _, b := range broken {
+			mps := []restql.MiddlewarePlugin{namedPlugin{name: "tenant"}, b}
+
+			h, err := Apply(test.NoOpLogger{}, func(ctx *fasthttp.RequestCtx) {}, FetchHealth(test.NoOpLogger{}, cfg, mps))
+			if err == nil {
+				t.Fatalf("expected an error for plugin %+v", b)
+			}
+			test.Equal(t, h == nil, true)
+		}
+	})
+}
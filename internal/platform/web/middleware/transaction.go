@@ -2,6 +2,7 @@ package middleware
 
 import (
 	"github.com/b2wdigital/restQL-golang/v4/internal/platform/plugins"
+	"github.com/b2wdigital/restQL-golang/v4/internal/platform/tracing"
 	"github.com/valyala/fasthttp"
 )
 
@@ -17,12 +18,24 @@ func (t transaction) Apply(h fasthttp.RequestHandler) fasthttp.RequestHandler {
 	return func(ctx *fasthttp.RequestCtx) {
 		nativeContext := GetNativeContext(ctx)
 
+		traceparent := string(ctx.Request.Header.Peek(tracing.TraceparentHeader))
+		tracestate := string(ctx.Request.Header.Peek(tracing.TracestateHeader))
+		if remote, ok := tracing.ParseTraceparent(traceparent, tracestate); ok {
+			nativeContext = tracing.WithRemoteSpanContext(nativeContext, remote)
+		}
+
+		nativeContext, span := tracing.Start(nativeContext, "restql.transaction", tracing.SpanKindServer)
+		span.SetAttribute("http.method", string(ctx.Method()))
+		span.SetAttribute("http.target", string(ctx.RequestURI()))
+
 		transactionCtx := t.lifecycle.BeforeTransaction(nativeContext, ctx)
 		WithNativeContext(ctx, transactionCtx)
 
 		defer func() {
 			if reason := recover(); reason != nil {
 				t.lifecycle.AfterTransaction(transactionCtx, ctx)
+				span.SetAttribute("http.status_code", fasthttp.StatusInternalServerError)
+				span.Finish()
 				panic(reason)
 			}
 		}()
@@ -30,5 +43,8 @@ func (t transaction) Apply(h fasthttp.RequestHandler) fasthttp.RequestHandler {
 		h(ctx)
 
 		t.lifecycle.AfterTransaction(transactionCtx, ctx)
+
+		span.SetAttribute("http.status_code", ctx.Response.StatusCode())
+		span.Finish()
 	}
 }
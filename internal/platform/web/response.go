@@ -61,6 +61,19 @@ type StatementDebugging struct {
 	Params          map[string]interface{} `json:"params,omitempty"`
 	RequestBody     interface{}            `json:"request-body,omitempty"`
 	ResponseTime    int64                  `json:"response-time,omitempty"`
+	Attempts        int                    `json:"attempts,omitempty"`
+	Cache           string                 `json:"cache,omitempty"`
+	Pages           []PageDebugging        `json:"pages,omitempty"`
+}
+
+// PageDebugging represents the client format of the
+// debugging information of a paginated statement page
+type PageDebugging struct {
+	URL          string `json:"url,omitempty"`
+	Status       int    `json:"status"`
+	ResponseTime int64  `json:"response-time,omitempty"`
+	Attempts     int    `json:"attempts,omitempty"`
+	Items        int    `json:"items"`
 }
 
 // StatementMetadata represents the client format of metadata
@@ -70,10 +83,12 @@ type StatementMetadata struct {
 
 // StatementDetails represents the client format of the statement details
 type StatementDetails struct {
-	Status   int                 `json:"status"`
-	Success  bool                `json:"success"`
-	Metadata StatementMetadata   `json:"metadata"`
-	Debug    *StatementDebugging `json:"debug,omitempty"`
+	Status    int                 `json:"status"`
+	Success   bool                `json:"success"`
+	Skipped   bool                `json:"skipped,omitempty"`
+	ErrorKind string              `json:"error-kind,omitempty"`
+	Metadata  StatementMetadata   `json:"metadata"`
+	Debug     *StatementDebugging `json:"debug,omitempty"`
 }
 
 // StatementResult represents the client format of the statement result
@@ -143,9 +158,11 @@ func parseDetails(resource domain.DoneResource, debug bool) StatementDetails {
 	}
 
 	sd := StatementDetails{
-		Status:   resource.Status,
-		Success:  resource.Success,
-		Metadata: metadata,
+		Status:    resource.Status,
+		Success:   resource.Success,
+		Skipped:   resource.Skipped,
+		ErrorKind: string(resource.ErrorKind),
+		Metadata:  metadata,
 	}
 
 	if debug {
@@ -156,6 +173,11 @@ func parseDetails(resource domain.DoneResource, debug bool) StatementDetails {
 }
 
 func parseDebug(resource domain.DoneResource) *StatementDebugging {
+	var pages []PageDebugging
+	for _, p := range resource.Pages {
+		pages = append(pages, PageDebugging{URL: p.URL, Status: p.Status, ResponseTime: p.ResponseTime, Attempts: p.Attempts, Items: p.Items})
+	}
+
 	return &StatementDebugging{
 		Method:          resource.Method,
 		URL:             resource.URL,
@@ -164,6 +186,9 @@ func parseDebug(resource domain.DoneResource) *StatementDebugging {
 		Params:          resource.RequestParams,
 		RequestBody:     resource.RequestBody,
 		ResponseTime:    resource.ResponseTime,
+		Attempts:        resource.Attempts,
+		Cache:           resource.CacheStatus,
+		Pages:           pages,
 	}
 }
 
@@ -174,6 +199,8 @@ func parseDebug(resource domain.DoneResource) *StatementDebugging {
 // 0 => 500
 // 204 => 200
 // 201 => 200
+//
+// Statements skipped by the `when` clause are not considered.
 func CalculateStatusCode(queryResult domain.Resources) int {
 	results := make([]interface{}, len(queryResult))
 	index := 0
@@ -192,7 +219,7 @@ var statusNormalization = map[int]int{0: 500, 204: 200, 201: 200}
 func calculateResultStatusCode(result interface{}) int {
 	switch r := result.(type) {
 	case domain.DoneResource:
-		if r.IgnoreErrors {
+		if r.IgnoreErrors || r.Skipped {
 			return 200
 		}
 
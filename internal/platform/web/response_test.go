@@ -401,6 +401,15 @@ func TestCalculateStatusCode(t *testing.T) {
 			},
 			200,
 		},
+		{
+			"should return max status code except for skipped result",
+			domain.Resources{
+				"hero":     domain.DoneResource{Status: 200},
+				"sidekick": domain.DoneResource{Status: 204, Success: true, Skipped: true},
+				"villain":  domain.DoneResources{domain.DoneResource{Status: 204, Success: true, Skipped: true}},
+			},
+			200,
+		},
 	}
 
 	for _, tt := range tests {
@@ -1,6 +1,7 @@
 package web
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -39,7 +40,7 @@ func newRestQl(l restql.Logger, cfg *conf.Config, e eval.Evaluator, p parser.Par
 
 func (r restQl) ValidateQuery(ctx *fasthttp.RequestCtx) error {
 	queryTxt := string(ctx.PostBody())
-	_, err := r.parser.Parse(queryTxt)
+	query, err := r.parser.Parse(queryTxt)
 	if err != nil {
 		r.log.Error("an error occurred when parsing query", err)
 
@@ -51,12 +52,24 @@ func (r restQl) ValidateQuery(ctx *fasthttp.RequestCtx) error {
 		return RespondError(ctx, e)
 	}
 
+	err = eval.ValidateDependencies(query)
+	if err != nil {
+		r.log.Error("an error occurred when validating query dependencies", err)
+
+		e := &Error{
+			Err:    errors.Wrap(err, "invalid query"),
+			Status: http.StatusUnprocessableEntity,
+		}
+
+		return RespondError(ctx, e)
+	}
+
 	return Respond(ctx, nil, http.StatusOK, nil)
 }
 
 func (r restQl) RunAdHocQuery(reqCtx *fasthttp.RequestCtx) error {
 	ctx := middleware.GetNativeContext(reqCtx)
-	ctx = restql.WithLogger(reqCtx, r.log)
+	ctx = restql.WithLogger(ctx, r.log)
 
 	tenant, err := makeTenant(reqCtx, r.config.Tenant)
 	if err != nil {
@@ -72,35 +85,21 @@ func (r restQl) RunAdHocQuery(reqCtx *fasthttp.RequestCtx) error {
 	}
 
 	queryTxt := string(reqCtx.PostBody())
+	debugEnabled := isDebugEnabled(input)
+
+	if format, ok := streamFormatFor(reqCtx); ok {
+		return streamQuery(reqCtx, ctx, r.log, format, debugEnabled, adHocQueryError, observeQuery(reqCtx),
+			func(ctx context.Context, listener eval.StatementListener) (domain.Resources, error) {
+				return r.evaluator.StreamAdHocQuery(ctx, queryTxt, options, input, listener)
+			})
+	}
 
 	result, err := r.evaluator.AdHocQuery(ctx, queryTxt, options, input)
 	if err != nil {
 		r.log.Error("failed to evaluated adhoc query", err)
-
-		switch {
-		case errors.Is(err, domain.ErrMappingsNotFound):
-			return RespondError(reqCtx, NewRequestError(err, http.StatusNotFound))
-		case errors.Is(err, domain.ErrQueryNotFound):
-			return RespondError(reqCtx, NewRequestError(err, http.StatusNotFound))
-		case errors.Is(err, restql.ErrDatabaseCommunicationFailed):
-			return RespondError(reqCtx, NewRequestError(err, http.StatusInsufficientStorage))
-		}
-
-		switch err := err.(type) {
-		case eval.ValidationError:
-			return RespondError(reqCtx, NewRequestError(err, http.StatusUnprocessableEntity))
-		case eval.ParserError:
-			return RespondError(reqCtx, NewRequestError(err, http.StatusBadRequest))
-		case eval.TimeoutError:
-			return RespondError(reqCtx, NewRequestError(err, http.StatusRequestTimeout))
-		case eval.MappingError:
-			return RespondError(reqCtx, NewRequestError(err, http.StatusInternalServerError))
-		default:
-			return RespondError(reqCtx, err)
-		}
+		return RespondError(reqCtx, adHocQueryError(err))
 	}
 
-	debugEnabled := isDebugEnabled(input)
 	response := MakeQueryResponse(result, debugEnabled)
 	return Respond(reqCtx, response.Body, response.StatusCode, response.Headers)
 }
@@ -124,40 +123,96 @@ func (r restQl) RunSavedQuery(reqCtx *fasthttp.RequestCtx) error {
 		return RespondError(reqCtx, NewRequestError(err, http.StatusBadRequest))
 	}
 
-	result, err := r.evaluator.SavedQuery(ctx, options, input)
-	if err != nil {
-		log.Error("failed to evaluated saved query", err)
+	debugEnabled := isDebugEnabled(input)
 
-		switch {
-		case errors.Is(err, domain.ErrMappingsNotFound):
-			return RespondError(reqCtx, NewRequestError(err, http.StatusNotFound))
-		case errors.Is(err, domain.ErrQueryNotFound):
-			return RespondError(reqCtx, NewRequestError(err, http.StatusNotFound))
-		case errors.Is(err, restql.ErrDatabaseCommunicationFailed):
-			return RespondError(reqCtx, NewRequestError(err, http.StatusInsufficientStorage))
-		}
+	// The request context must not be used by the streamed query,
+	// as it runs after the handler returns.
+	qo := observeQuery(reqCtx)
+
+	if format, ok := streamFormatFor(reqCtx); ok {
+		return streamQuery(reqCtx, ctx, log, format, debugEnabled, savedQueryError, qo,
+			func(ctx context.Context, listener eval.StatementListener) (domain.Resources, error) {
+				result, info, err := r.evaluator.StreamSavedQuery(ctx, options, input, listener)
+				if info.Resolved {
+					qo.resolve(options)
+				}
+				return result, err
+			})
+	}
 
-		switch err := err.(type) {
-		case eval.ValidationError:
-			return RespondError(reqCtx, NewRequestError(err, http.StatusUnprocessableEntity))
-		case eval.TimeoutError:
-			return RespondError(reqCtx, NewRequestError(err, http.StatusRequestTimeout))
-		case eval.ParserError:
-			return RespondError(reqCtx, NewRequestError(err, http.StatusInternalServerError))
-		case eval.MappingError:
-			return RespondError(reqCtx, NewRequestError(err, http.StatusInternalServerError))
-		case domain.ErrQueryRevisionDeprecated:
-			return RespondError(reqCtx, NewRequestError(err, http.StatusBadRequest))
-		default:
-			return RespondError(reqCtx, err)
-		}
+	result, info, err := r.evaluator.SavedQuery(ctx, options, input)
+	if info.Resolved {
+		qo.resolve(options)
+	}
+	if err != nil {
+		log.Error("failed to evaluated saved query", err)
+		return RespondError(reqCtx, savedQueryError(err))
 	}
 
-	debugEnabled := isDebugEnabled(input)
 	response := MakeQueryResponse(result, debugEnabled)
+	if info.CacheHit {
+		response.Headers["Age"] = strconv.Itoa(int(info.CacheAge.Seconds()))
+	}
+
 	return Respond(reqCtx, response.Body, response.StatusCode, response.Headers)
 }
 
+func (r restQl) ExplainAdHocQuery(reqCtx *fasthttp.RequestCtx) error {
+	ctx := middleware.GetNativeContext(reqCtx)
+	ctx = restql.WithLogger(ctx, r.log)
+
+	tenant, err := makeTenant(reqCtx, r.config.Tenant)
+	if err != nil {
+		r.log.Error("failed to build query options", err)
+		return RespondError(reqCtx, NewRequestError(err, http.StatusBadRequest))
+	}
+	options := restql.QueryOptions{Tenant: tenant}
+
+	input, err := makeQueryInput(reqCtx, r.log)
+	if err != nil {
+		r.log.Error("failed to build query input", err)
+		return RespondError(reqCtx, NewRequestError(err, http.StatusBadRequest))
+	}
+
+	queryTxt := string(reqCtx.PostBody())
+
+	plan, err := r.evaluator.ExplainAdHocQuery(ctx, queryTxt, options, input)
+	if err != nil {
+		r.log.Error("failed to explain adhoc query", err)
+		return RespondError(reqCtx, adHocQueryError(err))
+	}
+
+	return Respond(reqCtx, MakeExplainResponse(plan), http.StatusOK, nil)
+}
+
+func (r restQl) ExplainSavedQuery(reqCtx *fasthttp.RequestCtx) error {
+	log := r.log.With("restql-endpoint", string(reqCtx.Request.URI().Path()))
+	log = log.With("request-id", string(reqCtx.Request.Header.Peek("X-TID")))
+
+	ctx := middleware.GetNativeContext(reqCtx)
+	ctx = restql.WithLogger(ctx, log)
+
+	options, err := makeQueryOptions(reqCtx, log, r.config.Tenant)
+	if err != nil {
+		log.Error("failed to build query options", err)
+		return RespondError(reqCtx, NewRequestError(err, http.StatusBadRequest))
+	}
+
+	input, err := makeQueryInput(reqCtx, log)
+	if err != nil {
+		log.Error("failed to build query input", err)
+		return RespondError(reqCtx, NewRequestError(err, http.StatusBadRequest))
+	}
+
+	plan, err := r.evaluator.ExplainSavedQuery(ctx, options, input)
+	if err != nil {
+		log.Error("failed to explain saved query", err)
+		return RespondError(reqCtx, savedQueryError(err))
+	}
+
+	return Respond(reqCtx, MakeExplainResponse(plan), http.StatusOK, nil)
+}
+
 func makeQueryOptions(ctx *fasthttp.RequestCtx, log restql.Logger, envTenant string) (restql.QueryOptions, error) {
 	namespace, err := pathParamString(ctx, "namespace")
 	if err != nil {
@@ -307,3 +362,57 @@ func isDebugEnabled(queryInput restql.QueryInput) bool {
 
 	return d
 }
+
+// adHocQueryError translates an ad-hoc query evaluation
+// error into a client error with the proper status code.
+func adHocQueryError(err error) error {
+	switch {
+	case errors.Is(err, domain.ErrMappingsNotFound):
+		return NewRequestError(err, http.StatusNotFound)
+	case errors.Is(err, domain.ErrQueryNotFound):
+		return NewRequestError(err, http.StatusNotFound)
+	case errors.Is(err, restql.ErrDatabaseCommunicationFailed):
+		return NewRequestError(err, http.StatusInsufficientStorage)
+	}
+
+	switch err := err.(type) {
+	case eval.ValidationError:
+		return NewRequestError(err, http.StatusUnprocessableEntity)
+	case eval.ParserError:
+		return NewRequestError(err, http.StatusBadRequest)
+	case eval.TimeoutError:
+		return NewRequestError(err, http.StatusRequestTimeout)
+	case eval.MappingError:
+		return NewRequestError(err, http.StatusInternalServerError)
+	default:
+		return err
+	}
+}
+
+// savedQueryError translates a saved query evaluation
+// error into a client error with the proper status code.
+func savedQueryError(err error) error {
+	switch {
+	case errors.Is(err, domain.ErrMappingsNotFound):
+		return NewRequestError(err, http.StatusNotFound)
+	case errors.Is(err, domain.ErrQueryNotFound):
+		return NewRequestError(err, http.StatusNotFound)
+	case errors.Is(err, restql.ErrDatabaseCommunicationFailed):
+		return NewRequestError(err, http.StatusInsufficientStorage)
+	}
+
+	switch err := err.(type) {
+	case eval.ValidationError:
+		return NewRequestError(err, http.StatusUnprocessableEntity)
+	case eval.TimeoutError:
+		return NewRequestError(err, http.StatusRequestTimeout)
+	case eval.ParserError:
+		return NewRequestError(err, http.StatusInternalServerError)
+	case eval.MappingError:
+		return NewRequestError(err, http.StatusInternalServerError)
+	case domain.ErrQueryRevisionDeprecated:
+		return NewRequestError(err, http.StatusBadRequest)
+	default:
+		return err
+	}
+}
@@ -0,0 +1,48 @@
+package web
+
+import (
+	"net/http"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/b2wdigital/restQL-golang/v4/internal/domain"
+)
+
+var resultMaxAgeRegex = regexp.MustCompile("max-age=(\\d+)")
+var resultSMaxAgeRegex = regexp.MustCompile("s-maxage=(\\d+)")
+var resultNoCacheRegex = regexp.MustCompile("no-cache|no-store")
+
+// queryResultTTL allows a query result to be stored in the
+// result cache only if its response would be successful,
+// for the time defined by the Cache-Control header
+// calculated for it.
+func queryResultTTL(resources domain.Resources) (time.Duration, bool) {
+	response := MakeQueryResponse(resources, false)
+	if response.StatusCode != http.StatusOK {
+		return 0, false
+	}
+
+	return resultTTL(response.Headers["Cache-Control"])
+}
+
+func resultTTL(cacheControl string) (time.Duration, bool) {
+	if cacheControl == "" || resultNoCacheRegex.MatchString(cacheControl) {
+		return 0, false
+	}
+
+	matches := resultSMaxAgeRegex.FindStringSubmatch(cacheControl)
+	if len(matches) < 2 {
+		matches = resultMaxAgeRegex.FindStringSubmatch(cacheControl)
+	}
+	if len(matches) < 2 {
+		return 0, false
+	}
+
+	seconds, err := strconv.Atoi(matches[1])
+	if err != nil || seconds <= 0 {
+		return 0, false
+	}
+
+	return time.Duration(seconds) * time.Second, true
+}
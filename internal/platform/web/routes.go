@@ -4,11 +4,13 @@ import (
 	"github.com/b2wdigital/restQL-golang/v4/pkg/restql"
 	"net/http"
 
+	"github.com/b2wdigital/restQL-golang/v4/internal/domain"
 	"github.com/b2wdigital/restQL-golang/v4/internal/eval"
 	"github.com/b2wdigital/restQL-golang/v4/internal/parser"
 	"github.com/b2wdigital/restQL-golang/v4/internal/platform/cache"
 	"github.com/b2wdigital/restQL-golang/v4/internal/platform/conf"
 	"github.com/b2wdigital/restQL-golang/v4/internal/platform/httpclient"
+	"github.com/b2wdigital/restQL-golang/v4/internal/platform/metrics"
 	"github.com/b2wdigital/restQL-golang/v4/internal/platform/persistence"
 	"github.com/b2wdigital/restQL-golang/v4/internal/platform/plugins"
 	"github.com/b2wdigital/restQL-golang/v4/internal/runner"
@@ -16,20 +18,24 @@ import (
 )
 
 // API constructs a handler for the restQL query related endpoints
-func API(log restql.Logger, cfg *conf.Config) (fasthttp.RequestHandler, error) {
+// wrapped by the middleware plugins enabled for it, returning
+// with it the circuit breakers of the upstream calls.
+func API(log restql.Logger, cfg *conf.Config, mps []restql.MiddlewarePlugin) (fasthttp.RequestHandler, *httpclient.CircuitBreakers, error) {
 	log.Debug("starting api")
-	defaultParser, err := parser.New()
+	functions := plugins.NewFunctions(log)
+
+	defaultParser, err := parser.New(functions.Names()...)
 	if err != nil {
 		log.Error("failed to compile parser", err)
-		return nil, err
+		return nil, nil, err
 	}
-	parserCacheLoader := cache.New(log, cfg.Cache.Parser.MaxSize, cache.ParserCacheLoader(defaultParser))
+	parserCacheLoader := cache.New(log, cfg.Cache.Parser.MaxSize, cache.ParserCacheLoader(defaultParser), cache.WithName("parser"))
 	parserCache := cache.NewParserCache(log, parserCacheLoader)
 
 	db, err := persistence.NewDatabase(log)
 	if err != nil {
 		log.Error("failed to establish connection to database", err)
-		return nil, err
+		return nil, nil, err
 	}
 
 	lifecycle, err := plugins.NewLifecycle(log)
@@ -38,13 +44,20 @@ func API(log restql.Logger, cfg *conf.Config) (fasthttp.RequestHandler, error) {
 	}
 
 	app := newApp(log, cfg, lifecycle)
-	client := httpclient.New(log, lifecycle, cfg)
-	executor := runner.NewExecutor(log, client, cfg.HTTP.QueryResourceTimeout, cfg.HTTP.ForwardPrefix)
-	r := runner.NewRunner(log, executor, cfg.HTTP.GlobalQueryTimeout)
+	client, breakers := httpclient.New(log, lifecycle, cfg)
+	executor := runner.NewExecutor(log, client, cfg.HTTP.QueryResourceTimeout, cfg.HTTP.ForwardPrefix, makeRetryPolicies(cfg), lifecycle, plugins.NewResources(log))
+	concurrency := runner.Concurrency{
+		Workers:   cfg.HTTP.Concurrency.Workers,
+		Query:     cfg.HTTP.Concurrency.Query,
+		Statement: cfg.HTTP.Concurrency.Statement,
+		OnSpawn:   func() { metrics.RunnerGoroutines.Inc() },
+	}
+	r := runner.NewRunner(log, executor, cfg.HTTP.GlobalQueryTimeout, cfg.HTTP.OnQueryTimeout, concurrency, functions)
 
 	mr := persistence.NewMappingReader(log, cfg.Env, cfg.Mappings, db)
 	tenantCache := cache.New(log, cfg.Cache.Mappings.MaxSize,
 		cache.TenantCacheLoader(mr),
+		cache.WithName("mappings"),
 		cache.WithExpiration(cfg.Cache.Mappings.Expiration),
 		cache.WithRefreshInterval(cfg.Cache.Mappings.RefreshInterval),
 		cache.WithRefreshQueueLength(cfg.Cache.Mappings.RefreshQueueLength),
@@ -52,30 +65,56 @@ func API(log restql.Logger, cfg *conf.Config) (fasthttp.RequestHandler, error) {
 	cacheMr := cache.NewMappingsReaderCache(log, tenantCache)
 
 	qr := persistence.NewQueryReader(log, cfg.Queries, db)
-	queryCache := cache.New(log, cfg.Cache.Query.MaxSize, cache.QueryCacheLoader(qr))
+	queryCache := cache.New(log, cfg.Cache.Query.MaxSize, cache.QueryCacheLoader(qr), cache.WithName("query"))
 	cacheQr := cache.NewQueryReaderCache(log, queryCache)
 
-	e := eval.NewEvaluator(log, cacheMr, cacheQr, r, parserCache, lifecycle)
+	var perRequestHeaders []string
+	if requestID := cfg.HTTP.Server.Middlewares.RequestID; requestID != nil {
+		perRequestHeaders = append(perRequestHeaders, requestID.Header)
+	}
+	resultCache := eval.NewResultCache(log, cfg.Cache.Result.MaxSize, cfg.Cache.Result.VaryHeaders, perRequestHeaders, queryResultTTL)
+
+	e := eval.NewEvaluator(log, cacheMr, cacheQr, r, parserCache, lifecycle, resultCache)
 
 	restQl := newRestQl(log, cfg, e, defaultParser)
 
 	app.Handle(http.MethodPost, "/validate-query", restQl.ValidateQuery)
-	app.Handle(http.MethodPost, "/run-query", restQl.RunAdHocQuery)
-	app.Handle(http.MethodGet, "/run-query/:namespace/:queryId/:revision", restQl.RunSavedQuery)
-	app.Handle(http.MethodPost, "/run-query/:namespace/:queryId/:revision", restQl.RunSavedQuery)
+	app.Handle(http.MethodPost, "/explain-query", restQl.ExplainAdHocQuery)
+	app.Handle(http.MethodGet, "/explain-query/:namespace/:queryId/:revision", restQl.ExplainSavedQuery)
+	app.Handle(http.MethodPost, "/explain-query/:namespace/:queryId/:revision", restQl.ExplainSavedQuery)
+	app.Handle(http.MethodPost, "/run-query", instrumentQuery(restQl.RunAdHocQuery))
+	app.Handle(http.MethodGet, "/run-query/:namespace/:queryId/:revision", instrumentQuery(restQl.RunSavedQuery))
+	app.Handle(http.MethodPost, "/run-query/:namespace/:queryId/:revision", instrumentQuery(restQl.RunSavedQuery))
+
+	handler, err := app.RequestHandler(mps)
+	if err != nil {
+		log.Error("failed to apply api middlewares", err)
+		return nil, nil, err
+	}
 
-	return app.RequestHandler(), nil
+	return handler, breakers, nil
 }
 
 // Health constructs a handler for system checks endpoints
-func Health(log restql.Logger, cfg *conf.Config) fasthttp.RequestHandler {
+// wrapped by the middleware plugins enabled for it.
+func Health(log restql.Logger, cfg *conf.Config, breakers *httpclient.CircuitBreakers, mps []restql.MiddlewarePlugin) (fasthttp.RequestHandler, error) {
 	app := newApp(log, cfg, plugins.NoOpLifecycle)
-	check := newCheck(cfg.Build)
+	check := newCheck(cfg.Build, breakers)
 
 	app.Handle(http.MethodGet, "/health", check.Health)
 	app.Handle(http.MethodGet, "/resource-status", check.ResourceStatus)
+	app.Handle(http.MethodGet, "/circuit-breakers", check.CircuitBreakers)
 
-	return app.RequestHandlerWithoutMiddlewares()
+	m := newMetricsHandler(metrics.Default)
+	app.Handle(http.MethodGet, "/metrics", m.Metrics)
+
+	handler, err := app.HealthRequestHandler(mps)
+	if err != nil {
+		log.Error("failed to apply health middlewares", err)
+		return nil, err
+	}
+
+	return handler, nil
 }
 
 // Debug constructs a handler for profiling endpoints
@@ -93,3 +132,21 @@ func Debug(log restql.Logger, cfg *conf.Config) fasthttp.RequestHandler {
 
 	return app.RequestHandlerWithoutMiddlewares()
 }
+
+func makeRetryPolicies(cfg *conf.Config) map[string]domain.RetryPolicy {
+	policies := make(map[string]domain.RetryPolicy)
+	for mapping, policy := range cfg.MappingPolicies {
+		if policy.Retry == nil {
+			continue
+		}
+
+		policies[mapping] = domain.RetryPolicy{
+			Attempts:    policy.Retry.Attempts,
+			Backoff:     policy.Retry.Backoff,
+			Jitter:      policy.Retry.Jitter,
+			StatusCodes: policy.Retry.StatusCodes,
+		}
+	}
+
+	return policies
+}
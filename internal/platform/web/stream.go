@@ -0,0 +1,220 @@
+package web
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/b2wdigital/restQL-golang/v4/internal/domain"
+	"github.com/b2wdigital/restQL-golang/v4/internal/eval"
+	"github.com/b2wdigital/restQL-golang/v4/internal/platform/detached"
+	"github.com/b2wdigital/restQL-golang/v4/pkg/restql"
+	"github.com/valyala/fasthttp"
+)
+
+// Content types that enable the streaming of query results.
+const (
+	ndjsonContentType      = "application/x-ndjson"
+	eventStreamContentType = "text/event-stream"
+)
+
+type streamFormat int
+
+const (
+	streamNDJSON streamFormat = iota
+	streamSSE
+)
+
+// StatementFrame is sent to the client as soon as a statement is done.
+type StatementFrame struct {
+	Type      string      `json:"type"`
+	Statement string      `json:"statement"`
+	Details   interface{} `json:"details"`
+	Result    interface{} `json:"result,omitempty"`
+}
+
+// SummaryFrame is the last frame sent to the client, with
+// the query status and headers otherwise sent in the response.
+type SummaryFrame struct {
+	Type    string            `json:"type"`
+	Status  int               `json:"status"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Error   string            `json:"error,omitempty"`
+}
+
+const (
+	statementFrameType = "statement"
+	summaryFrameType   = "summary"
+)
+
+type queryRunner func(ctx context.Context, listener eval.StatementListener) (domain.Resources, error)
+
+type streamEvent struct {
+	frame interface{}
+	err   error
+	done  bool
+}
+
+// streamFormatFor tells if the client asked for the query
+// results to be streamed, and in which format.
+func streamFormatFor(reqCtx *fasthttp.RequestCtx) (streamFormat, bool) {
+	accept := string(reqCtx.Request.Header.Peek("Accept"))
+
+	switch {
+	case strings.Contains(accept, ndjsonContentType):
+		return streamNDJSON, true
+	case strings.Contains(accept, eventStreamContentType):
+		return streamSSE, true
+	default:
+		return 0, false
+	}
+}
+
+// streamQuery runs the query sending each statement result to the
+// client as soon as it is done, followed by a summary frame. Errors
+// happening before any statement is done, like validation errors,
+// are answered as regular error responses. Otherwise, the query
+// observation is finished with the summary status once the query
+// returns, even if the client goes away before.
+func streamQuery(reqCtx *fasthttp.RequestCtx, ctx context.Context, log restql.Logger, format streamFormat, debug bool, translateErr func(error) error, qo *queryObservation, run queryRunner) error {
+	// The response body is written after the handler returns,
+	// when the middlewares have already cancelled the request
+	// context, hence the query runs on a detached one.
+	ctx, cancel := detachContext(ctx)
+
+	events := make(chan streamEvent)
+	closed := make(chan struct{})
+	send := func(event streamEvent) {
+		select {
+		case events <- event:
+		case <-closed:
+		}
+	}
+
+	// The summary is written by the query goroutine
+	// before runDone is closed, and read only after it.
+	var summary SummaryFrame
+	runDone := make(chan struct{})
+
+	go func() {
+		defer close(runDone)
+
+		resources, err := run(ctx, func(resourceID domain.ResourceID, resource interface{}) {
+			result := parseResource(resource, debug)
+			send(streamEvent{frame: StatementFrame{
+				Type:      statementFrameType,
+				Statement: string(resourceID),
+				Details:   result.Details,
+				Result:    result.Result,
+			}})
+		})
+
+		if err != nil {
+			log.Error("failed to evaluate streamed query", err)
+			err = translateErr(err)
+		}
+
+		summary = makeSummaryFrame(resources, err)
+		send(streamEvent{frame: summary, err: err, done: true})
+	}()
+
+	first := <-events
+	if first.err != nil {
+		close(closed)
+		cancel()
+
+		return RespondError(reqCtx, first.err)
+	}
+
+	switch format {
+	case streamSSE:
+		reqCtx.Response.Header.SetContentType(eventStreamContentType)
+	default:
+		reqCtx.Response.Header.SetContentType(ndjsonContentType)
+	}
+	reqCtx.Response.Header.Set("Cache-Control", "no-cache")
+	reqCtx.Response.SetStatusCode(http.StatusOK)
+
+	qo.stream()
+	reqCtx.SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer func() {
+			close(closed)
+			cancel()
+
+			<-runDone
+			qo.finish(summary.Status)
+		}()
+
+		event := first
+		for {
+			if err := writeFrame(w, format, event.frame); err != nil {
+				log.Debug("failed to write streamed frame", "error", err)
+				return
+			}
+
+			if event.done {
+				return
+			}
+
+			event = <-events
+		}
+	})
+
+	return nil
+}
+
+func makeSummaryFrame(resources domain.Resources, err error) SummaryFrame {
+	if err != nil {
+		status := http.StatusInternalServerError
+		if webErr, ok := err.(*Error); ok {
+			status = webErr.Status
+		}
+
+		return SummaryFrame{Type: summaryFrameType, Status: status, Error: err.Error()}
+	}
+
+	return SummaryFrame{
+		Type:    summaryFrameType,
+		Status:  CalculateStatusCode(resources),
+		Headers: makeHeaders(resources),
+	}
+}
+
+func writeFrame(w *bufio.Writer, format streamFormat, frame interface{}) error {
+	data, err := json.Marshal(frame)
+	if err != nil {
+		return err
+	}
+
+	switch format {
+	case streamSSE:
+		eventType := statementFrameType
+		if _, ok := frame.(SummaryFrame); ok {
+			eventType = summaryFrameType
+		}
+
+		w.WriteString("event: ")
+		w.WriteString(eventType)
+		w.WriteString("\ndata: ")
+		w.Write(data)
+		w.WriteString("\n\n")
+	default:
+		w.Write(data)
+		w.WriteString("\n")
+	}
+
+	return w.Flush()
+}
+
+// detachContext creates a context with the values and the deadline
+// of the parent one, but not bound to its cancellation.
+func detachContext(parent context.Context) (context.Context, context.CancelFunc) {
+	ctx := detached.Context(parent)
+	if deadline, ok := parent.Deadline(); ok {
+		return context.WithDeadline(ctx, deadline)
+	}
+
+	return context.WithCancel(ctx)
+}
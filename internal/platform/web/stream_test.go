@@ -0,0 +1,110 @@
+package web
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/b2wdigital/restQL-golang/v4/internal/domain"
+	"github.com/b2wdigital/restQL-golang/v4/internal/eval"
+	"github.com/b2wdigital/restQL-golang/v4/test"
+	"github.com/pkg/errors"
+	"github.com/valyala/fasthttp"
+)
+
+func TestStreamQuery(t *testing.T) {
+	hero := domain.DoneResource{
+		Status:       200,
+		Success:      true,
+		ResponseBody: map[string]interface{}{"name": "batman"},
+		CacheControl: domain.ResourceCacheControl{MaxAge: domain.ResourceCacheControlValue{Exist: true, Time: 60}},
+	}
+	villain := domain.DoneResource{
+		Status:       404,
+		ResponseBody: map[string]interface{}{},
+		CacheControl: domain.ResourceCacheControl{MaxAge: domain.ResourceCacheControlValue{Exist: true, Time: 30}},
+	}
+
+	run := func(ctx context.Context, listener eval.StatementListener) (domain.Resources, error) {
+		listener("hero", hero)
+		listener("villain", villain)
+		return domain.Resources{"hero": hero, "villain": villain}, nil
+	}
+
+	tests := []struct {
+		name        string
+		accept      string
+		contentType string
+		expected    string
+	}{
+		{
+			"should stream statements as ndjson",
+			"application/x-ndjson",
+			"application/x-ndjson",
+			`{"type":"statement","statement":"hero","details":{"status":200,"success":true,"metadata":{}},"result":{"name":"batman"}}` + "\n" +
+				`{"type":"statement","statement":"villain","details":{"status":404,"success":false,"metadata":{}},"result":{}}` + "\n" +
+				`{"type":"summary","status":404,"headers":{"Cache-Control":"max-age=30"}}` + "\n",
+		},
+		{
+			"should stream statements as server sent events",
+			"text/event-stream",
+			"text/event-stream",
+			"event: statement\ndata: " + `{"type":"statement","statement":"hero","details":{"status":200,"success":true,"metadata":{}},"result":{"name":"batman"}}` + "\n\n" +
+				"event: statement\ndata: " + `{"type":"statement","statement":"villain","details":{"status":404,"success":false,"metadata":{}},"result":{}}` + "\n\n" +
+				"event: summary\ndata: " + `{"type":"summary","status":404,"headers":{"Cache-Control":"max-age=30"}}` + "\n\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reqCtx := &fasthttp.RequestCtx{}
+			reqCtx.Request.Header.Set("Accept", tt.accept)
+
+			format, ok := streamFormatFor(reqCtx)
+			test.Equal(t, ok, true)
+
+			err := streamQuery(reqCtx, context.Background(), test.NoOpLogger{}, format, false, adHocQueryError, nil, run)
+			test.VerifyError(t, err)
+
+			test.Equal(t, reqCtx.Response.StatusCode(), http.StatusOK)
+			test.Equal(t, string(reqCtx.Response.Header.ContentType()), tt.contentType)
+			test.Equal(t, string(reqCtx.Response.Body()), tt.expected)
+		})
+	}
+
+	t.Run("should report errors after the first statement on the summary", func(t *testing.T) {
+		run := func(ctx context.Context, listener eval.StatementListener) (domain.Resources, error) {
+			listener("hero", hero)
+			return nil, eval.TimeoutError{Err: errors.New("query timed out")}
+		}
+
+		reqCtx := &fasthttp.RequestCtx{}
+		err := streamQuery(reqCtx, context.Background(), test.NoOpLogger{}, streamNDJSON, false, adHocQueryError, nil, run)
+		test.VerifyError(t, err)
+
+		expected := `{"type":"statement","statement":"hero","details":{"status":200,"success":true,"metadata":{}},"result":{"name":"batman"}}` + "\n" +
+			`{"type":"summary","status":408,"error":"query timed out"}` + "\n"
+		test.Equal(t, string(reqCtx.Response.Body()), expected)
+	})
+
+	t.Run("should respond errors before the first statement as regular responses", func(t *testing.T) {
+		run := func(ctx context.Context, listener eval.StatementListener) (domain.Resources, error) {
+			return nil, eval.ParserError{Err: errors.New("invalid query syntax")}
+		}
+
+		reqCtx := &fasthttp.RequestCtx{}
+		err := streamQuery(reqCtx, context.Background(), test.NoOpLogger{}, streamNDJSON, false, adHocQueryError, nil, run)
+		test.VerifyError(t, err)
+
+		test.Equal(t, reqCtx.Response.StatusCode(), http.StatusBadRequest)
+		test.Equal(t, string(reqCtx.Response.Body()), `{"error":"invalid query syntax"}`+"\n")
+	})
+
+	t.Run("should not stream without the proper accept header", func(t *testing.T) {
+		reqCtx := &fasthttp.RequestCtx{}
+		reqCtx.Request.Header.Set("Accept", "application/json")
+
+		_, ok := streamFormatFor(reqCtx)
+		test.Equal(t, ok, false)
+	})
+}
@@ -52,10 +52,14 @@ func (a app) Handle(method, url string, handler handler) {
 	}
 }
 
-func (a app) RequestHandler() fasthttp.RequestHandler {
-	mws := middleware.FetchEnabled(a.log, a.config, a.lifecycle)
-	h := middleware.Apply(a.log, a.router.Handler, mws)
-	return h
+func (a app) RequestHandler(mps []restql.MiddlewarePlugin) (fasthttp.RequestHandler, error) {
+	mws := middleware.FetchEnabled(a.log, a.config, a.lifecycle, mps)
+	return middleware.Apply(a.log, a.router.Handler, mws)
+}
+
+func (a app) HealthRequestHandler(mps []restql.MiddlewarePlugin) (fasthttp.RequestHandler, error) {
+	mws := middleware.FetchHealth(a.log, a.config, mps)
+	return middleware.Apply(a.log, a.router.Handler, mws)
 }
 
 func (a app) RequestHandlerWithoutMiddlewares() fasthttp.RequestHandler {
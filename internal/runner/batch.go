@@ -0,0 +1,113 @@
+package runner
+
+import (
+	"strings"
+
+	"github.com/b2wdigital/restQL-golang/v4/internal/domain"
+)
+
+// makeChunks groups the values of a batched parameter, each
+// chunk wrapped as `no-multiplex` to be sent in a single request.
+func makeChunks(values []interface{}, size int) []interface{} {
+	if size <= 0 {
+		size = 1
+	}
+
+	chunks := make([]interface{}, 0, (len(values)+size-1)/size)
+	for start := 0; start < len(values); start += size {
+		end := start + size
+		if end > len(values) {
+			end = len(values)
+		}
+
+		chunks = append(chunks, domain.NoMultiplex{Value: values[start:end]})
+	}
+
+	return chunks
+}
+
+// unbatch replaces the response of each batched statement
+// with one response per value sent in it, so a multiplexed
+// result has the same shape as if it were not batched.
+func unbatch(statement interface{}, response interface{}) interface{} {
+	statements, ok := statement.([]interface{})
+	if !ok {
+		return response
+	}
+
+	responses, ok := response.(domain.DoneResources)
+	if !ok || len(responses) != len(statements) {
+		return response
+	}
+
+	result := make(domain.DoneResources, 0, len(responses))
+	for i, r := range responses {
+		switch stmt := statements[i].(type) {
+		case domain.Statement:
+			dr, ok := r.(domain.DoneResource)
+			if stmt.Batched != nil && ok {
+				result = append(result, splitBatchResponse(*stmt.Batched, dr)...)
+				continue
+			}
+		case []interface{}:
+			r = unbatch(stmt, r)
+		}
+
+		result = append(result, r)
+	}
+
+	return result
+}
+
+// splitBatchResponse makes a response for each value of the chunk.
+// The items of a successful response are matched with the values by
+// the chunk key or, when there is none, by their position. Values
+// without a matching item get an empty body, while failed responses
+// are replicated for every value.
+func splitBatchResponse(chunk domain.BatchChunk, dr domain.DoneResource) []interface{} {
+	result := make([]interface{}, len(chunk.Values))
+
+	if !dr.Success {
+		for i := range chunk.Values {
+			result[i] = dr
+		}
+		return result
+	}
+
+	items, ok := dr.ResponseBody.([]interface{})
+	if !ok {
+		items = []interface{}{dr.ResponseBody}
+	}
+
+	var byKey map[string]interface{}
+	if chunk.Key != "" {
+		path := strings.Split(chunk.Key, ".")
+
+		byKey = make(map[string]interface{}, len(items))
+		for _, item := range items {
+			key, found := getValueFromBody(path, item)
+			if !found {
+				continue
+			}
+
+			byKey[domain.ValueKey(key)] = item
+		}
+	}
+
+	for i, value := range chunk.Values {
+		itemResponse := dr
+
+		switch {
+		case byKey != nil:
+			itemResponse.ResponseBody = byKey[domain.ValueKey(value)]
+		case i < len(items):
+			itemResponse.ResponseBody = items[i]
+		default:
+			itemResponse.ResponseBody = nil
+		}
+
+		result[i] = itemResponse
+	}
+
+	return result
+}
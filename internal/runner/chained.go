@@ -13,6 +13,10 @@ import (
 // that could not be resolved due to a failed response from the upstream dependency.
 const EmptyChained = "__EMPTY_CHAINED__"
 
+// SkippedChained in a token used to represent a chained parameter value
+// that could not be resolved because the target statement was skipped.
+const SkippedChained = "__SKIPPED_CHAINED__"
+
 // ErrInvalidChainedParameter represents an error when a chain parameter value
 // references an unknown statement.
 var ErrInvalidChainedParameter = errors.New("chained parameter targeting unknown statement")
@@ -54,6 +58,11 @@ func resolveStatement(stmt interface{}, doneResources domain.Resources) interfac
 			headers[name] = headerValue
 		}
 
+		if stmt.When != nil {
+			stmt.When = resolveCondition(*stmt.When, doneResources)
+		}
+
+		return stmt
 	case []interface{}:
 		result := make([]interface{}, len(stmt))
 		for i, s := range stmt {
@@ -65,6 +74,13 @@ func resolveStatement(stmt interface{}, doneResources domain.Resources) interfac
 	return stmt
 }
 
+func resolveCondition(condition domain.Condition, doneResources domain.Resources) *domain.Condition {
+	condition.Left = resolveValue(condition.Left, doneResources)
+	condition.Right = resolveValue(condition.Right, doneResources)
+
+	return &condition
+}
+
 func stringify(value interface{}) (string, error) {
 	switch value := value.(type) {
 	case string:
@@ -206,6 +222,10 @@ func resolveWithMultiplexedRequests(path []string, doneRequests domain.DoneResou
 }
 
 func resolveWithSingleRequest(path []string, done domain.DoneResource) interface{} {
+	if done.Skipped {
+		return SkippedChained
+	}
+
 	if done.Status < 200 || done.Status >= 400 {
 		return EmptyChained
 	}
@@ -293,6 +313,16 @@ func validateStatement(stmt interface{}, resources domain.Resources) error {
 				return err
 			}
 		}
+
+		if stmt.When != nil {
+			err := validateParam(stmt.When.Left, resources)
+			if err != nil {
+				return err
+			}
+
+			return validateParam(stmt.When.Right, resources)
+		}
+
 		return nil
 	case []interface{}:
 		for _, s := range stmt {
@@ -237,6 +237,18 @@ func TestResolveChainedValues(t *testing.T) {
 			domain.Resources{"resource-name": domain.Statement{Resource: "resource-name", With: domain.Params{Values: map[string]interface{}{"info": domain.NoMultiplex{Value: map[string]interface{}{"weapon": domain.Chain{"done-resource", "hero", "weapons"}}}}}}},
 			domain.Resources{"done-resource": domain.DoneResource{Status: 200, ResponseBody: test.Unmarshal(`{"hero": {"weapons": ["batarang", "batbelt"]}}`)}},
 		},
+		{
+			"Returns a statement with chained value in when clause resolved",
+			domain.Resources{"resource-name": domain.Statement{Resource: "resource-name", When: &domain.Condition{Left: "premium", Operator: "==", Right: "premium"}}},
+			domain.Resources{"resource-name": domain.Statement{Resource: "resource-name", When: &domain.Condition{Left: domain.Chain{"done-resource", "type"}, Operator: "==", Right: "premium"}}},
+			domain.Resources{"done-resource": domain.DoneResource{Status: 200, ResponseBody: test.Unmarshal(`{"type": "premium"}`)}},
+		},
+		{
+			"Returns a statement with skipped token for chained value targeting skipped statement",
+			domain.Resources{"resource-name": domain.Statement{Resource: "resource-name", With: domain.Params{Values: map[string]interface{}{"id": runner.SkippedChained}}}},
+			domain.Resources{"resource-name": domain.Statement{Resource: "resource-name", With: domain.Params{Values: map[string]interface{}{"id": domain.Chain{"done-resource", "id"}}}}},
+			domain.Resources{"done-resource": domain.DoneResource{Status: 204, Success: true, Skipped: true}},
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,107 @@
+package runner
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/b2wdigital/restQL-golang/v4/internal/domain"
+)
+
+// IsConditionSatisfied evaluates the `when` clause of a statement
+// with its values already resolved.
+// A statement without the `when` clause is always satisfied.
+func IsConditionSatisfied(statement domain.Statement) bool {
+	condition := statement.When
+	if condition == nil {
+		return true
+	}
+
+	var result bool
+	switch condition.Operator {
+	case domain.EqualOperator:
+		result = isEqual(condition.Left, condition.Right)
+	case domain.NotEqualOperator:
+		result = !isEqual(condition.Left, condition.Right)
+	default:
+		result = isTruthy(condition.Left)
+	}
+
+	if condition.Negated {
+		return !result
+	}
+
+	return result
+}
+
+func isTruthy(value interface{}) bool {
+	switch value := normalizeOperand(value).(type) {
+	case nil:
+		return false
+	case bool:
+		return value
+	case string:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return value != ""
+		}
+		return b
+	case int:
+		return value != 0
+	case float64:
+		return value != 0
+	case []interface{}:
+		return len(value) > 0
+	case map[string]interface{}:
+		return len(value) > 0
+	default:
+		return true
+	}
+}
+
+func isEqual(left, right interface{}) bool {
+	left = normalizeOperand(left)
+	right = normalizeOperand(right)
+
+	if left == nil || right == nil {
+		return left == nil && right == nil
+	}
+
+	leftNumber, leftIsNumber := toNumber(left)
+	rightNumber, rightIsNumber := toNumber(right)
+	if leftIsNumber && rightIsNumber {
+		return leftNumber == rightNumber
+	}
+
+	return fmt.Sprintf("%v", left) == fmt.Sprintf("%v", right)
+}
+
+func normalizeOperand(value interface{}) interface{} {
+	switch value := value.(type) {
+	case domain.Chain:
+		return nil
+	case string:
+		if value == EmptyChained || value == SkippedChained {
+			return nil
+		}
+		return value
+	default:
+		return value
+	}
+}
+
+func toNumber(value interface{}) (float64, bool) {
+	switch value := value.(type) {
+	case int:
+		return float64(value), true
+	case float64:
+		return value, true
+	case string:
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return 0, false
+		}
+		return f, true
+	default:
+		return 0, false
+	}
+}
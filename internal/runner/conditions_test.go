@@ -0,0 +1,50 @@
+package runner_test
+
+import (
+	"testing"
+
+	"github.com/b2wdigital/restQL-golang/v4/internal/domain"
+	"github.com/b2wdigital/restQL-golang/v4/internal/runner"
+	"github.com/b2wdigital/restQL-golang/v4/test"
+)
+
+func TestIsConditionSatisfied(t *testing.T) {
+	tests := []struct {
+		name      string
+		condition *domain.Condition
+		expected  bool
+	}{
+		{"should be satisfied when there is no condition", nil, true},
+		{"should be satisfied by true boolean", &domain.Condition{Left: true}, true},
+		{"should not be satisfied by false boolean", &domain.Condition{Left: false}, false},
+		{"should be satisfied by true string", &domain.Condition{Left: "true"}, true},
+		{"should not be satisfied by false string", &domain.Condition{Left: "false"}, false},
+		{"should be satisfied by non empty string", &domain.Condition{Left: "yes"}, true},
+		{"should not be satisfied by empty string", &domain.Condition{Left: ""}, false},
+		{"should not be satisfied by nil", &domain.Condition{Left: nil}, false},
+		{"should not be satisfied by zero", &domain.Condition{Left: 0}, false},
+		{"should not be satisfied by empty list", &domain.Condition{Left: []interface{}{}}, false},
+		{"should not be satisfied by empty chained", &domain.Condition{Left: runner.EmptyChained}, false},
+		{"should not be satisfied by skipped chained", &domain.Condition{Left: runner.SkippedChained}, false},
+		{"should negate the result", &domain.Condition{Negated: true, Left: nil}, true},
+		{"should compare equal strings", &domain.Condition{Left: "premium", Operator: "==", Right: "premium"}, true},
+		{"should compare different strings", &domain.Condition{Left: "basic", Operator: "==", Right: "premium"}, false},
+		{"should compare numbers with numeric strings", &domain.Condition{Left: "10", Operator: "==", Right: 10}, true},
+		{"should compare integers with floats", &domain.Condition{Left: 10, Operator: "==", Right: 10.0}, true},
+		{"should compare booleans with boolean strings", &domain.Condition{Left: true, Operator: "==", Right: "true"}, true},
+		{"should compare nil values", &domain.Condition{Left: nil, Operator: "==", Right: nil}, true},
+		{"should compare not equal values", &domain.Condition{Left: "basic", Operator: "!=", Right: "premium"}, true},
+		{"should compare not equal with nil", &domain.Condition{Left: runner.EmptyChained, Operator: "!=", Right: "premium"}, true},
+		{"should negate comparison", &domain.Condition{Negated: true, Left: "premium", Operator: "==", Right: "premium"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			statement := domain.Statement{Method: "from", Resource: "hero", When: tt.condition}
+
+			got := runner.IsConditionSatisfied(statement)
+
+			test.Equal(t, got, tt.expected)
+		})
+	}
+}
@@ -6,30 +6,33 @@ import (
 	"fmt"
 
 	"github.com/b2wdigital/restQL-golang/v4/internal/domain"
+	"github.com/b2wdigital/restQL-golang/v4/internal/platform/plugins"
 	"github.com/b2wdigital/restQL-golang/v4/pkg/restql"
+	"github.com/pkg/errors"
 )
 
 // ApplyEncoders transform parameter values with encoder functions applied
-// into a Resource collection with the values processed.
-func ApplyEncoders(resources domain.Resources, log restql.Logger) domain.Resources {
+// into a Resource collection with the values processed. Custom functions
+// are applied by the plugins in the given collection.
+func ApplyEncoders(resources domain.Resources, log restql.Logger, functions plugins.Functions) domain.Resources {
 	for resourceID, statement := range resources {
 		if statement, ok := statement.(domain.Statement); ok {
-			resources[resourceID] = applyEncoderToStatement(log, statement)
+			resources[resourceID] = applyEncoderToStatement(log, functions, statement)
 		}
 	}
 
 	return resources
 }
 
-func applyEncoderToStatement(log restql.Logger, statement domain.Statement) domain.Statement {
+func applyEncoderToStatement(log restql.Logger, functions plugins.Functions, statement domain.Statement) domain.Statement {
 	values := statement.With.Values
 	for key, value := range values {
-		result := applyEncoderToValue(log, value)
+		result := applyEncoderToValue(log, functions, value)
 
 		values[key] = result
 	}
 
-	body := applyEncoderToBody(log, statement.With.Body)
+	body := applyEncoderToBody(log, functions, statement.With.Body)
 
 	statement.With.Body = body
 	statement.With.Values = values
@@ -37,24 +40,26 @@ func applyEncoderToStatement(log restql.Logger, statement domain.Statement) doma
 	return statement
 }
 
-func applyEncoderToBody(log restql.Logger, body interface{}) interface{} {
+func applyEncoderToBody(log restql.Logger, functions plugins.Functions, body interface{}) interface{} {
 	switch body := body.(type) {
 	case domain.Base64:
-		return applyBase64encoder(applyEncoderToBody(log, body.Target()))
+		return applyBase64encoder(applyEncoderToBody(log, functions, body.Target()))
 	case domain.JSON:
-		return applyEncoderToBody(log, body.Target())
+		return applyEncoderToBody(log, functions, body.Target())
 	case domain.Flatten:
-		return applyFlattenEncoder(log, applyEncoderToBody(log, body.Target()))
+		return applyFlattenEncoder(log, applyEncoderToBody(log, functions, body.Target()))
+	case domain.CustomFunction:
+		return applyCustomFunction(log, functions, body.Name, applyEncoderToBody(log, functions, body.Target()))
 	case domain.Function:
 		return body.Map(func(target interface{}) interface{} {
-			return applyEncoderToBody(log, target)
+			return applyEncoderToBody(log, functions, target)
 		})
 	default:
 		return body
 	}
 }
 
-func applyEncoderToValue(log restql.Logger, value interface{}) interface{} {
+func applyEncoderToValue(log restql.Logger, functions plugins.Functions, value interface{}) interface{} {
 	switch value := value.(type) {
 	case domain.Base64:
 		target := value.Target()
@@ -62,35 +67,42 @@ func applyEncoderToValue(log restql.Logger, value interface{}) interface{} {
 			return value
 		}
 
-		return applyBase64encoder(applyEncoderToValue(log, value.Target()))
+		return applyBase64encoder(applyEncoderToValue(log, functions, value.Target()))
 	case domain.JSON:
 		target := value.Target()
 		if _, ok := target.(domain.Chain); ok {
 			return value
 		}
 
-		return applyJSONEncoder(log, applyEncoderToValue(log, value.Target()))
+		return applyJSONEncoder(log, applyEncoderToValue(log, functions, value.Target()))
 	case domain.Flatten:
 		target := value.Target()
 		if _, ok := target.(domain.Chain); ok {
 			return value
 		}
 
-		return applyFlattenEncoder(log, applyEncoderToValue(log, value.Target()))
+		return applyFlattenEncoder(log, applyEncoderToValue(log, functions, value.Target()))
+	case domain.CustomFunction:
+		target := value.Target()
+		if _, ok := target.(domain.Chain); ok {
+			return value
+		}
+
+		return applyCustomFunction(log, functions, value.Name, applyEncoderToValue(log, functions, value.Target()))
 	case domain.Function:
 		return value.Map(func(target interface{}) interface{} {
-			return applyEncoderToValue(log, target)
+			return applyEncoderToValue(log, functions, target)
 		})
 	case map[string]interface{}:
 		m := make(map[string]interface{})
 		for k, v := range value {
-			m[k] = applyEncoderToValue(log, v)
+			m[k] = applyEncoderToValue(log, functions, v)
 		}
 		return m
 	case []interface{}:
 		l := make([]interface{}, len(value))
 		for i, v := range value {
-			l[i] = applyEncoderToValue(log, v)
+			l[i] = applyEncoderToValue(log, functions, v)
 		}
 		return l
 	default:
@@ -121,6 +133,57 @@ func applyFlattenEncoder(log restql.Logger, value interface{}) interface{} {
 	return value
 }
 
+// functionError replaces a parameter value when the custom
+// function applied to it fails, so the statement is not executed.
+type functionError struct {
+	err error
+}
+
+func applyCustomFunction(log restql.Logger, functions plugins.Functions, name string, value interface{}) interface{} {
+	result, err := functions.Apply(log, name, value)
+	if err != nil {
+		log.Error("failed to apply custom function", err, "function", name, "target", value)
+		return functionError{err: errors.Wrapf(err, "failed to apply function %s", name)}
+	}
+
+	return result
+}
+
+// findFunctionError returns the error of the first custom
+// function that failed on the statement parameters, if any.
+func findFunctionError(statement domain.Statement) error {
+	for _, value := range statement.With.Values {
+		if err := findFunctionErrorInValue(value); err != nil {
+			return err
+		}
+	}
+
+	return findFunctionErrorInValue(statement.With.Body)
+}
+
+func findFunctionErrorInValue(value interface{}) error {
+	switch value := value.(type) {
+	case functionError:
+		return value.err
+	case domain.Function:
+		return findFunctionErrorInValue(value.Target())
+	case map[string]interface{}:
+		for _, v := range value {
+			if err := findFunctionErrorInValue(v); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		for _, v := range value {
+			if err := findFunctionErrorInValue(v); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
 func flatten(ii []interface{}) []interface{} {
 	var res []interface{}
 	for _, i := range ii {
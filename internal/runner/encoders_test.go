@@ -1,9 +1,15 @@
 package runner_test
 
 import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/b2wdigital/restQL-golang/v4/internal/domain"
+	"github.com/b2wdigital/restQL-golang/v4/internal/platform/plugins"
 	"github.com/b2wdigital/restQL-golang/v4/internal/runner"
 	"github.com/b2wdigital/restQL-golang/v4/pkg/restql"
 	"github.com/b2wdigital/restQL-golang/v4/test"
@@ -250,12 +256,93 @@ func TestApplyEncoders(t *testing.T) {
 	logger := noOpLogger{}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := runner.ApplyEncoders(tt.resources, logger)
+			got := runner.ApplyEncoders(tt.resources, logger, nil)
 			test.Equal(t, got, tt.expected)
 		})
 	}
 }
 
+type upperFunction struct{}
+
+func (u upperFunction) Name() string { return "upper" }
+
+func (u upperFunction) Apply(log restql.Logger, value interface{}) (interface{}, error) {
+	s, ok := value.(string)
+	if !ok {
+		return nil, errors.New("upper function only accepts strings")
+	}
+
+	return strings.ToUpper(s), nil
+}
+
+func TestApplyEncodersWithCustomFunctions(t *testing.T) {
+	functions := plugins.Functions{"upper": upperFunction{}}
+
+	resources := domain.Resources{"hero": domain.Statement{
+		Method:   "from",
+		Resource: "hero",
+		With: domain.Params{
+			Body: domain.CustomFunction{Name: "upper", Value: "body"},
+			Values: map[string]interface{}{
+				"name":    domain.Base64{Value: domain.CustomFunction{Name: "upper", Value: "batman"}},
+				"chained": domain.CustomFunction{Name: "upper", Value: domain.Chain{"done-resource", "name"}},
+			},
+		},
+	}}
+
+	expected := domain.Resources{"hero": domain.Statement{
+		Method:   "from",
+		Resource: "hero",
+		With: domain.Params{
+			Body: "BODY",
+			Values: map[string]interface{}{
+				"name":    "QkFUTUFO",
+				"chained": domain.CustomFunction{Name: "upper", Value: domain.Chain{"done-resource", "name"}},
+			},
+		},
+	}}
+
+	got := runner.ApplyEncoders(resources, noOpLogger{}, functions)
+	test.Equal(t, got, expected)
+}
+
+type panicFunction struct{}
+
+func (p panicFunction) Name() string { return "explode" }
+
+func (p panicFunction) Apply(log restql.Logger, value interface{}) (interface{}, error) {
+	panic("boom")
+}
+
+func TestRunnerCustomFunctionFailure(t *testing.T) {
+	hero, _ := restql.NewMapping("hero", "http://hero.api/")
+	queryCtx := restql.QueryContext{
+		Mappings: map[string]restql.Mapping{"hero": hero},
+	}
+	ctx := restql.WithLogger(context.Background(), noOpLogger{})
+
+	functions := plugins.Functions{"upper": upperFunction{}, "explode": panicFunction{}}
+	client := &batchClient{}
+	executor := runner.NewExecutor(noOpLogger{}, client, time.Second, "", nil, plugins.NoOpLifecycle, nil)
+	r := runner.NewRunner(noOpLogger{}, executor, 5*time.Second, runner.OnTimeoutFail, runner.Concurrency{}, functions)
+
+	query := domain.Query{Statements: []domain.Statement{
+		{Method: domain.FromMethod, Resource: "hero", Alias: "invalid", With: domain.Params{Values: map[string]interface{}{"name": domain.CustomFunction{Name: "upper", Value: 10}}}},
+		{Method: domain.FromMethod, Resource: "hero", Alias: "panic", With: domain.Params{Body: domain.CustomFunction{Name: "explode", Value: "batman"}}},
+	}}
+
+	got, err := r.ExecuteQuery(ctx, query, queryCtx)
+	test.VerifyError(t, err)
+
+	test.Equal(t, client.calls, 0)
+	for _, id := range []domain.ResourceID{"invalid", "panic"} {
+		dr := got[id].(domain.DoneResource)
+		test.Equal(t, dr.Status, http.StatusBadRequest)
+		test.Equal(t, dr.Success, false)
+		test.Equal(t, dr.ErrorKind, domain.FunctionErrorKind)
+	}
+}
+
 type noOpLogger struct{}
 
 func (n noOpLogger) Panic(msg string, fields ...interface{})            {}
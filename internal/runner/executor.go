@@ -2,30 +2,78 @@ package runner
 
 import (
 	"context"
-	"sync"
 	"time"
 
 	"github.com/b2wdigital/restQL-golang/v4/internal/domain"
+	"github.com/b2wdigital/restQL-golang/v4/internal/platform/plugins"
 	"github.com/b2wdigital/restQL-golang/v4/pkg/restql"
 )
 
 // Executor process statements into a result
 // by executing the relevant HTTP calls to
-// the upstream dependency.
+// the upstream dependency or resource plugin.
 type Executor struct {
 	client          domain.HTTPClient
 	log             restql.Logger
 	resourceTimeout time.Duration
 	forwardPrefix   string
+	retryPolicies   map[string]domain.RetryPolicy
+	lifecycle       plugins.Lifecycle
+	resources       plugins.Resources
 }
 
 // NewExecutor constructs an instance of Executor.
-func NewExecutor(log restql.Logger, client domain.HTTPClient, resourceTimeout time.Duration, forwardPrefix string) Executor {
-	return Executor{client: client, log: log, resourceTimeout: resourceTimeout, forwardPrefix: forwardPrefix}
+// The retry policies are indexed by mapping name and are used
+// when the statement does not define its own `retry` clause.
+// The lifecycle is notified before and after each statement, and
+// the resources resolve the statements of mappings with plugin schema.
+func NewExecutor(log restql.Logger, client domain.HTTPClient, resourceTimeout time.Duration, forwardPrefix string, retryPolicies map[string]domain.RetryPolicy, lifecycle plugins.Lifecycle, resources plugins.Resources) Executor {
+	if lifecycle == nil {
+		lifecycle = plugins.NoOpLifecycle
+	}
+
+	return Executor{client: client, log: log, resourceTimeout: resourceTimeout, forwardPrefix: forwardPrefix, retryPolicies: retryPolicies, lifecycle: lifecycle, resources: resources}
 }
 
 // DoStatement process a single statement into a result by executing the relevant HTTP calls to the upstream dependency.
 func (e Executor) DoStatement(ctx context.Context, statement domain.Statement, queryCtx restql.QueryContext) domain.DoneResource {
+	return e.doStatement(ctx, statement, queryCtx, newStatementInfo(statement))
+}
+
+// DoMultiplexedStatement process one of the statements a multiplexed
+// statement was expanded into, identified by its index among them.
+func (e Executor) DoMultiplexedStatement(ctx context.Context, statement domain.Statement, index int, queryCtx restql.QueryContext) domain.DoneResource {
+	return e.doStatement(ctx, statement, queryCtx, newMultiplexedStatementInfo(statement, index))
+}
+
+// NotifyStatement calls the statement lifecycle hooks for a
+// result given in place of executing the statement, like when
+// the query times out or is cancelled before it is requested.
+func (e Executor) NotifyStatement(ctx context.Context, info restql.StatementInfo, result domain.DoneResource) {
+	ctx = e.lifecycle.BeforeStatement(ctx, info)
+	e.lifecycle.AfterStatement(ctx, info, result)
+}
+
+func newStatementInfo(statement domain.Statement) restql.StatementInfo {
+	return restql.StatementInfo{ResourceID: domain.NewResourceID(statement), Method: statement.Method, Resource: statement.Resource}
+}
+
+func newMultiplexedStatementInfo(statement domain.Statement, index int) restql.StatementInfo {
+	info := newStatementInfo(statement)
+	info.Multiplexed = true
+	info.Index = index
+	return info
+}
+
+func (e Executor) doStatement(ctx context.Context, statement domain.Statement, queryCtx restql.QueryContext, info restql.StatementInfo) domain.DoneResource {
+	ctx = e.lifecycle.BeforeStatement(ctx, info)
+	dr := e.executeStatement(ctx, statement, queryCtx)
+	e.lifecycle.AfterStatement(ctx, info, dr)
+
+	return dr
+}
+
+func (e Executor) executeStatement(ctx context.Context, statement domain.Statement, queryCtx restql.QueryContext) domain.DoneResource {
 	log := restql.GetLogger(ctx)
 
 	drOptions := DoneResourceOptions{
@@ -34,6 +82,16 @@ func (e Executor) DoStatement(ctx context.Context, statement domain.Statement, q
 		SMaxAge:      statement.CacheControl.SMaxAge,
 	}
 
+	if !IsConditionSatisfied(statement) || HasSkippedChainedParams(statement) {
+		log.Debug("request execution skipped due to when clause or skipped dependency", "resource", statement.Resource, "method", statement.Method)
+		return NewSkippedResponse(drOptions)
+	}
+
+	if err := findFunctionError(statement); err != nil {
+		log.Debug("request execution failed due to custom function error", "resource", statement.Resource, "method", statement.Method, "error", err)
+		return NewFunctionErrorResponse(err, drOptions)
+	}
+
 	emptyChainedParams := GetEmptyChainedParams(statement)
 	if len(emptyChainedParams) > 0 {
 		emptyChainedResponse := NewEmptyChainedResponse(emptyChainedParams, drOptions)
@@ -45,58 +103,26 @@ func (e Executor) DoStatement(ctx context.Context, statement domain.Statement, q
 
 	log.Debug("executing request for statement", "resource", statement.Resource, "method", statement.Method, "request", request)
 
-	response, err := e.client.Do(ctx, request)
+	if queryCtx.Mappings[statement.Resource].IsPlugin() {
+		return e.doPlugin(ctx, request, statement, drOptions)
+	}
+
+	if statement.Paginate != nil {
+		return e.doPaginated(ctx, request, statement, drOptions)
+	}
+
+	response, attempts, err := e.doRequest(ctx, request, e.retryPolicy(statement))
 	if err != nil {
 		errorResponse := NewErrorResponse(err, request, response, drOptions)
-		log.Debug("request execution failed", "error", err, "resource", statement.Resource, "method", statement.Method, "response", errorResponse)
+		errorResponse.Attempts = attempts
+		log.Debug("request execution failed", "error", err, "resource", statement.Resource, "method", statement.Method, "attempts", attempts, "response", errorResponse)
 		return errorResponse
 	}
 
 	dr := NewDoneResource(request, response, drOptions)
+	dr.Attempts = attempts
 
 	log.Debug("request execution done", "resource", statement.Resource, "method", statement.Method, "response", dr)
 
 	return dr
 }
-
-// DoMultiplexedStatement process multiplexed statements into a result by executing the relevant HTTP calls to the upstream dependency.
-func (e Executor) DoMultiplexedStatement(ctx context.Context, statements []interface{}, queryCtx restql.QueryContext) domain.DoneResources {
-	responseChans := make([]chan interface{}, len(statements))
-	for i := range responseChans {
-		responseChans[i] = make(chan interface{}, 1)
-	}
-
-	var wg sync.WaitGroup
-
-	wg.Add(len(statements))
-	for i, stmt := range statements {
-		i, stmt := i, stmt
-		ch := responseChans[i]
-
-		go func() {
-			response := e.doCurrentStatement(ctx, stmt, queryCtx)
-			ch <- response
-			wg.Done()
-		}()
-	}
-
-	wg.Wait()
-
-	responses := make(domain.DoneResources, len(statements))
-	for i, ch := range responseChans {
-		responses[i] = <-ch
-	}
-
-	return responses
-}
-
-func (e Executor) doCurrentStatement(ctx context.Context, stmt interface{}, queryCtx restql.QueryContext) interface{} {
-	switch stmt := stmt.(type) {
-	case domain.Statement:
-		return e.DoStatement(ctx, stmt, queryCtx)
-	case []interface{}:
-		return e.DoMultiplexedStatement(ctx, stmt, queryCtx)
-	default:
-		return nil
-	}
-}
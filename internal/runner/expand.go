@@ -15,12 +15,14 @@ type parameter struct {
 	paramType string
 	path      []string
 	value     interface{}
+	batch     *domain.Batch
 }
 
 type listParameters struct {
 	paramType string
 	path      []string
 	value     []interface{}
+	batch     *domain.Batch
 }
 
 // MultiplexStatements creates a statement for each value in a
@@ -70,6 +72,11 @@ func multiplex(statement domain.Statement) interface{} {
 			if p.paramType == bodyParamType {
 				newStmt.With.Body = p.value
 			}
+
+			if p.batch != nil {
+				chunk := p.value.(domain.NoMultiplex).Value.([]interface{})
+				newStmt.Batched = &domain.BatchChunk{Values: chunk, Key: p.batch.Key}
+			}
 		}
 
 		result[i] = multiplex(newStmt)
@@ -117,6 +124,12 @@ func findListParameters(path []string, val interface{}) []listParameters {
 	switch val := val.(type) {
 	case domain.NoMultiplex:
 		return []listParameters{}
+	case domain.Batch:
+		values, ok := val.Target().([]interface{})
+		if !ok {
+			return []listParameters{}
+		}
+		return []listParameters{{path: path, paramType: valuesParamType, value: makeChunks(values, val.Size), batch: &val}}
 	case map[string]interface{}:
 		var result []listParameters
 		for k, v := range val {
@@ -139,7 +152,7 @@ func zipListParams(listParams []listParameters) [][]parameter {
 	for i = 0; i < statementCount; i++ {
 		statementParameters := make([]parameter, len(listParams))
 		for j, lp := range listParams {
-			statementParameters[j] = parameter{path: lp.path, paramType: lp.paramType, value: lp.value[i]}
+			statementParameters[j] = parameter{path: lp.path, paramType: lp.paramType, value: lp.value[i], batch: lp.batch}
 		}
 
 		result[i] = statementParameters
@@ -192,6 +192,28 @@ func TestMultiplexStatements(t *testing.T) {
 				},
 			},
 		},
+		{
+			"should make a new statement for each chunk of batched list",
+			domain.Resources{
+				"hero": domain.Statement{Method: "from", Resource: "hero", With: domain.Params{Values: map[string]interface{}{"id": domain.Batch{Value: []interface{}{"1", "2", "3"}, Size: 2, Key: "id"}}}},
+			},
+			domain.Resources{
+				"hero": []interface{}{
+					domain.Statement{
+						Method:   "from",
+						Resource: "hero",
+						With:     domain.Params{Values: map[string]interface{}{"id": domain.NoMultiplex{Value: []interface{}{"1", "2"}}}},
+						Batched:  &domain.BatchChunk{Values: []interface{}{"1", "2"}, Key: "id"},
+					},
+					domain.Statement{
+						Method:   "from",
+						Resource: "hero",
+						With:     domain.Params{Values: map[string]interface{}{"id": domain.NoMultiplex{Value: []interface{}{"3"}}}},
+						Batched:  &domain.BatchChunk{Values: []interface{}{"3"}, Key: "id"},
+					},
+				},
+			},
+		},
 		{
 			"should make a new statement for each list value in object param",
 			domain.Resources{
@@ -0,0 +1,260 @@
+package runner
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/b2wdigital/restQL-golang/v4/internal/domain"
+	"github.com/b2wdigital/restQL-golang/v4/pkg/restql"
+)
+
+const linkHeader = "Link"
+
+// doPaginated follows the pages of the statement response until
+// the last one or the maximum number of pages, concatenating their
+// items into a single result. When a page fails its response is
+// returned instead, with the details of the pages already requested.
+func (e Executor) doPaginated(ctx context.Context, request domain.HTTPRequest, statement domain.Statement, drOptions DoneResourceOptions) domain.DoneResource {
+	log := restql.GetLogger(ctx)
+	pagination := *statement.Paginate
+	policy := e.retryPolicy(statement)
+
+	maxPages := pagination.MaxPages
+	if maxPages <= 0 {
+		maxPages = domain.DefaultMaxPages
+	}
+
+	var (
+		first        domain.HTTPResponse
+		items        []interface{}
+		pages        []domain.PageDetails
+		attempts     int
+		responseTime int64
+	)
+
+	counter := newPageCounter(request, pagination)
+	pageRequest := request
+	for page := 0; page < maxPages; page++ {
+		response, pageAttempts, err := e.doRequest(ctx, pageRequest, policy)
+		attempts += pageAttempts
+		responseTime += response.Duration.Milliseconds()
+
+		pageItems := getPageItems(pagination, response.Body)
+		pages = append(pages, domain.PageDetails{
+			URL:          response.URL,
+			Status:       response.StatusCode,
+			ResponseTime: response.Duration.Milliseconds(),
+			Attempts:     pageAttempts,
+			Items:        len(pageItems),
+		})
+
+		if err != nil {
+			errorResponse := NewErrorResponse(err, pageRequest, response, drOptions)
+			errorResponse.Attempts = attempts
+			errorResponse.Pages = pages
+			log.Debug("paginated request execution failed", "error", err, "resource", statement.Resource, "page", page+1)
+			return errorResponse
+		}
+
+		dr := NewDoneResource(pageRequest, response, drOptions)
+		if !dr.Success {
+			dr.Attempts = attempts
+			dr.Pages = pages
+			log.Debug("paginated request execution failed", "resource", statement.Resource, "page", page+1, "status", response.StatusCode)
+			return dr
+		}
+
+		if page == 0 {
+			first = response
+		}
+		items = append(items, pageItems...)
+
+		next, ok := nextPageRequest(pagination, pageRequest, response, counter, len(pageItems))
+		if !ok {
+			break
+		}
+		pageRequest = next
+	}
+
+	if items == nil {
+		items = []interface{}{}
+	}
+
+	dr := NewDoneResource(request, first, drOptions)
+	dr.ResponseBody = items
+	dr.ResponseTime = responseTime
+	dr.Attempts = attempts
+	dr.Pages = pages
+
+	log.Debug("paginated request execution done", "resource", statement.Resource, "pages", len(pages), "items", len(items))
+
+	return dr
+}
+
+// getPageItems extracts the items of a page, either from the
+// given body path or from the body itself when it is a list.
+func getPageItems(pagination domain.Pagination, body domain.Body) []interface{} {
+	if pagination.Items != "" {
+		var found bool
+		body, found = getValueFromBody(strings.Split(pagination.Items, "."), body)
+		if !found {
+			return nil
+		}
+	}
+
+	switch body := body.(type) {
+	case nil:
+		return nil
+	case []interface{}:
+		return body
+	default:
+		return []interface{}{body}
+	}
+}
+
+// pageCounter holds the value of the page or
+// offset parameter sent in the last request.
+type pageCounter struct {
+	param string
+	value int
+}
+
+func newPageCounter(request domain.HTTPRequest, pagination domain.Pagination) *pageCounter {
+	switch {
+	case pagination.Page != "":
+		return &pageCounter{param: pagination.Page, value: initialCounter(request, pagination.Page, 1)}
+	case pagination.Offset != "":
+		return &pageCounter{param: pagination.Offset, value: initialCounter(request, pagination.Offset, 0)}
+	default:
+		return nil
+	}
+}
+
+func initialCounter(request domain.HTTPRequest, param string, defaultValue int) int {
+	value, found := request.Query[param]
+	if !found {
+		return defaultValue
+	}
+
+	n, err := strconv.Atoi(fmt.Sprintf("%v", value))
+	if err != nil {
+		return defaultValue
+	}
+
+	return n
+}
+
+func nextPageRequest(pagination domain.Pagination, current domain.HTTPRequest, response domain.HTTPResponse, counter *pageCounter, items int) (domain.HTTPRequest, bool) {
+	switch {
+	case pagination.Next != "":
+		value, found := getValueFromBody(strings.Split(pagination.Next, "."), response.Body)
+		link, ok := value.(string)
+		if !found || !ok || link == "" {
+			return current, false
+		}
+		return requestFromLink(current, link)
+	case pagination.Link != "":
+		link, found := findLink(response.Headers, pagination.Link)
+		if !found {
+			return current, false
+		}
+		return requestFromLink(current, link)
+	case counter != nil:
+		if items == 0 {
+			return current, false
+		}
+
+		if pagination.Page != "" {
+			counter.value++
+		} else {
+			counter.value += items
+		}
+
+		next := current
+		next.Query = make(map[string]interface{}, len(current.Query)+1)
+		for k, v := range current.Query {
+			next.Query[k] = v
+		}
+		next.Query[counter.param] = counter.value
+
+		return next, true
+	default:
+		return current, false
+	}
+}
+
+// requestFromLink builds the request of the next page from
+// its URL, resolving it against the current one if relative.
+// Links to another scheme or host are not followed, as the
+// request carries the headers forwarded from the client.
+func requestFromLink(current domain.HTTPRequest, link string) (domain.HTTPRequest, bool) {
+	base := url.URL{Scheme: current.Schema, Host: current.Host, Path: current.Path}
+
+	u, err := base.Parse(link)
+	if err != nil {
+		return current, false
+	}
+
+	if u.Scheme != current.Schema || !strings.EqualFold(u.Host, current.Host) {
+		return current, false
+	}
+
+	next := current
+	next.Schema = u.Scheme
+	next.Host = u.Host
+	next.Path = u.Path
+	next.Query = make(map[string]interface{})
+	for key, values := range u.Query() {
+		if len(values) == 1 {
+			next.Query[key] = values[0]
+			continue
+		}
+
+		list := make([]interface{}, len(values))
+		for i, v := range values {
+			list[i] = v
+		}
+		next.Query[key] = list
+	}
+
+	return next, true
+}
+
+// findLink looks for the target with the given
+// relation in a RFC 5988 Link header.
+func findLink(headers domain.Headers, rel string) (string, bool) {
+	var header string
+	for key, value := range headers {
+		if strings.EqualFold(key, linkHeader) {
+			header = value
+			break
+		}
+	}
+
+	for _, link := range strings.Split(header, ",") {
+		segments := strings.Split(link, ";")
+
+		target := strings.TrimSpace(segments[0])
+		if len(target) < 2 || target[0] != '<' || target[len(target)-1] != '>' {
+			continue
+		}
+
+		for _, param := range segments[1:] {
+			kv := strings.SplitN(strings.TrimSpace(param), "=", 2)
+			if len(kv) != 2 || !strings.EqualFold(strings.TrimSpace(kv[0]), "rel") {
+				continue
+			}
+
+			for _, r := range strings.Fields(strings.Trim(strings.TrimSpace(kv[1]), `"`)) {
+				if strings.EqualFold(r, rel) {
+					return target[1 : len(target)-1], true
+				}
+			}
+		}
+	}
+
+	return "", false
+}
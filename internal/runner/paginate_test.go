@@ -0,0 +1,164 @@
+package runner_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/b2wdigital/restQL-golang/v4/internal/domain"
+	"github.com/b2wdigital/restQL-golang/v4/internal/platform/plugins"
+	"github.com/b2wdigital/restQL-golang/v4/internal/runner"
+	"github.com/b2wdigital/restQL-golang/v4/pkg/restql"
+	"github.com/b2wdigital/restQL-golang/v4/test"
+)
+
+// pageClient answers each call with the next response
+// and keeps the requests received.
+type pageClient struct {
+	responses []domain.HTTPResponse
+	requests  []domain.HTTPRequest
+}
+
+func (pc *pageClient) Do(ctx context.Context, request domain.HTTPRequest) (domain.HTTPResponse, error) {
+	i := len(pc.requests)
+	pc.requests = append(pc.requests, request)
+
+	if i >= len(pc.responses) {
+		return domain.HTTPResponse{StatusCode: http.StatusOK, Body: []interface{}{}}, nil
+	}
+
+	return pc.responses[i], nil
+}
+
+func TestExecutorPaginate(t *testing.T) {
+	mapping, err := restql.NewMapping("hero", "http://hero.api/hero")
+	test.VerifyError(t, err)
+
+	queryCtx := restql.QueryContext{Mappings: map[string]restql.Mapping{"hero": mapping}}
+
+	tests := []struct {
+		name           string
+		pagination     domain.Pagination
+		with           map[string]interface{}
+		responses      []domain.HTTPResponse
+		expectedBody   interface{}
+		expectedStatus int
+		expectedPages  int
+		lastRequest    domain.HTTPRequest
+	}{
+		{
+			"should follow next link in body",
+			domain.Pagination{Next: "links.next", Items: "data", MaxPages: 10},
+			nil,
+			[]domain.HTTPResponse{
+				{StatusCode: 200, Body: map[string]interface{}{"data": []interface{}{"a", "b"}, "links": map[string]interface{}{"next": "/hero?cursor=abc"}}},
+				{StatusCode: 200, Body: map[string]interface{}{"data": []interface{}{"c"}, "links": map[string]interface{}{"next": "http://hero.api/hero?cursor=def"}}},
+				{StatusCode: 200, Body: map[string]interface{}{"data": []interface{}{"d"}, "links": map[string]interface{}{}}},
+			},
+			[]interface{}{"a", "b", "c", "d"},
+			200,
+			3,
+			domain.HTTPRequest{Schema: "http", Host: "hero.api", Path: "/hero", Query: map[string]interface{}{"cursor": "def"}},
+		},
+		{
+			"should not follow next link to another host",
+			domain.Pagination{Next: "links.next", Items: "data", MaxPages: 10},
+			nil,
+			[]domain.HTTPResponse{
+				{StatusCode: 200, Body: map[string]interface{}{"data": []interface{}{"a"}, "links": map[string]interface{}{"next": "http://other.api/hero?cursor=abc"}}},
+				{StatusCode: 200, Body: map[string]interface{}{"data": []interface{}{"b"}, "links": map[string]interface{}{}}},
+			},
+			[]interface{}{"a"},
+			200,
+			1,
+			domain.HTTPRequest{Schema: "http", Host: "hero.api", Path: "/hero", Query: map[string]interface{}{}},
+		},
+		{
+			"should not follow link header to another scheme",
+			domain.Pagination{Link: "next", MaxPages: 10},
+			nil,
+			[]domain.HTTPResponse{
+				{StatusCode: 200, Body: []interface{}{"a"}, Headers: domain.Headers{"Link": `<https://hero.api/hero?page=2>; rel="next"`}},
+				{StatusCode: 200, Body: []interface{}{"b"}},
+			},
+			[]interface{}{"a"},
+			200,
+			1,
+			domain.HTTPRequest{Schema: "http", Host: "hero.api", Path: "/hero", Query: map[string]interface{}{}},
+		},
+		{
+			"should follow next relation in link header",
+			domain.Pagination{Link: "next", MaxPages: 10},
+			nil,
+			[]domain.HTTPResponse{
+				{StatusCode: 200, Body: []interface{}{"a"}, Headers: domain.Headers{"link": `<http://hero.api/hero?page=2>; rel="next", <http://hero.api/hero?page=9>; rel="last"`}},
+				{StatusCode: 200, Body: []interface{}{"b"}, Headers: domain.Headers{"Link": `<http://hero.api/hero?page=1>; rel="prev first"`}},
+			},
+			[]interface{}{"a", "b"},
+			200,
+			2,
+			domain.HTTPRequest{Schema: "http", Host: "hero.api", Path: "/hero", Query: map[string]interface{}{"page": "2"}},
+		},
+		{
+			"should increment page counter until an empty page",
+			domain.Pagination{Page: "page", MaxPages: 10},
+			map[string]interface{}{"page": 3},
+			[]domain.HTTPResponse{
+				{StatusCode: 200, Body: []interface{}{"a", "b"}},
+				{StatusCode: 200, Body: []interface{}{"c"}},
+			},
+			[]interface{}{"a", "b", "c"},
+			200,
+			3,
+			domain.HTTPRequest{Schema: "http", Host: "hero.api", Path: "/hero", Query: map[string]interface{}{"page": 5}},
+		},
+		{
+			"should increment offset counter by the page items",
+			domain.Pagination{Offset: "offset", MaxPages: 2},
+			nil,
+			[]domain.HTTPResponse{
+				{StatusCode: 200, Body: []interface{}{"a", "b"}},
+				{StatusCode: 200, Body: []interface{}{"c"}},
+			},
+			[]interface{}{"a", "b", "c"},
+			200,
+			2,
+			domain.HTTPRequest{Schema: "http", Host: "hero.api", Path: "/hero", Query: map[string]interface{}{"offset": 2}},
+		},
+		{
+			"should return failed page response",
+			domain.Pagination{Page: "page", MaxPages: 10},
+			nil,
+			[]domain.HTTPResponse{
+				{StatusCode: 200, Body: []interface{}{"a"}},
+				{StatusCode: 503, Body: map[string]interface{}{"error": "unavailable"}},
+			},
+			map[string]interface{}{"error": "unavailable"},
+			503,
+			2,
+			domain.HTTPRequest{Schema: "http", Host: "hero.api", Path: "/hero", Query: map[string]interface{}{"page": 2}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &pageClient{responses: tt.responses}
+			executor := runner.NewExecutor(noOpLogger{}, client, time.Second, "", nil, plugins.NoOpLifecycle, nil)
+
+			pagination := tt.pagination
+			statement := domain.Statement{Method: "from", Resource: "hero", With: domain.Params{Values: tt.with}, Paginate: &pagination}
+
+			ctx := restql.WithLogger(context.Background(), noOpLogger{})
+			got := executor.DoStatement(ctx, statement, queryCtx)
+
+			test.Equal(t, got.Status, tt.expectedStatus)
+			test.Equal(t, got.ResponseBody, tt.expectedBody)
+			test.Equal(t, len(got.Pages), tt.expectedPages)
+			test.Equal(t, len(client.requests), tt.expectedPages)
+
+			last := client.requests[len(client.requests)-1]
+			test.Equal(t, domain.HTTPRequest{Schema: last.Schema, Host: last.Host, Path: last.Path, Query: last.Query}, tt.lastRequest)
+		})
+	}
+}
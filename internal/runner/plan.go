@@ -0,0 +1,235 @@
+package runner
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/b2wdigital/restQL-golang/v4/internal/domain"
+	"github.com/b2wdigital/restQL-golang/v4/pkg/restql"
+	"github.com/pkg/errors"
+)
+
+// ErrUnresolvableDependencies represents the event of a query
+// with statements that will never have their dependencies resolved.
+var ErrUnresolvableDependencies = errors.New("query has statements with unresolvable dependencies")
+
+// Plan describes how the Runner will execute a query.
+type Plan struct {
+	Timeout time.Duration
+	Stages  []Stage
+}
+
+// Stage is a group of statements which dependencies
+// are resolved by the previous stages, hence are
+// executed in parallel.
+type Stage struct {
+	Statements []PlannedStatement
+}
+
+// PlannedStatement describes the requests a statement
+// will make to the upstream dependency.
+// Values chained from other statements are represented
+// by placeholders, like `{hero.id}`, and when a statement
+// depends on chained values the final number of requests
+// is only known during execution.
+type PlannedStatement struct {
+	ID          domain.ResourceID
+	Method      string
+	Resource    string
+	DependsOn   []domain.ResourceID
+	Multiplexed bool
+	Chained     bool
+	Requests    []domain.HTTPRequest
+}
+
+// Explain builds the execution plan of a query without
+// executing any HTTP call to the upstream dependencies.
+func (r Runner) Explain(ctx context.Context, query domain.Query, queryCtx restql.QueryContext) (Plan, error) {
+	log := restql.GetLogger(ctx)
+
+	queryTimeout, _ := r.parseQueryTimeout(query)
+
+	resources, err := r.initializeResources(query, queryCtx)
+	if err != nil {
+		return Plan{}, err
+	}
+
+	dependencies := make(map[domain.ResourceID][]domain.ResourceID)
+	for _, stmt := range query.Statements {
+		dependencies[domain.NewResourceID(stmt)] = StatementDependencies(stmt)
+	}
+
+	state := NewState(resources)
+
+	var stages []Stage
+	for !state.HasFinished() {
+		available := state.Available()
+		if len(available) == 0 {
+			log.Debug("failed to build plan", "pending", state.todo)
+			return Plan{}, ErrUnresolvableDependencies
+		}
+
+		ids := make([]string, 0, len(available))
+		for resourceID := range available {
+			ids = append(ids, string(resourceID))
+		}
+		sort.Strings(ids)
+
+		stage := Stage{Statements: make([]PlannedStatement, len(ids))}
+		for i, id := range ids {
+			resourceID := domain.ResourceID(id)
+			stage.Statements[i] = r.planStatement(resourceID, available[resourceID], dependencies[resourceID], queryCtx)
+			state.SetAsRequest(resourceID)
+		}
+
+		for _, id := range ids {
+			state.UpdateDone(domain.ResourceID(id), nil)
+		}
+
+		stages = append(stages, stage)
+	}
+
+	return Plan{Timeout: queryTimeout, Stages: stages}, nil
+}
+
+func (r Runner) planStatement(resourceID domain.ResourceID, stmt interface{}, dependencies []domain.ResourceID, queryCtx restql.QueryContext) PlannedStatement {
+	statements := flattenStatements(stmt)
+
+	ps := PlannedStatement{
+		ID:          resourceID,
+		DependsOn:   dependencies,
+		Multiplexed: isMultiplexed(stmt),
+		Chained:     len(dependencies) > 0,
+		Requests:    make([]domain.HTTPRequest, len(statements)),
+	}
+
+	for i, s := range statements {
+		ps.Method = s.Method
+		ps.Resource = s.Resource
+		ps.Requests[i] = MakeRequest(r.executor.resourceTimeout, r.executor.forwardPrefix, withPlaceholders(s), queryCtx)
+	}
+
+	return ps
+}
+
+func isMultiplexed(stmt interface{}) bool {
+	_, ok := stmt.([]interface{})
+	return ok
+}
+
+func flattenStatements(stmt interface{}) []domain.Statement {
+	switch stmt := stmt.(type) {
+	case domain.Statement:
+		return []domain.Statement{stmt}
+	case []interface{}:
+		var result []domain.Statement
+		for _, s := range stmt {
+			result = append(result, flattenStatements(s)...)
+		}
+		return result
+	default:
+		return nil
+	}
+}
+
+// StatementDependencies returns the identifiers of the statements
+// targeted by chained values in the `with` values and body,
+// `headers` and `when` clauses.
+func StatementDependencies(stmt domain.Statement) []domain.ResourceID {
+	targets := make(map[string]struct{})
+
+	for _, v := range stmt.With.Values {
+		collectChainTargets(v, targets)
+	}
+	collectChainTargets(stmt.With.Body, targets)
+
+	for _, v := range stmt.Headers {
+		collectChainTargets(v, targets)
+	}
+
+	if stmt.When != nil {
+		collectChainTargets(stmt.When.Left, targets)
+		collectChainTargets(stmt.When.Right, targets)
+	}
+
+	ids := make([]string, 0, len(targets))
+	for t := range targets {
+		ids = append(ids, t)
+	}
+	sort.Strings(ids)
+
+	result := make([]domain.ResourceID, len(ids))
+	for i, id := range ids {
+		result[i] = domain.ResourceID(id)
+	}
+
+	return result
+}
+
+func collectChainTargets(value interface{}, targets map[string]struct{}) {
+	switch value := value.(type) {
+	case domain.Chain:
+		if len(value) == 0 {
+			return
+		}
+
+		if target, ok := value[0].(string); ok {
+			targets[target] = struct{}{}
+		}
+	case domain.Function:
+		collectChainTargets(value.Target(), targets)
+	case []interface{}:
+		for _, v := range value {
+			collectChainTargets(v, targets)
+		}
+	case map[string]interface{}:
+		for _, v := range value {
+			collectChainTargets(v, targets)
+		}
+	}
+}
+
+func withPlaceholders(stmt domain.Statement) domain.Statement {
+	s := copyStatement(stmt)
+
+	for key, value := range s.With.Values {
+		s.With.Values[key] = replaceChains(value)
+	}
+	s.With.Body = replaceChains(s.With.Body)
+
+	for key, value := range s.Headers {
+		s.Headers[key] = replaceChains(value)
+	}
+
+	return s
+}
+
+func replaceChains(value interface{}) interface{} {
+	switch value := value.(type) {
+	case domain.Chain:
+		path := make([]string, len(value))
+		for i, p := range value {
+			path[i] = fmt.Sprintf("%v", p)
+		}
+		return fmt.Sprintf("{%s}", strings.Join(path, "."))
+	case domain.Function:
+		return replaceChains(value.Target())
+	case []interface{}:
+		result := make([]interface{}, len(value))
+		for i, v := range value {
+			result[i] = replaceChains(v)
+		}
+		return result
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(value))
+		for k, v := range value {
+			result[k] = replaceChains(v)
+		}
+		return result
+	default:
+		return value
+	}
+}
@@ -0,0 +1,113 @@
+package runner_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/b2wdigital/restQL-golang/v4/internal/domain"
+	"github.com/b2wdigital/restQL-golang/v4/internal/platform/plugins"
+	"github.com/b2wdigital/restQL-golang/v4/internal/runner"
+	"github.com/b2wdigital/restQL-golang/v4/pkg/restql"
+	"github.com/b2wdigital/restQL-golang/v4/test"
+)
+
+func TestExplain(t *testing.T) {
+	heroMapping, err := restql.NewMapping("hero", "http://hero.api/hero/:id")
+	test.VerifyError(t, err)
+
+	sidekickMapping, err := restql.NewMapping("sidekick", "http://sidekick.api/sidekick")
+	test.VerifyError(t, err)
+
+	queryCtx := restql.QueryContext{
+		Mappings: map[string]restql.Mapping{"hero": heroMapping, "sidekick": sidekickMapping},
+	}
+
+	executor := runner.NewExecutor(noOpLogger{}, nil, 1*time.Second, "c_", nil, plugins.NoOpLifecycle, nil)
+	r := runner.NewRunner(noOpLogger{}, executor, 5*time.Second, runner.OnTimeoutFail, runner.Concurrency{}, nil)
+
+	t.Run("should plan independent statements in the same stage", func(t *testing.T) {
+		query := domain.Query{
+			Use: domain.Modifiers{"timeout": 3000},
+			Statements: []domain.Statement{
+				{Method: "from", Resource: "hero", With: domain.Params{Values: map[string]interface{}{"id": "1"}}},
+				{Method: "from", Resource: "sidekick", Timeout: 200},
+			},
+		}
+
+		expected := runner.Plan{
+			Timeout: 3 * time.Second,
+			Stages: []runner.Stage{
+				{Statements: []runner.PlannedStatement{
+					{
+						ID:        "hero",
+						Method:    "from",
+						Resource:  "hero",
+						DependsOn: []domain.ResourceID{},
+						Requests: []domain.HTTPRequest{
+							{Resource: "hero", Method: http.MethodGet, Schema: "http", Host: "hero.api", Path: "/hero/1", Query: map[string]interface{}{}, Headers: domain.Headers{"Content-Type": "application/json"}, Timeout: 1 * time.Second},
+						},
+					},
+					{
+						ID:        "sidekick",
+						Method:    "from",
+						Resource:  "sidekick",
+						DependsOn: []domain.ResourceID{},
+						Requests: []domain.HTTPRequest{
+							{Resource: "sidekick", Method: http.MethodGet, Schema: "http", Host: "sidekick.api", Path: "/sidekick", Query: map[string]interface{}{}, Headers: domain.Headers{"Content-Type": "application/json"}, Timeout: 200 * time.Millisecond},
+						},
+					},
+				}},
+			},
+		}
+
+		got, err := r.Explain(context.Background(), query, queryCtx)
+		test.VerifyError(t, err)
+		test.Equal(t, got, expected)
+	})
+
+	t.Run("should plan chained statements in later stages and expand multiplexed ones", func(t *testing.T) {
+		query := domain.Query{
+			Statements: []domain.Statement{
+				{Method: "from", Resource: "hero", With: domain.Params{Values: map[string]interface{}{"id": []interface{}{"1", "2"}}}},
+				{Method: "from", Resource: "sidekick", With: domain.Params{Values: map[string]interface{}{"hero": domain.Chain{"hero", "id"}}}},
+			},
+		}
+
+		expected := runner.Plan{
+			Timeout: 5 * time.Second,
+			Stages: []runner.Stage{
+				{Statements: []runner.PlannedStatement{
+					{
+						ID:          "hero",
+						Method:      "from",
+						Resource:    "hero",
+						DependsOn:   []domain.ResourceID{},
+						Multiplexed: true,
+						Requests: []domain.HTTPRequest{
+							{Resource: "hero", Method: http.MethodGet, Schema: "http", Host: "hero.api", Path: "/hero/1", Query: map[string]interface{}{}, Headers: domain.Headers{"Content-Type": "application/json"}, Timeout: 1 * time.Second},
+							{Resource: "hero", Method: http.MethodGet, Schema: "http", Host: "hero.api", Path: "/hero/2", Query: map[string]interface{}{}, Headers: domain.Headers{"Content-Type": "application/json"}, Timeout: 1 * time.Second},
+						},
+					},
+				}},
+				{Statements: []runner.PlannedStatement{
+					{
+						ID:        "sidekick",
+						Method:    "from",
+						Resource:  "sidekick",
+						DependsOn: []domain.ResourceID{"hero"},
+						Chained:   true,
+						Requests: []domain.HTTPRequest{
+							{Resource: "sidekick", Method: http.MethodGet, Schema: "http", Host: "sidekick.api", Path: "/sidekick", Query: map[string]interface{}{"hero": "{hero.id}"}, Headers: domain.Headers{"Content-Type": "application/json"}, Timeout: 1 * time.Second},
+						},
+					},
+				}},
+			},
+		}
+
+		got, err := r.Explain(context.Background(), query, queryCtx)
+		test.VerifyError(t, err)
+		test.Equal(t, got, expected)
+	})
+}
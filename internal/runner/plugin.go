@@ -0,0 +1,101 @@
+package runner
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/b2wdigital/restQL-golang/v4/internal/domain"
+	"github.com/b2wdigital/restQL-golang/v4/pkg/restql"
+)
+
+// doPlugin resolves the statement with the resource plugin named
+// by the mapping host, building its result as if it was made by
+// an HTTP call so it can be chained, filtered and aggregated.
+func (e Executor) doPlugin(ctx context.Context, request domain.HTTPRequest, statement domain.Statement, drOptions DoneResourceOptions) domain.DoneResource {
+	log := restql.GetLogger(ctx)
+
+	if request.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, request.Timeout)
+		defer cancel()
+	}
+
+	pluginRequest := restql.ResourceRequest{
+		Resource: statement.Resource,
+		Method:   statement.Method,
+		Values:   makePluginValues(statement.With.Values),
+		Headers:  request.Headers,
+		Body:     request.Body,
+	}
+
+	start := time.Now()
+	pluginResponse, err := e.resources.Resolve(ctx, request.Host, pluginRequest)
+	response := domain.HTTPResponse{
+		URL:        request.Schema + "://" + request.Host,
+		StatusCode: pluginResponse.StatusCode,
+		Headers:    pluginResponse.Headers,
+		Duration:   time.Since(start),
+	}
+
+	if err == nil {
+		response.Body, err = normalizePluginBody(pluginResponse.Body)
+	}
+
+	if err != nil {
+		errorResponse := NewErrorResponse(err, request, response, drOptions)
+		log.Debug("resource plugin execution failed", "error", err, "resource", statement.Resource, "method", statement.Method, "plugin", request.Host)
+		return errorResponse
+	}
+
+	if response.StatusCode == 0 {
+		response.StatusCode = http.StatusOK
+	}
+
+	dr := NewDoneResource(request, response, drOptions)
+
+	log.Debug("resource plugin execution done", "resource", statement.Resource, "method", statement.Method, "plugin", request.Host, "response", dr)
+
+	return dr
+}
+
+func makePluginValues(values map[string]interface{}) map[string]interface{} {
+	if values == nil {
+		return nil
+	}
+
+	result := make(map[string]interface{}, len(values))
+	for key, value := range values {
+		if value, ok := value.(domain.AsBody); ok {
+			result[key] = value.Target()
+			continue
+		}
+
+		result[key] = value
+	}
+
+	return result
+}
+
+// normalizePluginBody converts the body returned by the plugin
+// to the same representation of a body decoded from JSON, as
+// the query evaluation only handles maps, lists and primitives.
+func normalizePluginBody(body interface{}) (domain.Body, error) {
+	if body == nil {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+
+	var result interface{}
+	err = json.Unmarshal(data, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,136 @@
+package runner_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/b2wdigital/restQL-golang/v4/internal/domain"
+	"github.com/b2wdigital/restQL-golang/v4/internal/platform/plugins"
+	"github.com/b2wdigital/restQL-golang/v4/internal/runner"
+	"github.com/b2wdigital/restQL-golang/v4/pkg/restql"
+	"github.com/b2wdigital/restQL-golang/v4/test"
+)
+
+var errFlagsUnavailable = errors.New("flags unavailable")
+
+type flag struct {
+	Name    string `json:"name"`
+	Enabled bool   `json:"enabled"`
+}
+
+// flagsPlugin answers with the flags requested in the
+// `name` parameter and keeps the request received.
+type flagsPlugin struct {
+	request restql.ResourceRequest
+	status  int
+	err     error
+	panics  bool
+}
+
+func (fp *flagsPlugin) Name() string { return "feature-flags" }
+
+func (fp *flagsPlugin) Resolve(ctx context.Context, request restql.ResourceRequest) (restql.ResourceResponse, error) {
+	fp.request = request
+	if fp.panics {
+		panic("flags panic")
+	}
+	if fp.err != nil {
+		return restql.ResourceResponse{}, fp.err
+	}
+
+	return restql.ResourceResponse{StatusCode: fp.status, Body: []flag{{Name: request.Values["name"].(string), Enabled: true}}}, nil
+}
+
+func TestExecutorResourcePlugin(t *testing.T) {
+	mapping, err := restql.NewMapping("flags", "plugin://feature-flags")
+	test.VerifyError(t, err)
+
+	queryCtx := restql.QueryContext{
+		Mappings: map[string]restql.Mapping{"flags": mapping},
+		Input:    restql.QueryInput{Headers: map[string]string{"X-Tid": "1"}},
+	}
+
+	tests := []struct {
+		name           string
+		plugin         *flagsPlugin
+		expectedStatus int
+		expectedBody   interface{}
+		expectedKind   domain.ErrorKind
+	}{
+		{
+			"should resolve statement with plugin",
+			&flagsPlugin{status: http.StatusOK},
+			http.StatusOK,
+			[]interface{}{map[string]interface{}{"name": "dark-mode", "enabled": true}},
+			"",
+		},
+		{
+			"should default to ok status",
+			&flagsPlugin{},
+			http.StatusOK,
+			[]interface{}{map[string]interface{}{"name": "dark-mode", "enabled": true}},
+			"",
+		},
+		{
+			"should keep plugin status",
+			&flagsPlugin{status: http.StatusNotFound},
+			http.StatusNotFound,
+			[]interface{}{map[string]interface{}{"name": "dark-mode", "enabled": true}},
+			"",
+		},
+		{
+			"should fail when plugin returns an error",
+			&flagsPlugin{err: errFlagsUnavailable},
+			0,
+			errFlagsUnavailable.Error(),
+			domain.RequestErrorKind,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resources := plugins.Resources{"feature-flags": tt.plugin}
+			executor := runner.NewExecutor(noOpLogger{}, nil, time.Second, "", nil, plugins.NoOpLifecycle, resources)
+
+			statement := domain.Statement{Method: "from", Resource: "flags", With: domain.Params{Values: map[string]interface{}{"name": "dark-mode"}}}
+
+			ctx := restql.WithLogger(context.Background(), noOpLogger{})
+			got := executor.DoStatement(ctx, statement, queryCtx)
+
+			test.Equal(t, got.Status, tt.expectedStatus)
+			test.Equal(t, got.ResponseBody, tt.expectedBody)
+			test.Equal(t, got.ErrorKind, tt.expectedKind)
+			test.Equal(t, tt.plugin.request.Values, map[string]interface{}{"name": "dark-mode"})
+			test.Equal(t, tt.plugin.request.Headers["X-Tid"], "1")
+		})
+	}
+}
+
+func TestExecutorResourcePluginFailure(t *testing.T) {
+	mapping, err := restql.NewMapping("flags", "plugin://feature-flags")
+	test.VerifyError(t, err)
+
+	queryCtx := restql.QueryContext{Mappings: map[string]restql.Mapping{"flags": mapping}}
+	statement := domain.Statement{Method: "from", Resource: "flags", With: domain.Params{Values: map[string]interface{}{"name": "dark-mode"}}}
+	ctx := restql.WithLogger(context.Background(), noOpLogger{})
+
+	t.Run("should fail when plugin panics", func(t *testing.T) {
+		resources := plugins.Resources{"feature-flags": &flagsPlugin{panics: true}}
+		executor := runner.NewExecutor(noOpLogger{}, nil, time.Second, "", nil, plugins.NoOpLifecycle, resources)
+
+		got := executor.DoStatement(ctx, statement, queryCtx)
+		test.Equal(t, got.Success, false)
+		test.Equal(t, got.ErrorKind, domain.RequestErrorKind)
+	})
+
+	t.Run("should fail when plugin is not registered", func(t *testing.T) {
+		executor := runner.NewExecutor(noOpLogger{}, nil, time.Second, "", nil, plugins.NoOpLifecycle, nil)
+
+		got := executor.DoStatement(ctx, statement, queryCtx)
+		test.Equal(t, got.Success, false)
+		test.Equal(t, got.ResponseBody, "resource plugin feature-flags not found")
+	})
+}
@@ -0,0 +1,143 @@
+package runner
+
+import (
+	"sync"
+)
+
+// DefaultWorkers is the size of the worker pool
+// when none is given in the configuration.
+const DefaultWorkers = 1024
+
+// Concurrency represents the limits on the number of upstream
+// calls running at the same time. Workers bounds the calls of all
+// queries together, while Query and Statement bound the calls of a
+// single query and of a single multiplexed statement. A non-positive
+// Query or Statement limit means that only the pool bounds them.
+// OnSpawn, when set, is called for every goroutine the runner starts.
+type Concurrency struct {
+	Workers   int
+	Query     int
+	Statement int
+	OnSpawn   func()
+}
+
+type task func()
+
+// workerPool runs tasks with at most a fixed number of goroutines.
+// Workers are started on demand and then kept for the next tasks.
+// Submitting never blocks: tasks wait in a FIFO queue until a
+// worker is free, so they are started in submission order.
+type workerPool struct {
+	mu      sync.Mutex
+	cond    *sync.Cond
+	queue   []task
+	size    int
+	workers int
+	idle    int
+	spawner spawner
+}
+
+func newWorkerPool(size int, s spawner) *workerPool {
+	if size <= 0 {
+		size = DefaultWorkers
+	}
+
+	wp := &workerPool{size: size, spawner: s}
+	wp.cond = sync.NewCond(&wp.mu)
+
+	return wp
+}
+
+func (wp *workerPool) submit(t task) {
+	wp.mu.Lock()
+	defer wp.mu.Unlock()
+
+	wp.queue = append(wp.queue, t)
+
+	// Idle workers only leave the count once awake, so a burst
+	// of tasks must start new workers for the ones not covered.
+	if len(wp.queue) > wp.idle && wp.workers < wp.size {
+		wp.workers++
+		wp.spawner.spawn(wp.work)
+		return
+	}
+
+	wp.cond.Signal()
+}
+
+func (wp *workerPool) work() {
+	for {
+		wp.mu.Lock()
+		for len(wp.queue) == 0 {
+			wp.idle++
+			wp.cond.Wait()
+			wp.idle--
+		}
+
+		t := wp.queue[0]
+		wp.queue[0] = nil
+		wp.queue = wp.queue[1:]
+		wp.mu.Unlock()
+
+		t()
+	}
+}
+
+// limiter bounds the number of its tasks running at the same
+// time, forwarding them to the next scheduler, like another
+// limiter or the worker pool, when there is room. As the pool,
+// it never blocks and keeps the submission order.
+type limiter struct {
+	mu       sync.Mutex
+	max      int
+	inFlight int
+	pending  []task
+	next     func(task)
+}
+
+// newLimiter returns the submit function of a limiter.
+// A non-positive max disables the limit.
+func newLimiter(max int, next func(task)) func(task) {
+	if max <= 0 {
+		return next
+	}
+
+	l := &limiter{max: max, next: next}
+	return l.submit
+}
+
+func (l *limiter) submit(t task) {
+	l.mu.Lock()
+	if l.inFlight >= l.max {
+		l.pending = append(l.pending, t)
+		l.mu.Unlock()
+		return
+	}
+	l.inFlight++
+	l.mu.Unlock()
+
+	l.next(l.wrap(t))
+}
+
+func (l *limiter) wrap(t task) task {
+	return func() {
+		t()
+		l.release()
+	}
+}
+
+func (l *limiter) release() {
+	l.mu.Lock()
+	if len(l.pending) == 0 {
+		l.inFlight--
+		l.mu.Unlock()
+		return
+	}
+
+	t := l.pending[0]
+	l.pending[0] = nil
+	l.pending = l.pending[1:]
+	l.mu.Unlock()
+
+	l.next(l.wrap(t))
+}
@@ -36,13 +36,14 @@ func MakeRequest(defaultResourceTimeout time.Duration, forwardPrefix string, sta
 	timeout := parseTimeout(defaultResourceTimeout, statement)
 
 	req := domain.HTTPRequest{
-		Method:  method,
-		Schema:  mapping.Schema(),
-		Host:    mapping.Host(),
-		Path:    path,
-		Query:   queryParams,
-		Headers: headers,
-		Timeout: timeout,
+		Resource: statement.Resource,
+		Method:   method,
+		Schema:   mapping.Schema(),
+		Host:     mapping.Host(),
+		Path:     path,
+		Query:    queryParams,
+		Headers:  headers,
+		Timeout:  timeout,
 	}
 
 	if statement.Method == domain.ToMethod || statement.Method == domain.UpdateMethod || statement.Method == domain.IntoMethod {
@@ -109,13 +110,20 @@ func makeHeaders(statement domain.Statement, queryCtx restql.QueryContext) map[s
 func getForwardHeaders(queryCtx restql.QueryContext) map[string]string {
 	r := make(map[string]string)
 	for k, v := range queryCtx.Input.Headers {
-		if _, found := disallowedHeaders[k]; !found {
+		if IsForwardedHeader(k) {
 			r[k] = v
 		}
 	}
 	return r
 }
 
+// IsForwardedHeader tells if the client header is
+// sent to the upstream dependencies by the statements.
+func IsForwardedHeader(name string) bool {
+	_, found := disallowedHeaders[name]
+	return !found
+}
+
 func makeQueryParams(forwardPrefix string, statement domain.Statement, mapping restql.Mapping, queryCtx restql.QueryContext) map[string]interface{} {
 	queryArgs := getForwardParams(forwardPrefix, queryCtx)
 
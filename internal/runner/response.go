@@ -2,10 +2,13 @@ package runner
 
 import (
 	"bytes"
+	"fmt"
+	"net/http"
 	"regexp"
 	"strconv"
 
 	"github.com/b2wdigital/restQL-golang/v4/internal/domain"
+	"github.com/pkg/errors"
 )
 
 // DoneResourceOptions represents information
@@ -33,6 +36,7 @@ func NewDoneResource(request domain.HTTPRequest, response domain.HTTPResponse, o
 		ResponseHeaders: response.Headers,
 		ResponseBody:    response.Body,
 		ResponseTime:    response.Duration.Milliseconds(),
+		CacheStatus:     response.CacheStatus,
 	}
 
 	return dr
@@ -44,6 +48,7 @@ func NewErrorResponse(err error, request domain.HTTPRequest, response domain.HTT
 		Status:          response.StatusCode,
 		Success:         false,
 		IgnoreErrors:    options.IgnoreErrors,
+		ErrorKind:       makeErrorKind(err),
 		ResponseBody:    err.Error(),
 		Method:          request.Method,
 		URL:             response.URL,
@@ -52,6 +57,90 @@ func NewErrorResponse(err error, request domain.HTTPRequest, response domain.HTT
 		RequestHeaders:  request.Headers,
 		ResponseHeaders: response.Headers,
 		ResponseTime:    response.Duration.Milliseconds(),
+		CacheStatus:     response.CacheStatus,
+	}
+}
+
+// NewTimedOutResponse builds the result of a statement not finished
+// when the query timed out, keeping the shape of multiplexed statements.
+func NewTimedOutResponse(statement interface{}) interface{} {
+	switch statement := statement.(type) {
+	case domain.Statement:
+		return domain.DoneResource{
+			Status:       http.StatusRequestTimeout,
+			Success:      false,
+			IgnoreErrors: statement.IgnoreErrors,
+			ErrorKind:    domain.TimeoutErrorKind,
+			ResponseBody: ErrQueryTimedOut.Error(),
+		}
+	case []interface{}:
+		responses := make(domain.DoneResources, len(statement))
+		for i, s := range statement {
+			responses[i] = NewTimedOutResponse(s)
+		}
+		return responses
+	default:
+		return domain.DoneResource{
+			Status:       http.StatusRequestTimeout,
+			ErrorKind:    domain.TimeoutErrorKind,
+			ResponseBody: ErrQueryTimedOut.Error(),
+		}
+	}
+}
+
+// NewCancelledResponse builds the result of a statement not finished
+// when the query was cancelled by the failure of another statement.
+func NewCancelledResponse(statement interface{}, failedResource domain.ResourceID) interface{} {
+	switch statement := statement.(type) {
+	case []interface{}:
+		responses := make(domain.DoneResources, len(statement))
+		for i, s := range statement {
+			responses[i] = NewCancelledResponse(s, failedResource)
+		}
+		return responses
+	default:
+		var ignoreErrors bool
+		if stmt, ok := statement.(domain.Statement); ok {
+			ignoreErrors = stmt.IgnoreErrors
+		}
+
+		return domain.DoneResource{
+			Success:      false,
+			Skipped:      true,
+			IgnoreErrors: ignoreErrors,
+			ErrorKind:    domain.CancelledErrorKind,
+			ResponseBody: fmt.Sprintf("The request was cancelled due to the failure of the %s statement", failedResource),
+		}
+	}
+}
+
+// IsFailedResponse tells if the statement result is an error
+// that should fail the query, which are the ones not skipped,
+// not successful and not marked to ignore errors.
+func IsFailedResponse(response interface{}) bool {
+	switch response := response.(type) {
+	case domain.DoneResource:
+		return !response.Success && !response.Skipped && !response.IgnoreErrors
+	case domain.DoneResources:
+		for _, r := range response {
+			if IsFailedResponse(r) {
+				return true
+			}
+		}
+		return false
+	default:
+		return false
+	}
+}
+
+func makeErrorKind(err error) domain.ErrorKind {
+	switch {
+	case errors.Is(err, domain.ErrCircuitOpen):
+		return domain.CircuitOpenErrorKind
+	case errors.Is(err, domain.ErrRequestTimeout):
+		return domain.TimeoutErrorKind
+	default:
+		return domain.RequestErrorKind
 	}
 }
 
@@ -76,12 +165,37 @@ func NewEmptyChainedResponse(params []string, options DoneResourceOptions) domai
 	}
 }
 
+// NewFunctionErrorResponse builds a DoneResource for a statement
+// not executed due to the failure of a custom function applied
+// to its parameters.
+func NewFunctionErrorResponse(err error, options DoneResourceOptions) domain.DoneResource {
+	return domain.DoneResource{
+		Status:       http.StatusBadRequest,
+		Success:      false,
+		IgnoreErrors: options.IgnoreErrors,
+		ErrorKind:    domain.FunctionErrorKind,
+		ResponseBody: err.Error(),
+	}
+}
+
+// NewSkippedResponse builds a DoneResource for a statement
+// that was not executed due to its `when` clause or
+// to a dependency on a skipped statement.
+func NewSkippedResponse(options DoneResourceOptions) domain.DoneResource {
+	return domain.DoneResource{
+		Status:       http.StatusNoContent,
+		Success:      true,
+		IgnoreErrors: options.IgnoreErrors,
+		Skipped:      true,
+	}
+}
+
 // GetEmptyChainedParams returns the chain parameters that
 // could not be resolved.
 func GetEmptyChainedParams(statement domain.Statement) []string {
 	var r []string
 	for key, value := range statement.With.Values {
-		if isEmptyChained(value) {
+		if hasChainedToken(value, EmptyChained) {
 			r = append(r, key)
 		}
 	}
@@ -89,11 +203,29 @@ func GetEmptyChainedParams(statement domain.Statement) []string {
 	return r
 }
 
-func isEmptyChained(value interface{}) bool {
+// HasSkippedChainedParams returns true if any chain parameter
+// or header references a skipped statement.
+func HasSkippedChainedParams(statement domain.Statement) bool {
+	for _, value := range statement.With.Values {
+		if hasChainedToken(value, SkippedChained) {
+			return true
+		}
+	}
+
+	for _, value := range statement.Headers {
+		if hasChainedToken(value, SkippedChained) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func hasChainedToken(value interface{}, token string) bool {
 	switch value := value.(type) {
 	case map[string]interface{}:
 		for _, v := range value {
-			if isEmptyChained(v) {
+			if hasChainedToken(v, token) {
 				return true
 			}
 		}
@@ -101,14 +233,14 @@ func isEmptyChained(value interface{}) bool {
 		return false
 	case []interface{}:
 		for _, v := range value {
-			if isEmptyChained(v) {
+			if hasChainedToken(v, token) {
 				return true
 			}
 		}
 
 		return false
 	default:
-		return value == EmptyChained
+		return value == token
 	}
 }
 
@@ -7,6 +7,7 @@ import (
 	"github.com/b2wdigital/restQL-golang/v4/internal/domain"
 	"github.com/b2wdigital/restQL-golang/v4/internal/runner"
 	"github.com/b2wdigital/restQL-golang/v4/test"
+	"github.com/pkg/errors"
 )
 
 func TestNewDoneResource(t *testing.T) {
@@ -210,6 +211,7 @@ func TestNewTimeoutResponse(t *testing.T) {
 				Status:         408,
 				Success:        false,
 				IgnoreErrors:   false,
+				ErrorKind:      domain.TimeoutErrorKind,
 				URL:            "http://hero.io/api",
 				RequestHeaders: map[string]string{"X-TID": "12345abdef"},
 				RequestParams:  map[string]interface{}{"id": "123456"},
@@ -236,6 +238,7 @@ func TestNewTimeoutResponse(t *testing.T) {
 				Status:         408,
 				Success:        false,
 				IgnoreErrors:   true,
+				ErrorKind:      domain.TimeoutErrorKind,
 				URL:            "http://hero.io/api",
 				RequestHeaders: map[string]string{"X-TID": "12345abdef"},
 				RequestParams:  map[string]interface{}{"id": "123456"},
@@ -254,6 +257,27 @@ func TestNewTimeoutResponse(t *testing.T) {
 	}
 }
 
+func TestNewErrorResponseKind(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected domain.ErrorKind
+	}{
+		{"should classify timeout error", domain.ErrRequestTimeout, domain.TimeoutErrorKind},
+		{"should classify open circuit error", domain.ErrCircuitOpen, domain.CircuitOpenErrorKind},
+		{"should classify wrapped open circuit error", errors.Wrap(domain.ErrCircuitOpen, "hero"), domain.CircuitOpenErrorKind},
+		{"should classify other errors as request error", errors.New("connection refused"), domain.RequestErrorKind},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := runner.NewErrorResponse(tt.err, domain.HTTPRequest{}, domain.HTTPResponse{}, runner.DoneResourceOptions{})
+
+			test.Equal(t, got.ErrorKind, tt.expected)
+		})
+	}
+}
+
 func TestNewEmptyChainedResponse(t *testing.T) {
 	t.Run("should create response for single empty chained param", func(t *testing.T) {
 		params := []string{"id"}
@@ -0,0 +1,130 @@
+package runner
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"time"
+
+	"github.com/b2wdigital/restQL-golang/v4/internal/domain"
+	"github.com/pkg/errors"
+)
+
+// maxBackoff limits the wait between attempts,
+// however large the backoff and the attempt are.
+const maxBackoff = 30 * time.Second
+
+// maxBackoffShift limits the doubling of the backoff,
+// avoiding an overflow on policies with many attempts.
+const maxBackoffShift = 16
+
+var defaultRetryableStatusCodes = []int{
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// doRequest executes the HTTP call to the upstream dependency
+// retrying it according to the policy. All attempts, including
+// the backoff between them, must fit in the statement timeout
+// and in the query deadline present in the context.
+func (e Executor) doRequest(ctx context.Context, request domain.HTTPRequest, policy *domain.RetryPolicy) (domain.HTTPResponse, int, error) {
+	if policy == nil || policy.Attempts <= 1 {
+		response, err := e.client.Do(ctx, request)
+		return response, 1, err
+	}
+
+	deadline := time.Now().Add(request.Timeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+
+	attempt := 0
+	for {
+		attempt++
+
+		req := request
+		if remaining := time.Until(deadline); remaining < req.Timeout {
+			req.Timeout = remaining
+		}
+
+		response, err := e.client.Do(ctx, req)
+		if attempt >= policy.Attempts || !isRetryable(policy, response, err) {
+			return response, attempt, err
+		}
+
+		wait := backoff(policy, attempt)
+		if time.Until(deadline) <= wait {
+			return response, attempt, err
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return response, attempt, err
+		}
+	}
+}
+
+// retryPolicy returns the statement `retry` clause or the mapping
+// default policy. As a retried write may be applied twice, the
+// mapping policy is only used by the `from` and `delete` methods.
+func (e Executor) retryPolicy(statement domain.Statement) *domain.RetryPolicy {
+	if statement.Retry != nil {
+		return statement.Retry
+	}
+
+	if statement.Method != domain.FromMethod && statement.Method != domain.DeleteMethod {
+		return nil
+	}
+
+	policy, found := e.retryPolicies[statement.Resource]
+	if !found {
+		return nil
+	}
+
+	return &policy
+}
+
+func isRetryable(policy *domain.RetryPolicy, response domain.HTTPResponse, err error) bool {
+	if errors.Is(err, domain.ErrCircuitOpen) {
+		return false
+	}
+
+	if err != nil {
+		return true
+	}
+
+	statusCodes := policy.StatusCodes
+	if len(statusCodes) == 0 {
+		statusCodes = defaultRetryableStatusCodes
+	}
+
+	for _, s := range statusCodes {
+		if response.StatusCode == s {
+			return true
+		}
+	}
+
+	return false
+}
+
+func backoff(policy *domain.RetryPolicy, attempt int) time.Duration {
+	shift := attempt - 1
+	if shift > maxBackoffShift {
+		shift = maxBackoffShift
+	}
+
+	wait := policy.Backoff * time.Duration(1<<uint(shift))
+	if wait > maxBackoff || wait < 0 {
+		wait = maxBackoff
+	}
+
+	if policy.Jitter > 0 {
+		wait += time.Duration(rand.Int63n(int64(policy.Jitter)))
+	}
+
+	return wait
+}
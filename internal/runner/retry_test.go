@@ -0,0 +1,138 @@
+package runner_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/b2wdigital/restQL-golang/v4/internal/domain"
+	"github.com/b2wdigital/restQL-golang/v4/internal/platform/plugins"
+	"github.com/b2wdigital/restQL-golang/v4/internal/runner"
+	"github.com/b2wdigital/restQL-golang/v4/pkg/restql"
+	"github.com/b2wdigital/restQL-golang/v4/test"
+)
+
+type sequenceClient struct {
+	responses []domain.HTTPResponse
+	errors    []error
+	calls     int
+}
+
+func (sc *sequenceClient) Do(ctx context.Context, request domain.HTTPRequest) (domain.HTTPResponse, error) {
+	i := sc.calls
+	if i >= len(sc.responses) {
+		i = len(sc.responses) - 1
+	}
+	sc.calls++
+
+	var err error
+	if i < len(sc.errors) {
+		err = sc.errors[i]
+	}
+
+	return sc.responses[i], err
+}
+
+func TestExecutorRetry(t *testing.T) {
+	mapping, err := restql.NewMapping("hero", "http://hero.api/hero")
+	test.VerifyError(t, err)
+
+	queryCtx := restql.QueryContext{Mappings: map[string]restql.Mapping{"hero": mapping}}
+
+	tests := []struct {
+		name             string
+		statement        domain.Statement
+		policies         map[string]domain.RetryPolicy
+		client           *sequenceClient
+		expectedStatus   int
+		expectedAttempts int
+	}{
+		{
+			"should make a single attempt without retry policy",
+			domain.Statement{Method: "from", Resource: "hero"},
+			nil,
+			&sequenceClient{responses: []domain.HTTPResponse{{StatusCode: http.StatusServiceUnavailable}, {StatusCode: http.StatusOK}}},
+			http.StatusServiceUnavailable,
+			1,
+		},
+		{
+			"should retry on default retryable status codes",
+			domain.Statement{Method: "from", Resource: "hero", Retry: &domain.RetryPolicy{Attempts: 3}},
+			nil,
+			&sequenceClient{responses: []domain.HTTPResponse{{StatusCode: http.StatusBadGateway}, {StatusCode: http.StatusServiceUnavailable}, {StatusCode: http.StatusOK}}},
+			http.StatusOK,
+			3,
+		},
+		{
+			"should retry on request error",
+			domain.Statement{Method: "from", Resource: "hero", Retry: &domain.RetryPolicy{Attempts: 2}},
+			nil,
+			&sequenceClient{
+				responses: []domain.HTTPResponse{{StatusCode: http.StatusRequestTimeout}, {StatusCode: http.StatusOK}},
+				errors:    []error{domain.ErrRequestTimeout},
+			},
+			http.StatusOK,
+			2,
+		},
+		{
+			"should not retry status codes outside the policy",
+			domain.Statement{Method: "from", Resource: "hero", Retry: &domain.RetryPolicy{Attempts: 3, StatusCodes: []int{http.StatusInternalServerError}}},
+			nil,
+			&sequenceClient{responses: []domain.HTTPResponse{{StatusCode: http.StatusBadGateway}, {StatusCode: http.StatusOK}}},
+			http.StatusBadGateway,
+			1,
+		},
+		{
+			"should stop after maximum attempts",
+			domain.Statement{Method: "from", Resource: "hero", Retry: &domain.RetryPolicy{Attempts: 2}},
+			nil,
+			&sequenceClient{responses: []domain.HTTPResponse{{StatusCode: http.StatusBadGateway}}},
+			http.StatusBadGateway,
+			2,
+		},
+		{
+			"should use mapping policy when statement has none",
+			domain.Statement{Method: "from", Resource: "hero"},
+			map[string]domain.RetryPolicy{"hero": {Attempts: 2}},
+			&sequenceClient{responses: []domain.HTTPResponse{{StatusCode: http.StatusGatewayTimeout}, {StatusCode: http.StatusOK}}},
+			http.StatusOK,
+			2,
+		},
+		{
+			"should not use mapping policy on write statement",
+			domain.Statement{Method: "to", Resource: "hero"},
+			map[string]domain.RetryPolicy{"hero": {Attempts: 2}},
+			&sequenceClient{responses: []domain.HTTPResponse{{StatusCode: http.StatusGatewayTimeout}, {StatusCode: http.StatusOK}}},
+			http.StatusGatewayTimeout,
+			1,
+		},
+		{
+			"should retry write statement with its own retry clause",
+			domain.Statement{Method: "update", Resource: "hero", Retry: &domain.RetryPolicy{Attempts: 2}},
+			map[string]domain.RetryPolicy{"hero": {Attempts: 3}},
+			&sequenceClient{responses: []domain.HTTPResponse{{StatusCode: http.StatusGatewayTimeout}, {StatusCode: http.StatusOK}}},
+			http.StatusOK,
+			2,
+		},
+		{
+			"should not retry when backoff exceeds statement timeout",
+			domain.Statement{Method: "from", Resource: "hero", Timeout: 50, Retry: &domain.RetryPolicy{Attempts: 3, Backoff: time.Second}},
+			nil,
+			&sequenceClient{responses: []domain.HTTPResponse{{StatusCode: http.StatusBadGateway}, {StatusCode: http.StatusOK}}},
+			http.StatusBadGateway,
+			1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			executor := runner.NewExecutor(noOpLogger{}, tt.client, time.Second, "", tt.policies, plugins.NoOpLifecycle, nil)
+
+			got := executor.DoStatement(context.Background(), tt.statement, queryCtx)
+
+			test.Equal(t, got.Status, tt.expectedStatus)
+			test.Equal(t, got.Attempts, tt.expectedAttempts)
+		})
+	}
+}
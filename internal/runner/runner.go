@@ -2,9 +2,11 @@ package runner
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	"github.com/b2wdigital/restQL-golang/v4/internal/domain"
+	"github.com/b2wdigital/restQL-golang/v4/internal/platform/plugins"
 	"github.com/b2wdigital/restQL-golang/v4/pkg/restql"
 	"github.com/pkg/errors"
 )
@@ -13,6 +15,15 @@ import (
 // exceed the maximum execution time for it.
 var ErrQueryTimedOut = errors.New("query timed out")
 
+// Behaviours of a query when it times out.
+const (
+	// OnTimeoutFail discards all results and fails the query.
+	OnTimeoutFail = domain.OnTimeoutFail
+	// OnTimeoutPartial returns the results of the finished statements,
+	// marking the unfinished ones as timed out.
+	OnTimeoutPartial = domain.OnTimeoutPartial
+)
+
 // Runner process a query into a Resource collection
 // with the results in the most efficient way.
 // All statements that can be executed in parallel,
@@ -21,19 +32,48 @@ type Runner struct {
 	log                restql.Logger
 	executor           Executor
 	globalQueryTimeout time.Duration
+	onTimeout          string
+	concurrency        Concurrency
+	pool               *workerPool
+	functions          plugins.Functions
+	spawner            spawner
 }
 
-// NewRunner returns a Runner instance.
-func NewRunner(log restql.Logger, executor Executor, globalQueryTimeout time.Duration) Runner {
+// NewRunner returns a Runner instance. The onTimeout parameter
+// defines the default behaviour of queries that time out, which
+// can be overridden by the query `use on-timeout` modifier.
+// The upstream calls of all queries are made by a worker pool
+// bounded by the concurrency parameter, whose query limit can
+// be overridden by the query `use max-concurrency` modifier.
+// Custom functions in the statements parameters are applied
+// by the given function plugins.
+func NewRunner(log restql.Logger, executor Executor, globalQueryTimeout time.Duration, onTimeout string, concurrency Concurrency, functions plugins.Functions) Runner {
 	return Runner{
 		log:                log,
 		executor:           executor,
 		globalQueryTimeout: globalQueryTimeout,
+		onTimeout:          onTimeout,
+		concurrency:        concurrency,
+		pool:               newWorkerPool(concurrency.Workers, concurrency.OnSpawn),
+		functions:          functions,
+		spawner:            concurrency.OnSpawn,
 	}
 }
 
+// StatementListener receives the response of a statement
+// as soon as it is done, before the whole query finishes.
+type StatementListener func(resourceID domain.ResourceID, response interface{})
+
 // ExecuteQuery process a query into a Resource collection.
 func (r Runner) ExecuteQuery(ctx context.Context, query domain.Query, queryCtx restql.QueryContext) (domain.Resources, error) {
+	return r.StreamQuery(ctx, query, queryCtx, nil)
+}
+
+// StreamQuery process a query into a Resource collection,
+// notifying the listener of each statement done. The listener
+// is called sequentially, outside of the query execution flow,
+// and all calls are finished when StreamQuery returns.
+func (r Runner) StreamQuery(ctx context.Context, query domain.Query, queryCtx restql.QueryContext, listener StatementListener) (domain.Resources, error) {
 	log := restql.GetLogger(ctx)
 
 	var cancel context.CancelFunc
@@ -45,6 +85,15 @@ func (r Runner) ExecuteQuery(ctx context.Context, query domain.Query, queryCtx r
 	}
 	defer cancel()
 
+	partial := r.parseOnTimeout(query) == OnTimeoutPartial
+	failFast := parseFailFast(query)
+
+	// Requests are made on their own context, so they
+	// can be cancelled without finishing the query.
+	requestCtx, cancelRequests := context.WithCancel(ctx)
+	defer cancelRequests()
+	cancellation := &requestCancellation{cancel: cancelRequests}
+
 	resources, err := r.initializeResources(query, queryCtx)
 	if err != nil {
 		return nil, err
@@ -52,44 +101,107 @@ func (r Runner) ExecuteQuery(ctx context.Context, query domain.Query, queryCtx r
 
 	state := NewState(resources)
 
-	requestCh := make(chan request, 10)
-	resultCh := make(chan result, 10)
+	// Each resource has exactly one result, so workers
+	// never block delivering them, even after the query ends.
+	resultCh := make(chan result, len(resources))
 	outputCh := make(chan domain.Resources)
-	errorCh := make(chan error)
+
+	dispatcher := &dispatcher{
+		executor:       r.executor,
+		queryCtx:       queryCtx,
+		ctx:            requestCtx,
+		cancellation:   cancellation,
+		resultCh:       resultCh,
+		submit:         newLimiter(r.parseMaxConcurrency(query), r.pool.submit),
+		statementLimit: r.concurrency.Statement,
+	}
 
 	stateWorker := &stateWorker{
 		log:       log,
-		requestCh: requestCh,
+		functions: r.functions,
+		dispatch:  dispatcher.dispatch,
+		notify:    dispatcher.notify,
 		resultCh:  resultCh,
 		outputCh:  outputCh,
 		state:     state,
+		partial:   partial,
 		ctx:       ctx,
 	}
+	if failFast {
+		stateWorker.cancellation = cancellation
+	}
 
-	requestWorker := &requestWorker{
-		requestCh: requestCh,
-		resultCh:  resultCh,
-		errorCh:   errorCh,
-		executor:  r.executor,
-		queryCtx:  queryCtx,
-		ctx:       ctx,
+	forwarded := make(chan struct{})
+	if listener != nil {
+		stateWorker.doneCh = make(chan result, len(resources))
+		r.spawner.spawn(func() {
+			defer close(forwarded)
+			for res := range stateWorker.doneCh {
+				listener(res.ResourceIdentifier, res.Response)
+			}
+		})
+	} else {
+		close(forwarded)
 	}
+	defer func() {
+		cancel()
+		<-forwarded
+	}()
 
-	go stateWorker.Run()
-	go requestWorker.Run()
+	r.spawner.spawn(stateWorker.Run)
 
 	select {
 	case output := <-outputCh:
 		return output, nil
-	case err := <-errorCh:
-		log.Debug("an error occurred when running the query", "error", err)
-		return nil, err
 	case <-ctx.Done():
+		if partial {
+			log.Debug("query timed out, returning partial results")
+			return <-outputCh, nil
+		}
+
 		log.Debug("query timed out")
 		return nil, ErrQueryTimedOut
 	}
 }
 
+func parseFailFast(query domain.Query) bool {
+	failFast, found := query.Use["fail-fast"]
+	if !found {
+		return false
+	}
+
+	enabled, ok := failFast.(bool)
+	return ok && enabled
+}
+
+func (r Runner) parseMaxConcurrency(query domain.Query) int {
+	maxConcurrency, found := query.Use["max-concurrency"]
+	if !found {
+		return r.concurrency.Query
+	}
+
+	limit, ok := maxConcurrency.(int)
+	if !ok || limit <= 0 {
+		return r.concurrency.Query
+	}
+
+	return limit
+}
+
+func (r Runner) parseOnTimeout(query domain.Query) string {
+	onTimeout, found := query.Use["on-timeout"]
+	if !found {
+		return r.onTimeout
+	}
+
+	behaviour, ok := onTimeout.(string)
+	if !ok {
+		return r.onTimeout
+	}
+
+	return behaviour
+}
+
 func (r Runner) parseQueryTimeout(query domain.Query) (time.Duration, bool) {
 	timeout, found := query.Use["timeout"]
 	if !found {
@@ -113,17 +225,12 @@ func (r Runner) initializeResources(query domain.Query, queryCtx restql.QueryCon
 	}
 
 	resources = ApplyModifiers(resources, query.Use)
-	resources = ApplyEncoders(resources, r.log)
+	resources = ApplyEncoders(resources, r.log, r.functions)
 	resources = MultiplexStatements(resources)
 
 	return resources, nil
 }
 
-type request struct {
-	ResourceIdentifier domain.ResourceID
-	Statement          interface{}
-}
-
 type result struct {
 	ResourceIdentifier domain.ResourceID
 	Response           interface{}
@@ -131,14 +238,26 @@ type result struct {
 
 type stateWorker struct {
 	log       restql.Logger
-	requestCh chan request
+	functions plugins.Functions
+	dispatch  func(resourceID domain.ResourceID, statement interface{})
+	notify    func(statement interface{}, response interface{})
 	resultCh  chan result
 	outputCh  chan domain.Resources
+	doneCh    chan result
 	state     *State
+	partial   bool
 	ctx       context.Context
+
+	// cancellation is set when the query should
+	// stop at the first failed statement.
+	cancellation *requestCancellation
 }
 
 func (sw *stateWorker) Run() {
+	if sw.doneCh != nil {
+		defer close(sw.doneCh)
+	}
+
 	for !sw.state.HasFinished() {
 		availableResources := sw.state.Available()
 		for resourceID := range availableResources {
@@ -146,71 +265,258 @@ func (sw *stateWorker) Run() {
 		}
 
 		availableResources = ResolveChainedValues(availableResources, sw.state.Done())
-		availableResources = ApplyEncoders(availableResources, sw.log)
+		availableResources = ApplyEncoders(availableResources, sw.log, sw.functions)
 		availableResources = MultiplexStatements(availableResources)
 		availableResources = UnwrapNoMultiplex(availableResources)
 
 		for resourceID, stmt := range availableResources {
-			resourceID, stmt := resourceID, stmt
-			go func() {
-				select {
-				case sw.requestCh <- request{ResourceIdentifier: resourceID, Statement: stmt}:
-				case <-sw.ctx.Done():
-				}
-			}()
+			sw.dispatch(resourceID, stmt)
 		}
 
 		select {
 		case result := <-sw.resultCh:
 			sw.state.UpdateDone(result.ResourceIdentifier, result.Response)
+			if sw.doneCh != nil {
+				sw.doneCh <- result
+			}
+
+			if sw.cancellation != nil && IsFailedResponse(result.Response) {
+				sw.log.Debug("cancelling query due to failed statement", "resource", result.ResourceIdentifier)
+				failed := result.ResourceIdentifier
+				sw.cancellation.cancelFor(failed)
+
+				sw.sendOutput(sw.finishUnfinished(func(stmt interface{}) interface{} {
+					return NewCancelledResponse(stmt, failed)
+				}))
+				return
+			}
 		case <-sw.ctx.Done():
+			if sw.partial {
+				sw.sendOutput(sw.finishUnfinished(NewTimedOutResponse))
+			}
 			return
 		}
 	}
 
+	sw.sendOutput(sw.state.Done())
+}
+
+// sendOutput delivers the query results. When partial results are
+// enabled the output is always awaited, even if the query timed out.
+func (sw *stateWorker) sendOutput(output domain.Resources) {
+	if sw.partial {
+		sw.outputCh <- output
+		return
+	}
+
 	select {
-	case sw.outputCh <- sw.state.Done():
+	case sw.outputCh <- output:
 	case <-sw.ctx.Done():
 	}
 }
 
-type requestWorker struct {
-	requestCh chan request
-	resultCh  chan result
-	errorCh   chan error
-	executor  Executor
-	queryCtx  restql.QueryContext
-	ctx       context.Context
+// finishUnfinished sets the statements not yet done
+// with the given response, returning all results. The
+// statements never requested are notified to the lifecycle,
+// as the requested ones are by their calls.
+func (sw *stateWorker) finishUnfinished(makeResponse func(stmt interface{}) interface{}) domain.Resources {
+	requested := sw.state.Requested()
+	for resourceID, stmt := range sw.state.Unfinished() {
+		response := makeResponse(stmt)
+		if _, found := requested[resourceID]; !found {
+			sw.notify(stmt, response)
+		}
+
+		response = unbatch(stmt, response)
+
+		sw.state.UpdateDone(resourceID, response)
+		if sw.doneCh != nil {
+			sw.doneCh <- result{ResourceIdentifier: resourceID, Response: response}
+		}
+	}
+
+	return sw.state.Done()
 }
 
-func (rw *requestWorker) Run() {
-	for {
-		select {
-		case req := <-rw.requestCh:
-			resourceID := req.ResourceIdentifier
-			statement := req.Statement
-
-			switch statement := statement.(type) {
-			case domain.Statement:
-				go func() {
-					response := rw.executor.DoStatement(rw.ctx, statement, rw.queryCtx)
-					writeResult(rw.ctx, rw.resultCh, result{ResourceIdentifier: resourceID, Response: response})
-				}()
-			case []interface{}:
-				go func() {
-					responses := rw.executor.DoMultiplexedStatement(rw.ctx, statement, rw.queryCtx)
-					writeResult(rw.ctx, rw.resultCh, result{ResourceIdentifier: resourceID, Response: responses})
-				}()
+// requestCancellation cancels the requests of a query,
+// keeping the statement whose failure caused it, so
+// calls dropped afterwards can be reported accordingly.
+type requestCancellation struct {
+	cancel context.CancelFunc
+
+	mu     sync.Mutex
+	failed domain.ResourceID
+	set    bool
+}
+
+func (rc *requestCancellation) cancelFor(failed domain.ResourceID) {
+	rc.mu.Lock()
+	rc.failed = failed
+	rc.set = true
+	rc.mu.Unlock()
+
+	rc.cancel()
+}
+
+// response returns the result of a statement dropped by the
+// cancellation, which is cancelled if due to a failed statement
+// or timed out otherwise.
+func (rc *requestCancellation) response(statement interface{}) interface{} {
+	rc.mu.Lock()
+	failed, set := rc.failed, rc.set
+	rc.mu.Unlock()
+
+	if set {
+		return NewCancelledResponse(statement, failed)
+	}
+
+	return NewTimedOutResponse(statement)
+}
+
+// dispatcher schedules the upstream calls of a query on the
+// worker pool. Multiplexed statements are split in one task per
+// call, bounded by the statement limit, and their responses are
+// kept in the same order of the statements.
+type dispatcher struct {
+	executor       Executor
+	queryCtx       restql.QueryContext
+	ctx            context.Context
+	cancellation   *requestCancellation
+	resultCh       chan result
+	submit         func(task)
+	statementLimit int
+}
+
+func (d *dispatcher) dispatch(resourceID domain.ResourceID, statement interface{}) {
+	switch statement := statement.(type) {
+	case domain.Statement:
+		d.submit(func() {
+			d.resultCh <- result{ResourceIdentifier: resourceID, Response: d.execute(statement)}
+		})
+	case []interface{}:
+		d.dispatchMultiplexed(resourceID, statement)
+	}
+}
+
+func (d *dispatcher) dispatchMultiplexed(resourceID domain.ResourceID, statements []interface{}) {
+	responses := make(domain.DoneResources, len(statements))
+
+	calls := collectCalls(responses, statements, nil)
+	if len(calls) == 0 {
+		d.resultCh <- result{ResourceIdentifier: resourceID, Response: responses}
+		return
+	}
+
+	var mu sync.Mutex
+	remaining := len(calls)
+
+	submit := newLimiter(d.statementLimit, d.submit)
+	for i, c := range calls {
+		i, c := i, c
+		submit(func() {
+			response := d.executeMultiplexed(c.statement, i)
+
+			mu.Lock()
+			c.responses[c.index] = response
+			remaining--
+			done := remaining == 0
+			mu.Unlock()
+
+			if done {
+				d.resultCh <- result{ResourceIdentifier: resourceID, Response: unbatch(statements, responses)}
 			}
-		case <-rw.ctx.Done():
-			return
+		})
+	}
+}
+
+// execute does the statement call, unless the query requests
+// were cancelled while it was waiting for a worker.
+func (d *dispatcher) execute(statement domain.Statement) interface{} {
+	if d.ctx.Err() != nil {
+		response := d.cancellation.response(statement)
+		d.notify(statement, response)
+		return response
+	}
+
+	return d.executor.DoStatement(d.ctx, statement, d.queryCtx)
+}
+
+func (d *dispatcher) executeMultiplexed(statement domain.Statement, index int) interface{} {
+	if d.ctx.Err() != nil {
+		response := d.cancellation.response(statement)
+		if dr, ok := response.(domain.DoneResource); ok {
+			d.executor.NotifyStatement(d.ctx, newMultiplexedStatementInfo(statement, index), dr)
+		}
+		return response
+	}
+
+	return d.executor.DoMultiplexedStatement(d.ctx, statement, index, d.queryCtx)
+}
+
+// notify calls the lifecycle hooks of the statement calls
+// given a response without being made. Multiplexed calls are
+// indexed in the same order used by their dispatch.
+func (d *dispatcher) notify(statement interface{}, response interface{}) {
+	if stmt, ok := statement.(domain.Statement); ok {
+		if dr, ok := response.(domain.DoneResource); ok {
+			d.executor.NotifyStatement(d.ctx, newStatementInfo(stmt), dr)
 		}
+		return
 	}
+
+	index := 0
+	d.notifyMultiplexed(statement, response, &index)
 }
 
-func writeResult(ctx context.Context, out chan result, r result) {
-	select {
-	case out <- r:
-	case <-ctx.Done():
+func (d *dispatcher) notifyMultiplexed(statement interface{}, response interface{}, index *int) {
+	switch statement := statement.(type) {
+	case domain.Statement:
+		if dr, ok := response.(domain.DoneResource); ok {
+			d.executor.NotifyStatement(d.ctx, newMultiplexedStatementInfo(statement, *index), dr)
+		}
+		*index++
+	case []interface{}:
+		responses, _ := response.(domain.DoneResources)
+		for i, stmt := range statement {
+			var r interface{}
+			if i < len(responses) {
+				r = responses[i]
+			}
+			d.notifyMultiplexed(stmt, r, index)
+		}
+	}
+}
+
+type multiplexedCall struct {
+	responses domain.DoneResources
+	index     int
+	statement domain.Statement
+}
+
+// collectCalls flattens the multiplexed statements, creating
+// the nested response lists each call result is placed in.
+func collectCalls(responses domain.DoneResources, statements []interface{}, calls []multiplexedCall) []multiplexedCall {
+	for i, stmt := range statements {
+		switch stmt := stmt.(type) {
+		case domain.Statement:
+			calls = append(calls, multiplexedCall{responses: responses, index: i, statement: stmt})
+		case []interface{}:
+			nested := make(domain.DoneResources, len(stmt))
+			responses[i] = nested
+			calls = collectCalls(nested, stmt, calls)
+		}
+	}
+
+	return calls
+}
+
+// spawner starts the runner goroutines, calling
+// the hook, when set, for each one of them.
+type spawner func()
+
+func (s spawner) spawn(fn func()) {
+	if s != nil {
+		s()
 	}
+	go fn()
 }
@@ -0,0 +1,589 @@
+package runner_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/b2wdigital/restQL-golang/v4/internal/domain"
+	"github.com/b2wdigital/restQL-golang/v4/internal/platform/plugins"
+	"github.com/b2wdigital/restQL-golang/v4/internal/runner"
+	"github.com/b2wdigital/restQL-golang/v4/pkg/restql"
+	"github.com/b2wdigital/restQL-golang/v4/test"
+)
+
+type delayedClient struct {
+	delays   map[string]time.Duration
+	statuses map[string]int
+}
+
+func (dc delayedClient) Do(ctx context.Context, request domain.HTTPRequest) (domain.HTTPResponse, error) {
+	select {
+	case <-time.After(dc.delays[request.Resource]):
+	case <-ctx.Done():
+	}
+
+	status, found := dc.statuses[request.Resource]
+	if !found {
+		status = 200
+	}
+
+	return domain.HTTPResponse{StatusCode: status, Body: map[string]interface{}{"name": request.Resource}}, nil
+}
+
+// countingClient keeps track of the highest number
+// of calls running at the same time.
+type countingClient struct {
+	delay time.Duration
+
+	mu          sync.Mutex
+	inFlight    int
+	maxInFlight int
+	goroutines  int
+}
+
+func (cc *countingClient) Do(ctx context.Context, request domain.HTTPRequest) (domain.HTTPResponse, error) {
+	cc.mu.Lock()
+	cc.inFlight++
+	if cc.inFlight > cc.maxInFlight {
+		cc.maxInFlight = cc.inFlight
+	}
+	if g := runtime.NumGoroutine(); g > cc.goroutines {
+		cc.goroutines = g
+	}
+	cc.mu.Unlock()
+
+	select {
+	case <-time.After(cc.delay):
+	case <-ctx.Done():
+	}
+
+	cc.mu.Lock()
+	cc.inFlight--
+	cc.mu.Unlock()
+
+	return domain.HTTPResponse{StatusCode: 200, Body: map[string]interface{}{"id": request.Query["id"]}}, nil
+}
+
+func (cc *countingClient) reset() {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+
+	cc.maxInFlight = 0
+	cc.goroutines = 0
+}
+
+func multiplexedQuery(items int) domain.Query {
+	ids := make([]interface{}, items)
+	for i := range ids {
+		ids[i] = fmt.Sprintf("%d", i)
+	}
+
+	return domain.Query{Statements: []domain.Statement{
+		{Method: domain.FromMethod, Resource: "hero", With: domain.Params{Values: map[string]interface{}{"id": ids}}},
+	}}
+}
+
+func TestRunnerConcurrency(t *testing.T) {
+	hero, _ := restql.NewMapping("hero", "http://hero.api/")
+	queryCtx := restql.QueryContext{
+		Mappings: map[string]restql.Mapping{"hero": hero},
+	}
+	ctx := restql.WithLogger(context.Background(), noOpLogger{})
+
+	tests := []struct {
+		name        string
+		concurrency runner.Concurrency
+		use         domain.Modifiers
+		expected    int
+	}{
+		{"should bound calls by the worker pool", runner.Concurrency{Workers: 3}, nil, 3},
+		{"should bound calls by the query limit", runner.Concurrency{Query: 5}, nil, 5},
+		{"should bound calls by the statement limit", runner.Concurrency{Statement: 4}, nil, 4},
+		{"should bound calls by the query modifier", runner.Concurrency{Query: 5}, domain.Modifiers{"max-concurrency": 2}, 2},
+		{"should apply the lowest limit", runner.Concurrency{Workers: 6, Query: 8, Statement: 7}, nil, 6},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &countingClient{delay: 5 * time.Millisecond}
+			executor := runner.NewExecutor(noOpLogger{}, client, time.Second, "", nil, plugins.NoOpLifecycle, nil)
+			r := runner.NewRunner(noOpLogger{}, executor, 5*time.Second, runner.OnTimeoutFail, tt.concurrency, nil)
+
+			query := multiplexedQuery(30)
+			query.Use = tt.use
+
+			got, err := r.ExecuteQuery(ctx, query, queryCtx)
+			test.VerifyError(t, err)
+
+			test.Equal(t, client.maxInFlight, tt.expected)
+
+			responses := got["hero"].(domain.DoneResources)
+			test.Equal(t, len(responses), 30)
+			for i, response := range responses {
+				test.Equal(t, response.(domain.DoneResource).ResponseBody, map[string]interface{}{"id": fmt.Sprintf("%d", i)})
+			}
+		})
+	}
+}
+
+func TestRunnerWarmPoolConcurrency(t *testing.T) {
+	hero, _ := restql.NewMapping("hero", "http://hero.api/")
+	queryCtx := restql.QueryContext{
+		Mappings: map[string]restql.Mapping{"hero": hero},
+	}
+	ctx := restql.WithLogger(context.Background(), noOpLogger{})
+
+	client := &countingClient{delay: 50 * time.Millisecond}
+	executor := runner.NewExecutor(noOpLogger{}, client, time.Second, "", nil, plugins.NoOpLifecycle, nil)
+	r := runner.NewRunner(noOpLogger{}, executor, 5*time.Second, runner.OnTimeoutFail, runner.Concurrency{}, nil)
+
+	_, err := r.ExecuteQuery(ctx, multiplexedQuery(1), queryCtx)
+	test.VerifyError(t, err)
+	client.reset()
+
+	_, err = r.ExecuteQuery(ctx, multiplexedQuery(10), queryCtx)
+	test.VerifyError(t, err)
+
+	test.Equal(t, client.maxInFlight, 10)
+}
+
+// batchClient answers with one item per requested
+// id, in the reverse order of the request.
+type batchClient struct {
+	mu    sync.Mutex
+	calls int
+}
+
+func (bc *batchClient) Do(ctx context.Context, request domain.HTTPRequest) (domain.HTTPResponse, error) {
+	bc.mu.Lock()
+	bc.calls++
+	bc.mu.Unlock()
+
+	ids, _ := request.Query["id"].([]interface{})
+	if len(ids) == 0 {
+		return domain.HTTPResponse{StatusCode: 500}, nil
+	}
+
+	items := make([]interface{}, len(ids))
+	for i, id := range ids {
+		items[len(ids)-1-i] = map[string]interface{}{"id": float64(id.(int)), "name": fmt.Sprintf("hero %d", id)}
+	}
+
+	return domain.HTTPResponse{StatusCode: 200, Body: items}, nil
+}
+
+func TestRunnerBatch(t *testing.T) {
+	hero, _ := restql.NewMapping("hero", "http://hero.api/")
+	sidekick, _ := restql.NewMapping("sidekick", "http://sidekick.api/")
+	queryCtx := restql.QueryContext{
+		Mappings: map[string]restql.Mapping{"hero": hero, "sidekick": sidekick},
+	}
+	ctx := restql.WithLogger(context.Background(), noOpLogger{})
+
+	client := &batchClient{}
+	executor := runner.NewExecutor(noOpLogger{}, client, time.Second, "", nil, plugins.NoOpLifecycle, nil)
+	r := runner.NewRunner(noOpLogger{}, executor, 5*time.Second, runner.OnTimeoutFail, runner.Concurrency{}, nil)
+
+	query := domain.Query{Statements: []domain.Statement{
+		{
+			Method:   domain.FromMethod,
+			Resource: "hero",
+			With:     domain.Params{Values: map[string]interface{}{"id": domain.Batch{Value: []interface{}{1, 2, 3, 4, 5}, Size: 2, Key: "id"}}},
+		},
+		{
+			Method:   domain.FromMethod,
+			Resource: "sidekick",
+			With:     domain.Params{Values: map[string]interface{}{"id": domain.Batch{Value: []interface{}{1, 2, 3}, Size: 3}}},
+		},
+	}}
+
+	got, err := r.ExecuteQuery(ctx, query, queryCtx)
+	test.VerifyError(t, err)
+
+	test.Equal(t, client.calls, 4)
+
+	heroes := got["hero"].(domain.DoneResources)
+	test.Equal(t, len(heroes), 5)
+	for i, h := range heroes {
+		test.Equal(t, h.(domain.DoneResource).ResponseBody, map[string]interface{}{"id": float64(i + 1), "name": fmt.Sprintf("hero %d", i+1)})
+	}
+
+	sidekicks := got["sidekick"].(domain.DoneResources)
+	test.Equal(t, len(sidekicks), 3)
+	test.Equal(t, sidekicks[0].(domain.DoneResource).ResponseBody, map[string]interface{}{"id": float64(3), "name": "hero 3"})
+	test.Equal(t, sidekicks[2].(domain.DoneResource).ResponseBody, map[string]interface{}{"id": float64(1), "name": "hero 1"})
+}
+
+// statementRecorder keeps the statements notified
+// to the lifecycle with the status of their result.
+type statementRecorder struct {
+	plugins.Lifecycle
+
+	mu       sync.Mutex
+	before   []restql.StatementInfo
+	after    []restql.StatementInfo
+	results  []domain.DoneResource
+	statuses map[restql.ResourceID]int
+}
+
+func (sr *statementRecorder) BeforeStatement(ctx context.Context, statement restql.StatementInfo) context.Context {
+	sr.mu.Lock()
+	defer sr.mu.Unlock()
+
+	sr.before = append(sr.before, statement)
+	return ctx
+}
+
+func (sr *statementRecorder) AfterStatement(ctx context.Context, statement restql.StatementInfo, result domain.DoneResource) context.Context {
+	sr.mu.Lock()
+	defer sr.mu.Unlock()
+
+	sr.after = append(sr.after, statement)
+	sr.results = append(sr.results, result)
+	sr.statuses[statement.ResourceID] = result.Status
+	return ctx
+}
+
+func TestRunnerStatementLifecycle(t *testing.T) {
+	hero, _ := restql.NewMapping("hero", "http://hero.api/")
+	sidekick, _ := restql.NewMapping("sidekick", "http://sidekick.api/")
+	queryCtx := restql.QueryContext{
+		Mappings: map[string]restql.Mapping{"hero": hero, "sidekick": sidekick},
+	}
+	ctx := restql.WithLogger(context.Background(), noOpLogger{})
+
+	recorder := &statementRecorder{Lifecycle: plugins.NoOpLifecycle, statuses: map[restql.ResourceID]int{}}
+	client := delayedClient{statuses: map[string]int{"sidekick": 404}}
+	executor := runner.NewExecutor(noOpLogger{}, client, time.Second, "", nil, recorder, nil)
+	r := runner.NewRunner(noOpLogger{}, executor, 5*time.Second, runner.OnTimeoutFail, runner.Concurrency{}, nil)
+
+	query := domain.Query{Statements: []domain.Statement{
+		{Method: domain.FromMethod, Resource: "hero", With: domain.Params{Values: map[string]interface{}{"id": []interface{}{1, 2}}}},
+		{Method: domain.FromMethod, Resource: "sidekick", Alias: "robin"},
+	}}
+
+	_, err := r.ExecuteQuery(ctx, query, queryCtx)
+	test.VerifyError(t, err)
+
+	sort.Slice(recorder.after, func(i, j int) bool {
+		a, b := recorder.after[i], recorder.after[j]
+		return a.ResourceID < b.ResourceID || (a.ResourceID == b.ResourceID && a.Index < b.Index)
+	})
+
+	expected := []restql.StatementInfo{
+		{ResourceID: "hero", Method: domain.FromMethod, Resource: "hero", Multiplexed: true, Index: 0},
+		{ResourceID: "hero", Method: domain.FromMethod, Resource: "hero", Multiplexed: true, Index: 1},
+		{ResourceID: "robin", Method: domain.FromMethod, Resource: "sidekick"},
+	}
+
+	test.Equal(t, len(recorder.before), 3)
+	test.Equal(t, recorder.after, expected)
+	test.Equal(t, recorder.statuses, map[restql.ResourceID]int{"hero": 200, "robin": 404})
+}
+
+func TestRunnerStreamQuery(t *testing.T) {
+	hero, _ := restql.NewMapping("hero", "http://hero.api/")
+	sidekick, _ := restql.NewMapping("sidekick", "http://sidekick.api/")
+	villain, _ := restql.NewMapping("villain", "http://villain.api/")
+
+	queryCtx := restql.QueryContext{
+		Mappings: map[string]restql.Mapping{"hero": hero, "sidekick": sidekick, "villain": villain},
+	}
+
+	query := domain.Query{Statements: []domain.Statement{
+		{Method: domain.FromMethod, Resource: "hero"},
+		{Method: domain.FromMethod, Resource: "sidekick"},
+		{Method: domain.FromMethod, Resource: "villain"},
+	}}
+
+	client := delayedClient{delays: map[string]time.Duration{
+		"hero":     10 * time.Millisecond,
+		"sidekick": 60 * time.Millisecond,
+		"villain":  120 * time.Millisecond,
+	}}
+
+	executor := runner.NewExecutor(noOpLogger{}, client, time.Second, "", nil, plugins.NoOpLifecycle, nil)
+	r := runner.NewRunner(noOpLogger{}, executor, time.Second, runner.OnTimeoutFail, runner.Concurrency{}, nil)
+
+	var mu sync.Mutex
+	var order []domain.ResourceID
+	listener := func(resourceID domain.ResourceID, response interface{}) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		order = append(order, resourceID)
+		test.Equal(t, response.(domain.DoneResource).ResponseBody, map[string]interface{}{"name": string(resourceID)})
+	}
+
+	ctx := restql.WithLogger(context.Background(), noOpLogger{})
+	resources, err := r.StreamQuery(ctx, query, queryCtx, listener)
+	test.VerifyError(t, err)
+
+	test.Equal(t, len(resources), 3)
+	test.Equal(t, order, []domain.ResourceID{"hero", "sidekick", "villain"})
+}
+
+func TestRunnerOnTimeout(t *testing.T) {
+	hero, _ := restql.NewMapping("hero", "http://hero.api/")
+	villain, _ := restql.NewMapping("villain", "http://villain.api/")
+
+	queryCtx := restql.QueryContext{
+		Mappings: map[string]restql.Mapping{"hero": hero, "villain": villain},
+	}
+
+	statements := []domain.Statement{
+		{Method: domain.FromMethod, Resource: "hero"},
+		{Method: domain.FromMethod, Resource: "villain", IgnoreErrors: true},
+	}
+
+	client := delayedClient{delays: map[string]time.Duration{
+		"hero":    10 * time.Millisecond,
+		"villain": time.Second,
+	}}
+	executor := runner.NewExecutor(noOpLogger{}, client, 5*time.Second, "", nil, plugins.NoOpLifecycle, nil)
+	ctx := restql.WithLogger(context.Background(), noOpLogger{})
+
+	expectedPartial := domain.Resources{
+		"hero": domain.DoneResource{
+			Status:       200,
+			Success:      true,
+			Method:       "GET",
+			ResponseBody: map[string]interface{}{"name": "hero"},
+		},
+		"villain": domain.DoneResource{
+			Status:       408,
+			IgnoreErrors: true,
+			ErrorKind:    domain.TimeoutErrorKind,
+			ResponseBody: "query timed out",
+		},
+	}
+
+	tests := []struct {
+		name      string
+		onTimeout string
+		use       domain.Modifiers
+		expected  domain.Resources
+		err       error
+	}{
+		{
+			"should fail query by default",
+			"",
+			domain.Modifiers{"timeout": 100},
+			nil,
+			runner.ErrQueryTimedOut,
+		},
+		{
+			"should return partial results when set on query",
+			runner.OnTimeoutFail,
+			domain.Modifiers{"timeout": 100, "on-timeout": "partial"},
+			expectedPartial,
+			nil,
+		},
+		{
+			"should return partial results when set as default",
+			runner.OnTimeoutPartial,
+			domain.Modifiers{"timeout": 100},
+			expectedPartial,
+			nil,
+		},
+		{
+			"should fail query when set on query overriding default",
+			runner.OnTimeoutPartial,
+			domain.Modifiers{"timeout": 100, "on-timeout": "fail"},
+			nil,
+			runner.ErrQueryTimedOut,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := runner.NewRunner(noOpLogger{}, executor, 5*time.Second, tt.onTimeout, runner.Concurrency{}, nil)
+			query := domain.Query{Use: tt.use, Statements: statements}
+
+			got, err := r.ExecuteQuery(ctx, query, queryCtx)
+
+			test.Equal(t, errors.Is(err, tt.err), true)
+			if tt.expected == nil {
+				test.Equal(t, got == nil, true)
+				return
+			}
+
+			got["hero"] = clearDebugging(got["hero"].(domain.DoneResource))
+			test.Equal(t, got, tt.expected)
+		})
+	}
+}
+
+func TestRunnerFailFast(t *testing.T) {
+	hero, _ := restql.NewMapping("hero", "http://hero.api/")
+	sidekick, _ := restql.NewMapping("sidekick", "http://sidekick.api/")
+	villain, _ := restql.NewMapping("villain", "http://villain.api/")
+
+	queryCtx := restql.QueryContext{
+		Mappings: map[string]restql.Mapping{"hero": hero, "sidekick": sidekick, "villain": villain},
+	}
+
+	client := delayedClient{
+		delays: map[string]time.Duration{
+			"hero":    10 * time.Millisecond,
+			"villain": 2 * time.Second,
+		},
+		statuses: map[string]int{"hero": 500},
+	}
+	executor := runner.NewExecutor(noOpLogger{}, client, 5*time.Second, "", nil, plugins.NoOpLifecycle, nil)
+	r := runner.NewRunner(noOpLogger{}, executor, 5*time.Second, runner.OnTimeoutFail, runner.Concurrency{}, nil)
+	ctx := restql.WithLogger(context.Background(), noOpLogger{})
+
+	query := domain.Query{
+		Use: domain.Modifiers{"fail-fast": true},
+		Statements: []domain.Statement{
+			{Method: domain.FromMethod, Resource: "hero"},
+			{Method: domain.FromMethod, Resource: "sidekick", With: domain.Params{Values: map[string]interface{}{"hero": domain.Chain{"hero", "name"}}}},
+			{Method: domain.FromMethod, Resource: "villain"},
+		},
+	}
+
+	start := time.Now()
+	got, err := r.ExecuteQuery(ctx, query, queryCtx)
+	test.VerifyError(t, err)
+
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("query should have been cancelled, but took %s", elapsed)
+	}
+
+	cancelled := domain.DoneResource{
+		Skipped:      true,
+		ErrorKind:    domain.CancelledErrorKind,
+		ResponseBody: "The request was cancelled due to the failure of the hero statement",
+	}
+
+	test.Equal(t, got["hero"].(domain.DoneResource).Status, 500)
+	test.Equal(t, got["sidekick"], cancelled)
+	test.Equal(t, got["villain"], cancelled)
+}
+
+func TestRunnerFailFastLifecycle(t *testing.T) {
+	hero, _ := restql.NewMapping("hero", "http://hero.api/")
+	sidekick, _ := restql.NewMapping("sidekick", "http://sidekick.api/")
+	villain, _ := restql.NewMapping("villain", "http://villain.api/")
+
+	queryCtx := restql.QueryContext{
+		Mappings: map[string]restql.Mapping{"hero": hero, "sidekick": sidekick, "villain": villain},
+	}
+
+	recorder := &statementRecorder{Lifecycle: plugins.NoOpLifecycle, statuses: map[restql.ResourceID]int{}}
+	client := delayedClient{
+		delays: map[string]time.Duration{
+			"hero":    10 * time.Millisecond,
+			"villain": 2 * time.Second,
+		},
+		statuses: map[string]int{"hero": 500},
+	}
+	executor := runner.NewExecutor(noOpLogger{}, client, 5*time.Second, "", nil, recorder, nil)
+	r := runner.NewRunner(noOpLogger{}, executor, 5*time.Second, runner.OnTimeoutFail, runner.Concurrency{Statement: 1}, nil)
+	ctx := restql.WithLogger(context.Background(), noOpLogger{})
+
+	// The second villain call waits for the first one to finish,
+	// so it is only scheduled after the query is cancelled.
+	query := domain.Query{
+		Use: domain.Modifiers{"fail-fast": true},
+		Statements: []domain.Statement{
+			{Method: domain.FromMethod, Resource: "hero"},
+			{Method: domain.FromMethod, Resource: "sidekick", With: domain.Params{Values: map[string]interface{}{"hero": domain.Chain{"hero", "name"}}}},
+			{Method: domain.FromMethod, Resource: "villain", With: domain.Params{Values: map[string]interface{}{"id": []interface{}{1, 2}}}},
+		},
+	}
+
+	_, err := r.ExecuteQuery(ctx, query, queryCtx)
+	test.VerifyError(t, err)
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		recorder.mu.Lock()
+		calls := len(recorder.after)
+		recorder.mu.Unlock()
+
+		if calls == 4 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected 4 statement calls to be notified, got %d", calls)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+
+	test.Equal(t, len(recorder.before), 4)
+
+	cancelled := domain.DoneResource{
+		Skipped:      true,
+		ErrorKind:    domain.CancelledErrorKind,
+		ResponseBody: "The request was cancelled due to the failure of the hero statement",
+	}
+
+	for i, info := range recorder.after {
+		switch {
+		case info.ResourceID == "sidekick":
+			test.Equal(t, recorder.results[i], cancelled)
+		case info.ResourceID == "villain" && info.Index == 1:
+			test.Equal(t, recorder.results[i], cancelled)
+		}
+	}
+}
+
+func clearDebugging(dr domain.DoneResource) domain.DoneResource {
+	return domain.DoneResource{
+		Status:       dr.Status,
+		Success:      dr.Success,
+		Method:       dr.Method,
+		ResponseBody: dr.ResponseBody,
+	}
+}
+
+func BenchmarkRunnerMultiplexedStatement(b *testing.B) {
+	hero, _ := restql.NewMapping("hero", "http://hero.api/")
+	queryCtx := restql.QueryContext{
+		Mappings: map[string]restql.Mapping{"hero": hero},
+	}
+	ctx := restql.WithLogger(context.Background(), noOpLogger{})
+
+	benchmarks := []struct {
+		name        string
+		concurrency runner.Concurrency
+	}{
+		{"default pool", runner.Concurrency{}},
+		{"256 workers", runner.Concurrency{Workers: 256}},
+		{"64 calls per query", runner.Concurrency{Query: 64}},
+		{"16 calls per statement", runner.Concurrency{Statement: 16}},
+	}
+
+	for _, bm := range benchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			baseline := runtime.NumGoroutine()
+			client := &countingClient{delay: time.Millisecond}
+			executor := runner.NewExecutor(noOpLogger{}, client, 5*time.Second, "", nil, plugins.NoOpLifecycle, nil)
+			r := runner.NewRunner(noOpLogger{}, executor, 30*time.Second, runner.OnTimeoutFail, bm.concurrency, nil)
+			query := multiplexedQuery(2000)
+
+			client.reset()
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				if _, err := r.ExecuteQuery(ctx, query, queryCtx); err != nil {
+					b.Fatalf("An error occurred when running the benchmark: %v", err)
+				}
+			}
+
+			b.ReportMetric(float64(client.maxInFlight), "max-calls")
+			b.ReportMetric(float64(client.goroutines-baseline), "max-goroutines")
+		})
+	}
+}
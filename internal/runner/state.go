@@ -64,6 +64,10 @@ func (s *State) canRequest(statement domain.Statement) bool {
 		}
 	}
 
+	if statement.When != nil {
+		return s.isValueResolved(statement.When.Left) && s.isValueResolved(statement.When.Right)
+	}
+
 	return true
 }
 
@@ -124,6 +128,19 @@ func (s *State) Requested() domain.Resources {
 	return s.requested
 }
 
+// Unfinished returns all Resources to be resolved or being resolved.
+func (s *State) Unfinished() domain.Resources {
+	unfinished := make(domain.Resources, len(s.todo)+len(s.requested))
+	for resourceID, stmt := range s.todo {
+		unfinished[resourceID] = stmt
+	}
+	for resourceID, stmt := range s.requested {
+		unfinished[resourceID] = stmt
+	}
+
+	return unfinished
+}
+
 // HasFinished returns true if all Resources are set as done.
 func (s *State) HasFinished() bool {
 	return len(s.todo) == 0 && len(s.requested) == 0
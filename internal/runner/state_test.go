@@ -91,6 +91,24 @@ func TestAvailableResources(t *testing.T) {
 
 		test.Equal(t, got, expected)
 	})
+
+	t.Run("should wait for dependency in when clause", func(t *testing.T) {
+		heroStatement := domain.Statement{Method: "from", Resource: "hero"}
+		sidekickStatement := domain.Statement{Method: "from", Resource: "sidekick", When: &domain.Condition{Left: domain.Chain{"hero", "type"}, Operator: "==", Right: "premium"}}
+
+		input := domain.Resources{
+			"hero":     heroStatement,
+			"sidekick": sidekickStatement,
+		}
+
+		state := runner.NewState(input)
+		test.Equal(t, state.Available(), domain.Resources{"hero": heroStatement})
+
+		state.SetAsRequest("hero")
+		state.UpdateDone("hero", nil)
+
+		test.Equal(t, state.Available(), domain.Resources{"sidekick": sidekickStatement})
+	})
 }
 
 func TestSetAsRequested(t *testing.T) {
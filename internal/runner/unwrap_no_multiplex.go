@@ -5,8 +5,8 @@ import (
 )
 
 // UnwrapNoMultiplex transform a collection of unresolved Resources
-// with `no-multiplex` functions into a collection of Resources
-// without it.
+// with `no-multiplex` or `batch` functions into a collection of
+// Resources without them.
 func UnwrapNoMultiplex(resources domain.Resources) domain.Resources {
 	for resourceID, resource := range resources {
 		resources[resourceID] = unwrapResource(resource)
@@ -59,6 +59,8 @@ func unwrapValue(value interface{}) interface{} {
 	switch value := value.(type) {
 	case domain.NoMultiplex:
 		return value.Target()
+	case domain.Batch:
+		return unwrapValue(value.Target())
 	case map[string]interface{}:
 		m := make(map[string]interface{})
 		for k, v := range value {
@@ -8,7 +8,9 @@ import (
 )
 
 var pathParamRegex = regexp.MustCompile(":([^/]+)/?")
-var urlRegex = regexp.MustCompile("(https?)://([^/]+)([^?]*)\\??(.*)")
+var urlRegex = regexp.MustCompile("(https?|plugin)://([^/]+)([^?]*)\\??(.*)")
+
+const pluginSchema = "plugin"
 
 // Mapping represents the association of a name to a REST resource url.
 // It support special syntax in the URL to provide dynamic value substitution, like:
@@ -18,6 +20,9 @@ var urlRegex = regexp.MustCompile("(https?)://([^/]+)([^?]*)\\??(.*)")
 //• Query parameters: can be defined by placing a colon (:) before an identifier in the URL query,
 // for example "http://some.api?:page", will replace ":page" by the value of the "page" parameter
 // in the query definition creating the URL "http://some.api?page=<value>".
+//• Plugin resources: can be defined by using the "plugin" schema and the
+// Resource plugin name as host, for example "plugin://feature-flags",
+// will resolve the statement through the plugin instead of an HTTP call.
 type Mapping struct {
 	resourceName  string
 	schema        string
@@ -109,6 +114,12 @@ func (m Mapping) Host() string {
 	return m.host
 }
 
+// IsPlugin returns true if the resource is
+// resolved by a Resource plugin
+func (m Mapping) IsPlugin() bool {
+	return m.schema == pluginSchema
+}
+
 // IsQueryParam returns true if the given name is a query parameter identifier
 func (m Mapping) IsQueryParam(name string) bool {
 	_, found := m.query[name]
@@ -94,3 +94,24 @@ func TestMappingsQueryWithParams(t *testing.T) {
 		})
 	}
 }
+
+func TestMappingsIsPlugin(t *testing.T) {
+	tests := []struct {
+		name     string
+		url      string
+		expected bool
+	}{
+		{"should not be plugin for http resource", "http://hero.api/hero", false},
+		{"should not be plugin for https resource", "https://hero.api/hero", false},
+		{"should be plugin for plugin resource", "plugin://feature-flags", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mapping, err := restql.NewMapping("test-resource", tt.url)
+			test.VerifyError(t, err)
+
+			test.Equal(t, mapping.IsPlugin(), tt.expected)
+		})
+	}
+}
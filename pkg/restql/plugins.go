@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"github.com/b2wdigital/restQL-golang/v4/internal/domain"
+	"github.com/valyala/fasthttp"
 	"log"
 	"net/http"
 	"net/url"
@@ -22,18 +23,25 @@ var (
 )
 
 type pluginIndex struct {
-	lifecycle []PluginInfo
-	dbPlugin  *PluginInfo
+	lifecycle   []PluginInfo
+	functions   []PluginInfo
+	resources   []PluginInfo
+	middlewares []PluginInfo
+	dbPlugin    *PluginInfo
 }
 
 // Plugin types
 const (
 	LifecyclePluginType PluginType = iota
 	DatabasePluginType
+	FunctionPluginType
+	ResourcePluginType
+	MiddlewarePluginType
 )
 
 // PluginType is an enum of possible plugin types supported by restQL,
-// currently supports LifecyclePluginType and DatabasePluginType.
+// currently supports LifecyclePluginType, DatabasePluginType,
+// FunctionPluginType, ResourcePluginType and MiddlewarePluginType.
 type PluginType int
 
 func (pt PluginType) String() string {
@@ -42,6 +50,12 @@ func (pt PluginType) String() string {
 		return "Lifecycle"
 	case DatabasePluginType:
 		return "Database"
+	case FunctionPluginType:
+		return "Function"
+	case ResourcePluginType:
+		return "Resource"
+	case MiddlewarePluginType:
+		return "Middleware"
 	default:
 		return "Unknown"
 	}
@@ -57,8 +71,8 @@ type PluginInfo struct {
 
 // RegisterPlugin indexes the provided plugin information
 // for latter usage by restQL in runtime.
-// It supports registration of multiple Lifecycle plugins
-// but only one Database plugin.
+// It supports registration of multiple Lifecycle, Function,
+// Resource and Middleware plugins but only one Database plugin.
 // In case of failure to register the plugin a warn
 // message will be printed to the os.Stdout.
 func RegisterPlugin(pluginInfo PluginInfo) {
@@ -68,6 +82,12 @@ func RegisterPlugin(pluginInfo PluginInfo) {
 	switch pluginInfo.Type {
 	case LifecyclePluginType:
 		plugins.lifecycle = append(plugins.lifecycle, pluginInfo)
+	case FunctionPluginType:
+		plugins.functions = append(plugins.functions, pluginInfo)
+	case ResourcePluginType:
+		plugins.resources = append(plugins.resources, pluginInfo)
+	case MiddlewarePluginType:
+		plugins.middlewares = append(plugins.middlewares, pluginInfo)
 	case DatabasePluginType:
 		if plugins.dbPlugin != nil {
 			log.Printf("[WARN] database plugin already registred: %s", plugins.dbPlugin.Name)
@@ -89,6 +109,33 @@ func GetLifecyclePlugins() []PluginInfo {
 	return lp
 }
 
+func GetFunctionPlugins() []PluginInfo {
+	pluginsMu.RLock()
+	defer pluginsMu.RUnlock()
+
+	fp := plugins.functions
+
+	return fp
+}
+
+func GetResourcePlugins() []PluginInfo {
+	pluginsMu.RLock()
+	defer pluginsMu.RUnlock()
+
+	rp := plugins.resources
+
+	return rp
+}
+
+func GetMiddlewarePlugins() []PluginInfo {
+	pluginsMu.RLock()
+	defer pluginsMu.RUnlock()
+
+	mp := plugins.middlewares
+
+	return mp
+}
+
 func GetDatabasePlugin() (PluginInfo, bool) {
 	pluginsMu.RLock()
 	defer pluginsMu.RUnlock()
@@ -113,6 +160,118 @@ type LifecyclePlugin interface {
 	AfterRequest(ctx context.Context, request HttpRequest, response HttpResponse, err error) context.Context
 }
 
+// RequestInterceptor is an optional interface that a lifecycle
+// plugin can implement to change the HTTP calls made to upstream
+// dependencies, like adding authentication headers, rewriting hosts
+// or signing requests.
+//
+// InterceptRequest receives a copy of the request before it is sent
+// and returns the one that will be made, or an error that fails the
+// call. InterceptResponse receives the successful response before
+// restQL handles it and returns the one that will be used.
+// When many plugins implement it, they are called in the order they
+// were registered, each one receiving the result of the previous.
+type RequestInterceptor interface {
+	InterceptRequest(ctx context.Context, request HttpRequest) (HttpRequest, error)
+	InterceptResponse(ctx context.Context, request HttpRequest, response HttpResponse) HttpResponse
+}
+
+// StatementLifecycle is an optional interface that a lifecycle
+// plugin can implement to be notified of each statement execution.
+//
+// BeforeStatement is called before the statement HTTP calls are made,
+// and its context is used by them. AfterStatement receives the final
+// result of the statement, even when it was skipped or failed.
+// A multiplexed statement fires the hooks once for each of its calls.
+// Statements not yet requested when the query times out with partial
+// results or is cancelled by fail-fast also fire both hooks, with the
+// timed out or cancelled result given in their place. Calls interrupted
+// while in flight receive the result of the interrupted call instead.
+type StatementLifecycle interface {
+	BeforeStatement(ctx context.Context, statement StatementInfo) context.Context
+	AfterStatement(ctx context.Context, statement StatementInfo, result DoneResource) context.Context
+}
+
+// QueryErrorHandler is an optional interface that a lifecycle
+// plugin can implement to be notified of queries that failed,
+// either before their execution, like on a syntax error,
+// or during it, like when it times out.
+type QueryErrorHandler interface {
+	OnQueryError(ctx context.Context, query string, err error) context.Context
+}
+
+// StatementInfo identifies a statement execution.
+// When the statement is multiplexed, Index is the
+// position of the call among the ones made for it.
+type StatementInfo struct {
+	ResourceID  ResourceID
+	Method      string
+	Resource    string
+	Multiplexed bool
+	Index       int
+}
+
+// FunctionPlugin is the interface that defines a custom function
+// applied to statement parameters with the `->` operator, like the
+// built-in `base64` and `json`. The plugin Name is the function name
+// used in queries, hence it can only contain letters, digits, `_` and `-`.
+//
+// Apply receives the parameter value, with any chained value already
+// resolved, and returns the encoded one. When it fails or panics, the
+// statement is not executed and its result is an error with the
+// `function` kind.
+type FunctionPlugin interface {
+	Plugin
+	Apply(log Logger, value interface{}) (interface{}, error)
+}
+
+// ResourcePlugin is the interface that defines a resource resolved
+// in-process instead of through an HTTP call, like feature flags or
+// lookup tables. It is used by mappings with the `plugin` schema,
+// where the host is the plugin Name, e.g. `plugin://feature-flags`.
+//
+// Resolve receives the statement parameters and headers already
+// resolved and returns the resource body and status, which are
+// handled as an upstream response, hence the body must be
+// serializable to JSON. A zero status is taken as 200, and an
+// error fails the statement like a failed HTTP call.
+type ResourcePlugin interface {
+	Plugin
+	Resolve(ctx context.Context, request ResourceRequest) (ResourceResponse, error)
+}
+
+// ResourceRequest represents a statement
+// to be resolved by a Resource plugin.
+type ResourceRequest struct {
+	Resource string
+	Method   string
+	Values   map[string]interface{}
+	Headers  map[string]string
+	Body     interface{}
+}
+
+// ResourceResponse represents the result
+// of a statement resolved by a Resource plugin.
+type ResourceResponse struct {
+	StatusCode int
+	Headers    map[string]string
+	Body       interface{}
+}
+
+// MiddlewarePlugin is the interface that defines a wrapper
+// of the restQL HTTP server handler, used for cross-cutting
+// concerns like authentication or request shaping.
+//
+// Apply is called once for each server it is applied to, when restQL
+// starts, with the next handler in the chain and returns the one that
+// will be called instead. Its position in the chain and the servers it
+// is applied to, the API by default and optionally the health, are
+// defined in the configuration. A panic or a nil handler fails the startup.
+type MiddlewarePlugin interface {
+	Plugin
+	Apply(h fasthttp.RequestHandler) fasthttp.RequestHandler
+}
+
 // TransactionRequest represents a query execution
 // transaction received through the /run-query/* endpoints.
 type TransactionRequest struct {
@@ -139,6 +298,13 @@ type HttpRequest = domain.HTTPRequest
 // by the mappings.
 type HttpResponse = domain.HTTPResponse
 
+// ResourceID represents the identifier of a statement
+// in the query result, which is its alias or resource.
+type ResourceID = domain.ResourceID
+
+// DoneResource represents the result of a statement.
+type DoneResource = domain.DoneResource
+
 // DatabasePlugin is the interface that defines
 // the obligatory operations needed from a database.
 type DatabasePlugin interface {